@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/spf13/cobra"
+
+	"github.com/soapboxsocial/soapbox/pkg/account"
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/followers"
+	"github.com/soapboxsocial/soapbox/pkg/mail"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
+	"github.com/soapboxsocial/soapbox/pkg/minis"
+	notifs "github.com/soapboxsocial/soapbox/pkg/notifications"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/redis"
+	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+	"github.com/soapboxsocial/soapbox/pkg/stories"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+var exportsCmd = &cobra.Command{
+	Use:   "exports",
+	Short: "assembles the data exports users request and emails them a link to download it",
+	RunE:  runExports,
+}
+
+func runExports(*cobra.Command, []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := sql.Open(config.DB)
+	if err != nil {
+		return errors.Wrap(err, "failed to open db")
+	}
+
+	err = migrations.Check(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "incompatible database schema")
+	}
+
+	storiesStorage, err := storage.Open(config.Stories)
+	if err != nil {
+		return errors.Wrap(err, "failed to open stories storage")
+	}
+
+	exportsStorage, err := storage.Open(config.Exports.Storage)
+	if err != nil {
+		return errors.Wrap(err, "failed to open exports storage")
+	}
+
+	rdb := redis.NewRedis(config.Redis)
+	queue := pubsub.NewQueue(rdb)
+
+	store := account.NewExportStore(rdb, exportsStorage)
+	ms := mail.NewMailService(sendgrid.NewSendClient(config.Sendgrid.Key))
+	ub := users.NewBackend(db)
+
+	exporter := account.NewExporter(&account.ExporterConfig{
+		Users:         ub,
+		Followers:     followers.NewFollowersBackend(db),
+		Blocks:        blocks.NewBackend(db),
+		Stories:       stories.NewBackend(db),
+		StoryFiles:    stories.NewFileBackend(storiesStorage),
+		RoomLogs:      backends.NewUserRoomLogBackend(db),
+		Minis:         minis.NewBackend(db),
+		Notifications: notifs.NewStorage(rdb),
+		Settings:      notifs.NewSettings(db),
+	})
+
+	go removeExpiredExports(ctx, store)
+
+	events := queue.Subscribe(pubsub.UserTopic)
+
+	go func() {
+		<-ctx.Done()
+
+		err := queue.Close()
+		if err != nil {
+			log.Printf("queue.Close err: %v", err)
+		}
+	}()
+
+	for event := range events {
+		if event.Type != pubsub.EventTypeUserExportRequested {
+			continue
+		}
+
+		handleExport(event, ub, exporter, store, ms)
+	}
+
+	log.Print("shut down")
+
+	return nil
+}
+
+func handleExport(event *pubsub.Event, ub *users.Backend, exporter *account.Exporter, store *account.ExportStore, ms *mail.Service) {
+	ctx, span := event.StartSpan("accounts.export")
+	defer span.End()
+
+	id, err := event.GetInt("id")
+	if err != nil {
+		log.Printf("event.GetInt err: %v", err)
+		return
+	}
+
+	user, err := ub.FindByID(ctx, id)
+	if err != nil {
+		log.Printf("users.FindByID err: %v", err)
+		return
+	}
+
+	if user.Email == nil || *user.Email == "" {
+		log.Printf("user %d has no email to send the export to", id)
+		return
+	}
+
+	err = export(ctx, id, *user.Email, exporter, store, ms)
+	if err != nil {
+		span.RecordError(err)
+		log.Printf("failed to export user %d err: %v", id, err)
+
+		// the user would otherwise have to wait for the cooldown to try again.
+		err := store.Release(ctx, id)
+		if err != nil {
+			log.Printf("store.Release err: %v", err)
+		}
+
+		return
+	}
+
+	log.Printf("exported user %d", id)
+}
+
+func export(ctx context.Context, id int, email string, exporter *account.Exporter, store *account.ExportStore, ms *mail.Service) error {
+	token, err := store.Create(ctx, id, func(w io.Writer) error {
+		return exporter.Export(ctx, id, w)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	url, err := store.URL(token, config.Exports.URL)
+	if err != nil {
+		return errors.Wrap(err, "failed to create download url")
+	}
+
+	return errors.Wrap(ms.SendExportEmail(email, url), "failed to send email")
+}
+
+func removeExpiredExports(ctx context.Context, store *account.ExportStore) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		err := store.RemoveExpired(ctx)
+		if err != nil {
+			log.Printf("store.RemoveExpired err: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
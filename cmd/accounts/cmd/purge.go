@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/soapboxsocial/soapbox/pkg/account"
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/redis"
+	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+	"github.com/soapboxsocial/soapbox/pkg/stories"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+var days int
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "permanently deletes accounts whose deletion grace period has passed",
+	RunE:  runPurge,
+}
+
+func init() {
+	purgeCmd.Flags().IntVarP(&days, "days", "d", int(account.DeletionGracePeriod/(24*time.Hour)), "days an account stays recoverable after deletion was requested")
+}
+
+func runPurge(*cobra.Command, []string) error {
+	ctx := context.Background()
+
+	db, err := sql.Open(config.DB)
+	if err != nil {
+		return errors.Wrap(err, "failed to open db")
+	}
+
+	err = migrations.Check(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "incompatible database schema")
+	}
+
+	storiesStorage, err := storage.Open(config.Stories)
+	if err != nil {
+		return errors.Wrap(err, "failed to open stories storage")
+	}
+
+	imagesStorage, err := storage.Open(config.Images)
+	if err != nil {
+		return errors.Wrap(err, "failed to open images storage")
+	}
+
+	repliesStorage, err := storage.Open(config.Replies)
+	if err != nil {
+		return errors.Wrap(err, "failed to open replies storage")
+	}
+
+	queue := pubsub.NewQueue(redis.NewRedis(config.Redis))
+
+	accounts := account.NewBackend(db)
+	ub := users.NewBackend(db)
+	sb := stories.NewBackend(db)
+	files := stories.NewFileBackend(storiesStorage)
+	replies := stories.NewFileBackend(repliesStorage)
+	ib := images.NewImagesBackend(imagesStorage)
+
+	before := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+
+	ids, err := accounts.GetAccountsScheduledForDeletionBefore(ctx, before)
+	if err != nil {
+		return errors.Wrap(err, "failed to get accounts scheduled for deletion")
+	}
+
+	for _, id := range ids {
+		purged, err := purge(ctx, id, before, accounts, ub, sb, files, replies, ib, queue)
+		if err != nil {
+			log.Printf("failed to purge user %d err: %v", id, err)
+			continue
+		}
+
+		if !purged {
+			log.Printf("deletion of user %d was cancelled", id)
+			continue
+		}
+
+		log.Printf("purged user %d", id)
+	}
+
+	return nil
+}
+
+// purge deletes an account and its files, it returns false if the deletion was cancelled in the meantime.
+func purge(
+	ctx context.Context,
+	id int,
+	before time.Time,
+	accounts *account.Backend,
+	ub *users.Backend,
+	sb *stories.Backend,
+	files *stories.FileBackend,
+	replies *stories.FileBackend,
+	ib *images.Backend,
+	queue *pubsub.Queue,
+) (bool, error) {
+	list, err := sb.GetStoriesForUser(ctx, id, id, 0)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get stories")
+	}
+
+	replyIDs, err := sb.GetRepliesInvolvingUser(ctx, id)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get replies")
+	}
+
+	image, err := ub.GetProfileImage(ctx, id)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get profile image")
+	}
+
+	// the user may have logged in since the accounts were listed, which cancels the deletion.
+	deleted, err := accounts.DeleteAccount(ctx, id, before)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete account")
+	}
+
+	if !deleted {
+		return false, nil
+	}
+
+	// the account is gone at this point, failing to remove a file only leaves it orphaned.
+	for _, story := range list {
+		err := files.Remove(ctx, story.ID+".aac")
+		if err != nil && err != storage.ErrNotFound {
+			log.Printf("failed to remove story %s err: %v", story.ID, err)
+		}
+	}
+
+	for _, reply := range replyIDs {
+		err := replies.Remove(ctx, reply+".aac")
+		if err != nil && err != storage.ErrNotFound {
+			log.Printf("failed to remove reply %s err: %v", reply, err)
+		}
+	}
+
+	if image != "" {
+		err = ib.Remove(ctx, image)
+		if err != nil && err != storage.ErrNotFound {
+			log.Printf("failed to remove image %s err: %v", image, err)
+		}
+	}
+
+	err = queue.Publish(ctx, pubsub.UserTopic, pubsub.NewDeleteUserEvent(id))
+	if err != nil {
+		log.Printf("queue.Publish err: %v", err)
+	}
+
+	return true, nil
+}
@@ -21,10 +21,21 @@ var (
 
 type Conf struct {
 	DB      conf.PostgresConf `mapstructure:"db"`
+	Redis   conf.RedisConf    `mapstructure:"redis"`
 	Twitter struct {
 		Key    string `mapstructure:"key"`
 		Secret string `mapstructure:"secret"`
 	} `mapstructure:"twitter"`
+	Sendgrid struct {
+		Key string `mapstructure:"key"`
+	} `mapstructure:"sendgrid"`
+	Stories conf.StorageConf `mapstructure:"stories"`
+	Images  conf.StorageConf `mapstructure:"images"`
+	Replies conf.StorageConf `mapstructure:"replies"`
+	Exports struct {
+		Storage conf.StorageConf `mapstructure:"storage"`
+		URL     string           `mapstructure:"url"`
+	} `mapstructure:"exports"`
 }
 
 func init() {
@@ -32,6 +43,9 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&file, "config", "c", "config.toml", "config file")
 
 	rootCmd.AddCommand(twitterCmd)
+	rootCmd.AddCommand(exportsCmd)
+	rootCmd.AddCommand(purgeCmd)
+	rootCmd.AddCommand(sessionsCmd)
 }
 
 // Execute executes the root command.
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/soapboxsocial/soapbox/pkg/redis"
+	"github.com/soapboxsocial/soapbox/pkg/sessions"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:   "index-sessions",
+	Short: "lists sessions opened before they were tracked per user, so they are closed when an account is deleted",
+	RunE:  runIndexSessions,
+}
+
+func runIndexSessions(*cobra.Command, []string) error {
+	sm := sessions.NewSessionManager(redis.NewRedis(config.Redis))
+
+	indexed, err := sm.IndexSessions()
+	if err != nil {
+		return errors.Wrap(err, "failed to index sessions")
+	}
+
+	log.Printf("indexed %d sessions", indexed)
+
+	return nil
+}
@@ -97,21 +97,21 @@ func update(oauth *oauth1.Config, user int, token, secret string) {
 			return
 		}
 
-		log.Printf("accounts.VerifyCredentials failed err %s\n", err)
+		log.Printf("accounts.VerifyCredentials failed err %s", err)
 	}
 
-	err = accounts.UpdateTwitterUsernameFor(user, profile.ScreenName)
+	err = accounts.UpdateTwitterUsernameFor(context.Background(), user, profile.ScreenName)
 	if err != nil {
 		log.Printf("accounts.UpdateTwitterUsernameFor err: %s", err)
 	}
 }
 
 func unlink(user int) {
-	log.Printf("removing twitter for %d\n", user)
+	log.Printf("removing twitter for %d", user)
 
-	err := accounts.UnlinkTwitterProfile(user)
+	err := accounts.UnlinkTwitterProfile(context.Background(), user)
 	if err != nil {
-		log.Printf("accounts.UnlinkTwitterProfile err %s\n", err)
+		log.Printf("accounts.UnlinkTwitterProfile err %s", err)
 	}
 
 	_, err = notifications.SendNotification(context.Background(), &pb.SendNotificationRequest{
@@ -125,6 +125,6 @@ func unlink(user int) {
 	})
 
 	if err != nil {
-		log.Printf("notifications.SendNotification err: %s\n", err)
+		log.Printf("notifications.SendNotification err: %s", err)
 	}
 }
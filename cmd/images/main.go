@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"path/filepath"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+)
+
+type Conf struct {
+	Data conf.StorageConf `mapstructure:"data"`
+}
+
+func parse() (*Conf, error) {
+	var file string
+	flag.StringVar(&file, "c", "config.toml", "config file")
+	flag.Parse()
+
+	config := &Conf{}
+	err := conf.Load(file, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// generates the variants for images that were stored before they existed.
+func main() {
+	config, err := parse()
+	if err != nil {
+		log.Fatal("failed to parse config")
+	}
+
+	blob, err := storage.Open(config.Data)
+	if err != nil {
+		log.Fatalf("failed to open storage: %s", err)
+	}
+
+	ctx := context.Background()
+	backend := images.NewImagesBackend(blob)
+
+	names, err := blob.List(ctx)
+	if err != nil {
+		log.Fatalf("failed to list images: %s", err)
+	}
+
+	stored := make(map[string]bool)
+	for _, name := range names {
+		stored[name] = true
+	}
+
+	for _, name := range names {
+		if filepath.Ext(name) != ".png" || images.IsVariant(name) || hasVariants(stored, name) {
+			continue
+		}
+
+		err := backend.CreateVariants(ctx, name)
+		if err != nil {
+			log.Printf("failed to create variants for %s err: %v", name, err)
+			continue
+		}
+
+		log.Printf("created variants for %s", name)
+	}
+}
+
+func hasVariants(stored map[string]bool, name string) bool {
+	for _, variant := range images.Variants(name) {
+		if !stored[variant.PNG] || !stored[variant.WebP] {
+			return false
+		}
+	}
+
+	return true
+}
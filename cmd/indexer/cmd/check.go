@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/spf13/cobra"
+
+	"github.com/soapboxsocial/soapbox/pkg/search"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+var check = &cobra.Command{
+	Use:   "check",
+	Short: "compares a sample of users in postgres and elasticsearch",
+	RunE:  runCheck,
+}
+
+var (
+	sample int
+	fix    bool
+)
+
+func init() {
+	check.Flags().IntVarP(&sample, "sample", "s", 1000, "amount of users to sample from each side")
+	check.Flags().BoolVar(&fix, "fix", false, "index missing and stale users, delete orphaned ones")
+}
+
+func runCheck(*cobra.Command, []string) error {
+	ctx := context.Background()
+
+	var err error
+
+	client, err = elasticsearch.NewDefaultClient()
+	if err != nil {
+		return err
+	}
+
+	db, err := sqlutil.Open(config.DB)
+	if err != nil {
+		return err
+	}
+
+	engine = search.NewElasticsearchEngine(client)
+	userBackend = users.NewBackend(db)
+
+	// users scheduled for deletion are sampled too, they must not be in the index.
+	ids, err := sampleDatabase(ctx, db, sample)
+	if err != nil {
+		return err
+	}
+
+	indexed, err := sampleIndex(ctx, sample)
+	if err != nil {
+		return err
+	}
+
+	ids = append(ids, indexed...)
+
+	documents, err := getDocuments(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	var missing, stale, orphaned int
+	checked := make(map[int]bool)
+
+	for _, id := range ids {
+		if checked[id] {
+			continue
+		}
+
+		checked[id] = true
+
+		expected, err := userBackend.GetUserForSearchEngine(ctx, id)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		actual := documents[id]
+
+		var request *indexRequest
+		switch {
+		case expected != nil && actual == nil:
+			missing++
+			log.Printf("user %d is missing", id)
+			request, err = userUpdateRequest(ctx, id)
+		case expected == nil && actual != nil:
+			orphaned++
+			log.Printf("user %d is orphaned", id)
+			request = userDeleteRequest(id)
+		case expected != nil && stable(expected) != stable(actual):
+			stale++
+			log.Printf("user %d is stale", id)
+			request, err = userUpdateRequest(ctx, id)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !fix {
+			continue
+		}
+
+		err = request.apply(ctx, engine)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("checked %d users: %d missing, %d stale, %d orphaned", len(checked), missing, stale, orphaned)
+
+	return nil
+}
+
+// stable returns the fields of a user that are compared, the follower count and room time change too often to be
+// in sync at the time of the check.
+func stable(user *users.SearchUser) users.SearchUser {
+	compared := *user
+	compared.Followers = 0
+	compared.RoomTime = 0
+
+	return compared
+}
+
+// sampleDatabase returns the IDs of up to size random users.
+func sampleDatabase(ctx context.Context, db *sql.DB, size int) ([]int, error) {
+	rows, err := db.QueryContext(ctx, "SELECT id FROM users ORDER BY random() LIMIT $1;", size)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ids := make([]int, 0, size)
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// sampleIndex returns the IDs of up to size random documents in the users index.
+func sampleIndex(ctx context.Context, size int) ([]int, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"function_score": map[string]interface{}{
+				"query":        map[string]interface{}{"match_all": map[string]interface{}{}},
+				"random_score": map[string]interface{}{},
+			},
+		},
+		"_source": false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Search(
+		client.Search.WithContext(ctx),
+		client.Search.WithIndex(search.UsersIndex),
+		client.Search.WithBody(bytes.NewReader(body)),
+		client.Search.WithSize(size),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to sample index: %s", res.String())
+	}
+
+	result := struct {
+		Hits struct {
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}{}
+
+	err = json.NewDecoder(res.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		id, err := strconv.Atoi(hit.ID)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// getDocuments returns the indexed documents for the users, users without a document are not in the result.
+func getDocuments(ctx context.Context, ids []int) (map[int]*users.SearchUser, error) {
+	documents := make(map[int]*users.SearchUser)
+	if len(ids) == 0 {
+		return documents, nil
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, strconv.Itoa(id))
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"ids": keys})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := esapi.MgetRequest{Index: search.UsersIndex, Body: bytes.NewReader(body)}.Do(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to get documents: %s", res.String())
+	}
+
+	result := struct {
+		Docs []struct {
+			Found  bool            `json:"found"`
+			Source json.RawMessage `json:"_source"`
+		} `json:"docs"`
+	}{}
+
+	err = json.NewDecoder(res.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, doc := range result.Docs {
+		if !doc.Found {
+			continue
+		}
+
+		user := &users.SearchUser{}
+		err := json.Unmarshal(doc.Source, user)
+		if err != nil {
+			return nil, err
+		}
+
+		documents[user.ID] = user
+	}
+
+	return documents, nil
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esutil"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/spf13/cobra"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/redis"
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+	"github.com/soapboxsocial/soapbox/pkg/search"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+var reindex = &cobra.Command{
+	Use:   "reindex",
+	Short: "rebuilds an index with the current mapping and swaps the alias",
+	RunE:  runReindex,
+}
+
+var (
+	index  string
+	fromDB bool
+)
+
+func init() {
+	reindex.Flags().StringVarP(&index, "index", "i", search.UsersIndex, "alias of the index to rebuild")
+	reindex.Flags().BoolVar(&fromDB, "from-db", false, "load all users from postgres instead of copying the current index")
+}
+
+func runReindex(*cobra.Command, []string) error {
+	ctx := context.Background()
+
+	var err error
+
+	client, err = elasticsearch.NewDefaultClient()
+	if err != nil {
+		return err
+	}
+
+	engine = search.NewElasticsearchEngine(client)
+	indices := search.NewIndices(client)
+
+	var db *sql.DB
+	var topic pubsub.Topic
+
+	switch index {
+	case search.UsersIndex:
+		topic = pubsub.UserTopic
+
+		db, err = sqlutil.Open(config.DB)
+		if err != nil {
+			return err
+		}
+
+		userBackend = users.NewBackend(db)
+	case search.RoomsIndex:
+		// rooms only live in memory, their index is rebuilt by the room events.
+		if fromDB {
+			return errors.New("only the users index can be loaded from postgres")
+		}
+
+		topic = pubsub.RoomTopic
+
+		conn, err := dialRooms()
+		if err != nil {
+			return err
+		}
+
+		defer conn.Close()
+
+		rooms = pb.NewRoomServiceClient(conn)
+	default:
+		return fmt.Errorf("unknown index %s", index)
+	}
+
+	// the worker keeps writing to the previous index until the alias is swapped, those changes are replayed afterwards.
+	changes, err := record(ctx, redis.NewRedis(config.Redis), topic)
+	if err != nil {
+		return err
+	}
+
+	var created string
+	var count int
+	if fromDB {
+		created, count, err = reindexFromDB(ctx, db, indices)
+	} else {
+		created, err = indices.Reindex(ctx, index)
+	}
+
+	events := changes.stop()
+	if err != nil {
+		return err
+	}
+
+	if fromDB {
+		log.Printf("indexed %d users, alias %s now points to %s", count, index, created)
+	} else {
+		log.Printf("alias %s now points to %s", index, created)
+	}
+
+	log.Printf("replayed %d changes made while reindexing", replay(ctx, events))
+
+	return nil
+}
+
+// reindexFromDB fills a new users index from postgres and points the alias to it.
+func reindexFromDB(ctx context.Context, db *sql.DB, indices *search.Indices) (string, int, error) {
+	created, err := indices.Create(ctx, index)
+	if err != nil {
+		return "", 0, err
+	}
+
+	count, err := bulkIndexUsers(ctx, db, created)
+	if err != nil {
+		return "", 0, err
+	}
+
+	err = indices.Swap(ctx, index, created)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return created, count, nil
+}
+
+// recorder collects the events published on a topic while an index is rebuilt.
+type recorder struct {
+	sub    *goredis.PubSub
+	events []*pubsub.Event
+	done   chan struct{}
+}
+
+func record(ctx context.Context, rdb *goredis.Client, topic pubsub.Topic) (*recorder, error) {
+	sub := rdb.Subscribe(ctx, string(topic))
+
+	// the subscription has to be active before the index is filled, otherwise changes could be missed.
+	_, err := sub.Receive(ctx)
+	if err != nil {
+		_ = sub.Close()
+		return nil, err
+	}
+
+	r := &recorder{sub: sub, done: make(chan struct{})}
+	go r.read()
+
+	return r, nil
+}
+
+func (r *recorder) read() {
+	defer close(r.done)
+
+	for msg := range r.sub.Channel() {
+		event := &pubsub.Event{}
+		err := json.Unmarshal([]byte(msg.Payload), event)
+		if err != nil {
+			log.Printf("failed to decode event err: %v event: %s", err, msg.Payload)
+			continue
+		}
+
+		r.events = append(r.events, event)
+	}
+}
+
+// stop ends the recording and returns the events received until then.
+func (r *recorder) stop() []*pubsub.Event {
+	err := r.sub.Close()
+	if err != nil {
+		log.Printf("failed to close subscription: %v", err)
+	}
+
+	<-r.done
+
+	return r.events
+}
+
+// replay applies the changes of events to the rebuilt index, it returns how many documents were written.
+// Documents are loaded again for every change, so the latest version is written regardless of the order of events.
+func replay(ctx context.Context, events []*pubsub.Event) int {
+	count := 0
+
+	for _, event := range events {
+		requests, err := requestsFor(event)
+		if err == errNoRequestHandler {
+			continue
+		}
+
+		if err != nil {
+			log.Printf("failed to create request: %v", err)
+			continue
+		}
+
+		for _, request := range requests {
+			if request.index != index {
+				continue
+			}
+
+			err := request.apply(ctx, engine)
+			if err != nil {
+				log.Printf("failed to execute request: %v", err)
+				continue
+			}
+
+			count++
+		}
+	}
+
+	return count
+}
+
+// bulkIndexUsers indexes every user that is not scheduled for deletion into index using the bulk API.
+func bulkIndexUsers(ctx context.Context, db *sql.DB, index string) (int, error) {
+	bi, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Client: client,
+		Index:  index,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM users WHERE deletion_scheduled_at IS NULL ORDER BY id;")
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return 0, err
+		}
+
+		user, err := userBackend.GetUserForSearchEngine(ctx, id)
+		if err == sql.ErrNoRows {
+			continue
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		body, err := json.Marshal(user)
+		if err != nil {
+			return 0, err
+		}
+
+		err = bi.Add(ctx, esutil.BulkIndexerItem{
+			Action:     "index",
+			DocumentID: strconv.Itoa(id),
+			Body:       bytes.NewReader(body),
+			OnFailure: func(_ context.Context, item esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem, err error) {
+				if err != nil {
+					log.Printf("failed to index user %s: %v", item.DocumentID, err)
+					return
+				}
+
+				log.Printf("failed to index user %s: %s", item.DocumentID, res.Error.Reason)
+			},
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	err = bi.Close(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	stats := bi.Stats()
+	if stats.NumFailed > 0 {
+		return 0, fmt.Errorf("failed to index %d users", stats.NumFailed)
+	}
+
+	return int(stats.NumIndexed), nil
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+	"github.com/soapboxsocial/soapbox/pkg/search"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
 	"github.com/soapboxsocial/soapbox/pkg/users"
 )
 
@@ -17,6 +20,8 @@ var (
 	// Used by some of the commands.
 	client      *elasticsearch.Client
 	userBackend *users.Backend
+	rooms       pb.RoomServiceClient
+	engine      search.Engine
 
 	rootCmd = &cobra.Command{
 		Use:   "indexer",
@@ -26,8 +31,11 @@ var (
 )
 
 type Conf struct {
-	Redis conf.RedisConf    `mapstructure:"redis"`
-	DB    conf.PostgresConf `mapstructure:"db"`
+	Redis   conf.RedisConf    `mapstructure:"redis"`
+	DB      conf.PostgresConf `mapstructure:"db"`
+	Rooms   conf.AddrConf     `mapstructure:"rooms"`
+	Metrics conf.AddrConf     `mapstructure:"metrics"`
+	Tracing tracing.Config    `mapstructure:"tracing"`
 }
 
 func init() {
@@ -36,6 +44,8 @@ func init() {
 
 	rootCmd.AddCommand(worker)
 	rootCmd.AddCommand(writer)
+	rootCmd.AddCommand(reindex)
+	rootCmd.AddCommand(check)
 }
 
 // Execute executes the root command.
@@ -2,19 +2,31 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"os/signal"
 	"strconv"
-	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
-	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	grpcutil "github.com/soapboxsocial/soapbox/pkg/grpc"
+	"github.com/soapboxsocial/soapbox/pkg/metrics"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/redis"
-	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+	"github.com/soapboxsocial/soapbox/pkg/search"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
 	"github.com/soapboxsocial/soapbox/pkg/users"
 )
 
@@ -29,9 +41,24 @@ var errNoRequestHandler = errors.New("no request handler for event")
 // @TODO OPTIMIZE SO WORKER ONLY UPDATES ROOM TIME.
 
 func runWorker(*cobra.Command, []string) error {
+	provider, err := tracing.Init(config.Tracing)
+	if err != nil {
+		return err
+	}
+
+	defer provider.Shutdown(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	rdb := redis.NewRedis(config.Redis)
 
-	db, err := sql.Open(config.DB)
+	db, err := sqlutil.Open(config.DB)
+	if err != nil {
+		return err
+	}
+
+	err = migrations.Check(ctx, db)
 	if err != nil {
 		return err
 	}
@@ -41,81 +68,229 @@ func runWorker(*cobra.Command, []string) error {
 		panic(err)
 	}
 
+	engine = search.NewElasticsearchEngine(client)
+
+	indices := search.NewIndices(client)
+	for _, index := range []string{search.UsersIndex, search.RoomsIndex} {
+		err = indices.Ensure(ctx, index)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := dialRooms()
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	rooms = pb.NewRoomServiceClient(conn)
+
+	metricsServer := metrics.NewServer()
+	metricsServer.AddCheck("postgres", metrics.PostgresCheck(db))
+	metricsServer.AddCheck("redis", metrics.RedisCheck(rdb))
+
+	go func() {
+		err := metricsServer.ListenAndServe(ctx, config.Metrics)
+		if err != nil {
+			log.Printf("failed to serve metrics: %v", err)
+		}
+	}()
+
 	queue := pubsub.NewQueue(rdb)
-	events := queue.Subscribe(pubsub.UserTopic)
+	events := queue.Subscribe(pubsub.UserTopic, pubsub.RoomTopic)
 
 	userBackend = users.NewBackend(db)
 
+	go func() {
+		<-ctx.Done()
+
+		err := queue.Close()
+		if err != nil {
+			log.Printf("queue.Close err: %v", err)
+		}
+	}()
+
+	var wg sync.WaitGroup
+
 	for event := range events {
-		go handleEvent(event)
+		wg.Add(1)
+
+		go func(event *pubsub.Event) {
+			defer wg.Done()
+			handleEvent(event)
+		}(event)
 	}
 
+	wg.Wait()
+
 	return nil
 }
 
+// dialRooms connects to the rooms service, which room documents are loaded from.
+func dialRooms() (*grpc.ClientConn, error) {
+	return grpc.Dial(
+		fmt.Sprintf("%s:%d", config.Rooms.Host, config.Rooms.Port),
+		grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(grpcutil.UnaryClientRequestID, grpcutil.UnaryClientTracing),
+	)
+}
+
+// indexRequest is a change to a search index, a request without a document deletes the document.
+type indexRequest struct {
+	index    string
+	id       string
+	document interface{}
+}
+
+func (r *indexRequest) operation() string {
+	if r.document == nil {
+		return "delete"
+	}
+
+	return "index"
+}
+
+func (r *indexRequest) apply(ctx context.Context, engine search.Engine) error {
+	if r.document == nil {
+		return engine.Delete(ctx, r.index, r.id)
+	}
+
+	return engine.Index(ctx, r.index, r.id, r.document)
+}
+
 func handleEvent(event *pubsub.Event) {
-	request, err := requestFor(event)
+	requests, err := requestsFor(event)
 	if err != nil {
 		if err == errNoRequestHandler {
 			return
 		}
 
-		log.Printf("failed to create request: %v\n", err)
+		log.Printf("failed to create request: %v", err)
 		return
 	}
 
-	res, err := request.Do(context.Background(), client)
-	if err != nil {
-		log.Printf("failed to execute request: %v\n", err)
-	}
+	ctx, span := event.StartSpan("indexer.handle")
+	defer span.End()
+
+	for _, request := range requests {
+		start := time.Now()
 
-	_ = res.Body.Close()
+		err := request.apply(ctx, engine)
+		if err != nil {
+			span.RecordError(err)
+			metrics.IndexingDuration.WithLabelValues(request.operation(), "error").Observe(time.Since(start).Seconds())
+			log.Printf("failed to execute request: %v", err)
+			continue
+		}
+
+		metrics.IndexingDuration.WithLabelValues(request.operation(), "ok").Observe(time.Since(start).Seconds())
+	}
 }
 
-func requestFor(event *pubsub.Event) (esapi.Request, error) {
+func requestsFor(event *pubsub.Event) ([]*indexRequest, error) {
 	switch event.Type {
-	case pubsub.EventTypeUserUpdate, pubsub.EventTypeNewUser, pubsub.EventTypeNewFollower: // @TODO think about unfollows
-		return userUpdateRequest(event)
-	case pubsub.EventTypeDeleteUser:
-		return userDeleteRequest(event)
+	case pubsub.EventTypeUserUpdate, pubsub.EventTypeNewUser, pubsub.EventTypeNewFollower, pubsub.EventTypeUnfollow,
+		pubsub.EventTypeUserDeletionCancelled:
+		return userUpdateRequests(event, "id")
+	case pubsub.EventTypeUserBlocked:
+		// blocking removes the follows between both users, so both follower counts change.
+		return userUpdateRequests(event, "id", "blocked")
+	case pubsub.EventTypeDeleteUser, pubsub.EventTypeUserDeletionScheduled:
+		id, err := event.GetInt("id")
+		if err != nil {
+			return nil, errors.New("failed to recover user ID")
+		}
+
+		return []*indexRequest{userDeleteRequest(id)}, nil
+	case pubsub.EventTypeNewRoom, pubsub.EventTypeRoomJoin, pubsub.EventTypeRoomLeft, pubsub.EventTypeRoomRename,
+		pubsub.EventTypeRoomVisibilityUpdate, pubsub.EventTypeRoomMetadataUpdate:
+		request, err := roomUpdateRequest(event)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*indexRequest{request}, nil
+	case pubsub.EventTypeRoomClosed:
+		id, ok := event.Params["id"].(string)
+		if !ok {
+			return nil, errors.New("failed to recover room ID")
+		}
+
+		return []*indexRequest{roomDeleteRequest(id)}, nil
 	default:
 		return nil, errNoRequestHandler
 	}
 }
 
-func userUpdateRequest(event *pubsub.Event) (esapi.Request, error) {
-	id, ok := event.Params["id"].(float64)
-	if !ok {
-		return nil, errors.New("failed to recover user ID")
+// userUpdateRequests returns the requests updating the users whose IDs are stored in the event under keys.
+func userUpdateRequests(event *pubsub.Event, keys ...string) ([]*indexRequest, error) {
+	requests := make([]*indexRequest, 0, len(keys))
+
+	for _, key := range keys {
+		id, err := event.GetInt(key)
+		if err != nil {
+			return nil, errors.New("failed to recover user ID")
+		}
+
+		request, err := userUpdateRequest(event.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request)
 	}
 
-	user, err := userBackend.GetUserForSearchEngine(int(id))
+	return requests, nil
+}
+
+func userUpdateRequest(ctx context.Context, id int) (*indexRequest, error) {
+	user, err := userBackend.GetUserForSearchEngine(ctx, id)
 	if err != nil {
+		// users scheduled for deletion are not found, they must not be indexed again.
+		if err == sql.ErrNoRows {
+			return userDeleteRequest(id), nil
+		}
+
 		return nil, err
 	}
 
-	body, err := json.Marshal(user)
+	return &indexRequest{index: search.UsersIndex, id: strconv.Itoa(user.ID), document: user}, nil
+}
+
+func userDeleteRequest(id int) *indexRequest {
+	return &indexRequest{index: search.UsersIndex, id: strconv.Itoa(id)}
+}
+
+func roomUpdateRequest(event *pubsub.Event) (*indexRequest, error) {
+	id, ok := event.Params["id"].(string)
+	if !ok {
+		return nil, errors.New("failed to recover room ID")
+	}
+
+	// rooms are removed from the index as soon as they are closed, events for them may still arrive afterwards.
+	response, err := rooms.GetRoom(event.Context(), &pb.GetRoomRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return roomDeleteRequest(id), nil
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	return esapi.IndexRequest{
-		Index:      "users",
-		DocumentID: strconv.Itoa(user.ID),
-		Body:       strings.NewReader(string(body)),
-		Refresh:    "true",
-	}, nil
-}
+	if response.State == nil {
+		return nil, errors.New("empty room state")
+	}
 
-func userDeleteRequest(event *pubsub.Event) (esapi.Request, error) {
-	id, err := event.GetInt("id")
-	if err != nil {
-		return nil, errors.New("failed to recover user ID")
+	// private rooms must never be searchable.
+	if response.State.Visibility == pb.Visibility_VISIBILITY_PRIVATE {
+		return roomDeleteRequest(id), nil
 	}
 
-	return esapi.DeleteRequest{
-		Index:      "users",
-		DocumentID: strconv.Itoa(id),
-		Refresh:    "true",
-	}, nil
+	return &indexRequest{index: search.RoomsIndex, id: id, document: search.NewRoom(response.State)}, nil
+}
+
+func roomDeleteRequest(id string) *indexRequest {
+	return &indexRequest{index: search.RoomsIndex, id: id}
 }
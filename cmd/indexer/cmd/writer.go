@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -29,7 +30,7 @@ func runWriter(*cobra.Command, []string) error {
 	queue := pubsub.NewQueue(rdb)
 	userBackend = users.NewBackend(db)
 
-	rows, err := db.Query("SELECT id FROM users;")
+	rows, err := db.Query("SELECT id FROM users WHERE deletion_scheduled_at IS NULL;")
 	if err != nil {
 		return err
 	}
@@ -47,7 +48,7 @@ func runWriter(*cobra.Command, []string) error {
 			log.Printf("error encountered %s", err)
 		}
 
-		err = queue.Publish(pubsub.UserTopic, pubsub.NewUserUpdateEvent(id))
+		err = queue.Publish(context.Background(), pubsub.UserTopic, pubsub.NewUserUpdateEvent(id))
 		if err != nil {
 			log.Printf("error encountered %s", err)
 		}
@@ -1,25 +1,31 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
-	"net/http"
+	"os/signal"
+	"syscall"
 
 	"google.golang.org/grpc"
 
 	"github.com/soapboxsocial/soapbox/pkg/conf"
+	grpcutil "github.com/soapboxsocial/soapbox/pkg/grpc"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
 	"github.com/soapboxsocial/soapbox/pkg/metadata"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
 	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
 	"github.com/soapboxsocial/soapbox/pkg/users"
 )
 
 type Conf struct {
-	DB     conf.PostgresConf `mapstructure:"db"`
-	GRPC   conf.AddrConf     `mapstructure:"grpc"`
-	Listen conf.AddrConf     `mapstructure:"listen"`
+	DB      conf.PostgresConf `mapstructure:"db"`
+	GRPC    conf.AddrConf     `mapstructure:"grpc"`
+	Listen  conf.AddrConf     `mapstructure:"listen"`
+	Tracing tracing.Config    `mapstructure:"tracing"`
 }
 
 func parse() (*Conf, error) {
@@ -42,14 +48,33 @@ func main() {
 		log.Fatal("failed to parse config")
 	}
 
+	provider, err := tracing.Init(config.Tracing)
+	if err != nil {
+		log.Fatalf("failed to init tracing: %s", err)
+	}
+
+	defer provider.Shutdown(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	db, err := sql.Open(config.DB)
 	if err != nil {
 		log.Fatalf("failed to open db: %s", err)
 	}
 
+	err = migrations.Check(ctx, db)
+	if err != nil {
+		log.Fatalf("incompatible database schema: %s", err)
+	}
+
 	usersBackend := users.NewBackend(db)
 
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", config.GRPC.Host, config.GRPC.Port), grpc.WithInsecure())
+	conn, err := grpc.Dial(
+		fmt.Sprintf("%s:%d", config.GRPC.Host, config.GRPC.Port),
+		grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(grpcutil.UnaryClientRequestID, grpcutil.UnaryClientTracing),
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -60,6 +85,10 @@ func main() {
 	endpoint := metadata.NewEndpoint(usersBackend, client)
 
 	router := endpoint.Router()
+	router.Use(tracing.RouteMiddleware(""))
 
-	log.Print(http.ListenAndServe(fmt.Sprintf(":%d", config.Listen.Port), httputil.CORS(router)))
+	err = httputil.ListenAndServe(ctx, fmt.Sprintf(":%d", config.Listen.Port), httputil.RequestID(tracing.Handler(httputil.CORS(router))))
+	if err != nil {
+		log.Print(err)
+	}
 }
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+var down = &cobra.Command{
+	Use:   "down",
+	Short: "reverts the last applied migration",
+	RunE:  runDown,
+}
+
+func runDown(*cobra.Command, []string) error {
+	m, err := migrator()
+	if err != nil {
+		return err
+	}
+
+	err = m.Down(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return printVersion(m)
+}
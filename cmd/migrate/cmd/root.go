@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
+	"github.com/soapboxsocial/soapbox/pkg/sql"
+)
+
+type Conf struct {
+	DB conf.PostgresConf `mapstructure:"db"`
+}
+
+var (
+	file   string
+	config *Conf
+
+	rootCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Soapbox Database Migrations",
+		Long:  "",
+	}
+)
+
+func init() {
+	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().StringVarP(&file, "config", "c", "config.toml", "config file")
+
+	rootCmd.AddCommand(up)
+	rootCmd.AddCommand(down)
+	rootCmd.AddCommand(status)
+	rootCmd.AddCommand(to)
+}
+
+// Execute executes the root command.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func initConfig() {
+	config = &Conf{}
+	err := conf.Load(file, config)
+	if err != nil {
+		log.Fatalf("failed to load config: %s", err)
+	}
+}
+
+func migrator() (*migrations.Migrator, error) {
+	db, err := sql.Open(config.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations.NewDefaultMigrator(db)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
+)
+
+var status = &cobra.Command{
+	Use:   "status",
+	Short: "lists all migrations and whether they were applied",
+	RunE:  runStatus,
+}
+
+func runStatus(*cobra.Command, []string) error {
+	m, err := migrator()
+	if err != nil {
+		return err
+	}
+
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Printf("%04d %s %s\n", status.Migration.Version, status.Migration.Name, applied)
+	}
+
+	return printVersion(m)
+}
+
+func printVersion(m *migrations.Migrator) error {
+	version, err := m.Version(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Version = %d Latest = %d\n", version, m.Latest())
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var to = &cobra.Command{
+	Use:   "to [version]",
+	Short: "applies or reverts migrations until the database is at version, 0 reverts all",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTo,
+}
+
+func runTo(_ *cobra.Command, args []string) error {
+	version, err := strconv.Atoi(args[0])
+	if err != nil {
+		return errors.Wrap(err, "invalid version")
+	}
+
+	m, err := migrator()
+	if err != nil {
+		return err
+	}
+
+	err = m.To(context.Background(), version)
+	if err != nil {
+		return err
+	}
+
+	return printVersion(m)
+}
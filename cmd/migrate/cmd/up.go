@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+var up = &cobra.Command{
+	Use:   "up",
+	Short: "applies all pending migrations",
+	RunE:  runUp,
+}
+
+func runUp(*cobra.Command, []string) error {
+	m, err := migrator()
+	if err != nil {
+		return err
+	}
+
+	err = m.Up(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return printVersion(m)
+}
@@ -25,6 +25,7 @@ func init() {
 
 	rootCmd.AddCommand(workerCmd)
 	rootCmd.AddCommand(send)
+	rootCmd.AddCommand(stats)
 }
 
 // Execute executes the root command.
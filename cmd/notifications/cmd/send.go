@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
@@ -27,6 +28,8 @@ var (
 	// The actual notification data
 	body     string
 	category string
+
+	dryRun bool
 )
 
 func init() {
@@ -36,6 +39,8 @@ func init() {
 
 	send.Flags().StringVarP(&body, "body", "", "", "notification body")
 	send.Flags().StringVarP(&category, "category", "", "", "notification category")
+
+	send.Flags().BoolVarP(&dryRun, "dry-run", "", false, "report suppressed targets without sending")
 }
 
 func runSend(*cobra.Command, []string) error {
@@ -72,8 +77,22 @@ func runSend(*cobra.Command, []string) error {
 
 	client := pb.NewNotificationServiceClient(conn)
 
-	_, err = client.SendNotification(context.TODO(), &pb.SendNotificationRequest{Targets: ids, Notification: notification})
-	return err
+	resp, err := client.SendNotification(context.TODO(), &pb.SendNotificationRequest{Targets: ids, Notification: notification, DryRun: dryRun})
+	if err != nil {
+		return err
+	}
+
+	if !dryRun {
+		return nil
+	}
+
+	for _, id := range resp.Suppressed {
+		fmt.Printf("Target (%d) would be suppressed\n", id)
+	}
+
+	fmt.Printf("Total Targets %d Suppressed %d\n", len(ids), len(resp.Suppressed))
+
+	return nil
 }
 
 func getTargets() ([]int64, error) {
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/soapboxsocial/soapbox/pkg/notifications/pb"
+)
+
+var stats = &cobra.Command{
+	Use:   "stats",
+	Short: "prints notification delivery stats",
+	RunE:  runStats,
+}
+
+var (
+	statsCategory string
+	since         time.Duration
+	until         time.Duration
+	history       bool
+)
+
+func init() {
+	stats.Flags().StringVarP(&addr, "addr", "a", "127.0.0.1:50053", "grpc address")
+	stats.Flags().StringVarP(&statsCategory, "category", "", "", "notification category, all categories if empty")
+	stats.Flags().DurationVarP(&since, "since", "", 24*time.Hour, "start of the time range, relative to now")
+	stats.Flags().DurationVarP(&until, "until", "", 0, "end of the time range, relative to now")
+	stats.Flags().BoolVarP(&history, "history", "", false, "print the recent history")
+}
+
+func runStats(*cobra.Command, []string) error {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	client := pb.NewNotificationServiceClient(conn)
+
+	now := time.Now()
+	resp, err := client.GetStats(context.TODO(), &pb.GetStatsRequest{
+		Category: statsCategory,
+		Start:    now.Add(-since).Unix(),
+		End:      now.Add(-until).Unix(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, stat := range resp.Stats {
+		fmt.Printf(
+			"%s Sent = %d Suppressed = %d Failed = %d Retried = %d Unregistered = %d\n",
+			stat.Category, stat.Sent, stat.Suppressed, stat.Failed, stat.Retried, stat.Unregistered,
+		)
+	}
+
+	if !history {
+		return nil
+	}
+
+	for _, entry := range resp.History {
+		fmt.Printf("%s %s %s %d\n", time.Unix(entry.Timestamp, 0).Format(time.RFC3339), entry.Category, entry.Outcome, entry.Count)
+	}
+
+	return nil
+}
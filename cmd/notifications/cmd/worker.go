@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"context"
 	sqldb "database/sql"
 	"fmt"
-	"log"
 	"net"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/pkg/errors"
 	"github.com/sideshow/apns2"
@@ -14,8 +17,14 @@ import (
 
 	"github.com/soapboxsocial/soapbox/pkg/analytics"
 	"github.com/soapboxsocial/soapbox/pkg/apple"
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
 	"github.com/soapboxsocial/soapbox/pkg/conf"
 	"github.com/soapboxsocial/soapbox/pkg/devices"
+	grpcutil "github.com/soapboxsocial/soapbox/pkg/grpc"
+	"github.com/soapboxsocial/soapbox/pkg/inbox"
+	"github.com/soapboxsocial/soapbox/pkg/log"
+	"github.com/soapboxsocial/soapbox/pkg/metrics"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
 	notificationsGRPC "github.com/soapboxsocial/soapbox/pkg/notifications/grpc"
 	"github.com/soapboxsocial/soapbox/pkg/notifications/handlers"
@@ -27,6 +36,7 @@ import (
 	"github.com/soapboxsocial/soapbox/pkg/rooms"
 	roompb "github.com/soapboxsocial/soapbox/pkg/rooms/pb"
 	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
 	"github.com/soapboxsocial/soapbox/pkg/users"
 )
 
@@ -34,11 +44,13 @@ type Conf struct {
 	Notifications struct {
 		Environment string `mapstructure:"environment"`
 	} `mapstructure:"notifications"`
-	APNS  conf.AppleConf    `mapstructure:"apns"`
-	Redis conf.RedisConf    `mapstructure:"redis"`
-	DB    conf.PostgresConf `mapstructure:"db"`
-	Rooms conf.AddrConf     `mapstructure:"rooms"`
-	GRPC  conf.AddrConf     `mapstructure:"GRPC"`
+	APNS    conf.AppleConf    `mapstructure:"apns"`
+	Redis   conf.RedisConf    `mapstructure:"redis"`
+	DB      conf.PostgresConf `mapstructure:"db"`
+	Rooms   conf.AddrConf     `mapstructure:"rooms"`
+	GRPC    conf.AddrConf     `mapstructure:"GRPC"`
+	Metrics conf.AddrConf     `mapstructure:"metrics"`
+	Tracing tracing.Config    `mapstructure:"tracing"`
 }
 
 var workerCmd = &cobra.Command{
@@ -48,6 +60,16 @@ var workerCmd = &cobra.Command{
 }
 
 func runWorker(*cobra.Command, []string) error {
+	provider, err := tracing.Init(config.Tracing)
+	if err != nil {
+		return errors.Wrap(err, "failed to init tracing")
+	}
+
+	defer provider.Shutdown(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	rdb := redis.NewRedis(config.Redis)
 	queue := pubsub.NewQueue(rdb)
 
@@ -56,6 +78,11 @@ func runWorker(*cobra.Command, []string) error {
 		return errors.Wrap(err, "failed to open db")
 	}
 
+	err = migrations.Check(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "incompatible database schema")
+	}
+
 	currentRoom := rooms.NewCurrentRoomBackend(db)
 
 	authKey, err := token.AuthKeyFromFile(config.APNS.Path)
@@ -80,81 +107,150 @@ func runWorker(*cobra.Command, []string) error {
 		return fmt.Errorf("unknown environment \"%s\"", config.Notifications.Environment)
 	}
 
+	conn, err := grpc.Dial(
+		fmt.Sprintf("%s:%d", config.Rooms.Host, config.Rooms.Port),
+		grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(grpcutil.UnaryClientRequestID, grpcutil.UnaryClientTracing),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to dial rooms")
+	}
+
+	defer conn.Close()
+
+	metricsServer := metrics.NewServer()
+	metricsServer.AddCheck("postgres", metrics.PostgresCheck(db))
+	metricsServer.AddCheck("redis", metrics.RedisCheck(rdb))
+	metricsServer.AddCheck("rooms", metrics.GRPCCheck(conn))
+
+	go func() {
+		err := metricsServer.ListenAndServe(ctx, config.Metrics)
+		if err != nil {
+			log.Printf("failed to serve metrics: %v", err)
+		}
+	}()
+
 	settings := notifications.NewSettings(db)
-	notificationHandlers := setupHandlers(db, config.Rooms, settings)
+	feeder := inbox.NewFeeder(inbox.NewBackend(rdb, queue), blocks.NewBackend(db))
+	notificationHandlers := setupHandlers(db, roompb.NewRoomServiceClient(conn), settings)
+
+	events := queue.Subscribe(pubsub.RoomTopic, pubsub.UserTopic, pubsub.StoryTopic)
 
-	events := queue.Subscribe(pubsub.RoomTopic, pubsub.UserTopic)
+	limiter := notifications.NewLimiter(rdb, currentRoom)
+	stats := notifications.NewStats(rdb)
 
 	dispatch := worker.NewDispatcher(5, &worker.Config{
 		APNS:      apple.NewAPNS(config.APNS.Bundle, client),
-		Limiter:   notifications.NewLimiter(rdb, currentRoom),
+		Limiter:   limiter,
 		Devices:   devices.NewBackend(db),
 		Store:     notifications.NewStorage(rdb),
 		Analytics: analytics.NewBackend(db),
+		Stats:     stats,
 	})
 	dispatch.Run()
 
+	var handling sync.WaitGroup
+	consumed := make(chan struct{})
+
 	go func() {
+		defer close(consumed)
+
 		for event := range events {
+			handling.Add(1)
+
 			go func(event *pubsub.Event) {
+				defer handling.Done()
+
+				feed(event, feeder)
+
 				h := notificationHandlers[event.Type]
 				if h == nil {
 					return
 				}
 
-				targets, err := h.Targets(event)
+				ctx, span := event.StartSpan("notifications.handle")
+				defer span.End()
+
+				targets, err := h.Targets(ctx, event)
 				if err != nil {
-					log.Printf("failed to get targets: %s", err)
+					log.Ctx(ctx).Printf("failed to get targets: %s", err)
 					return
 				}
 
 				if len(targets) == 0 {
-					log.Printf("no targets for: %d", event.Type)
+					log.Ctx(ctx).Printf("no targets for: %d", event.Type)
 					return
 				}
 
-				notification, err := h.Build(event)
+				notification, err := h.Build(ctx, event)
 				if err != nil {
-					log.Printf("failed to build notifcation: %s", err)
+					log.Ctx(ctx).Printf("failed to build notifcation: %s", err)
 					return
 				}
 
 				id, err := h.Origin(event)
 				if err != nil {
 					if err != handlers.ErrNoCreator {
-						log.Printf("failed to get origin: %s", err)
+						log.Ctx(ctx).Printf("failed to get origin: %s", err)
 					}
 				}
 
-				dispatch.Dispatch(id, targets, notification)
+				dispatch.Dispatch(ctx, id, targets, notification)
 			}(event)
 		}
 	}()
 
-	return runServer(config.GRPC, dispatch, settings)
-}
+	err = runServer(ctx, config.GRPC, notificationsGRPC.NewService(dispatch, settings, limiter, stats))
+	if err != nil {
+		return err
+	}
 
-func runServer(addr conf.AddrConf, dispatcher *worker.Dispatcher, settings *notifications.Settings) error {
-	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", addr.Host, addr.Port))
+	// the server no longer accepts calls, we stop consuming events and let the dispatcher send what is queued.
+	err = queue.Close()
 	if err != nil {
-		return errors.Wrap(err, "failed to start server")
+		log.Printf("queue.Close err: %v", err)
 	}
 
-	gs := grpc.NewServer()
-	pb.RegisterNotificationServiceServer(
-		gs,
-		notificationsGRPC.NewService(dispatcher, settings),
-	)
+	<-consumed
+	handling.Wait()
+
+	shutdown, cancel := context.WithTimeout(context.Background(), grpcutil.ShutdownTimeout)
+	defer cancel()
 
-	err = gs.Serve(lis)
+	err = dispatch.Shutdown(shutdown)
 	if err != nil {
-		log.Panicf("failed to serve: %v", err)
+		return errors.Wrap(err, "failed to finish queued notifications")
 	}
 
+	log.Println("shut down")
+
 	return nil
 }
 
-func setupHandlers(db *sqldb.DB, roomsAddr conf.AddrConf, settings *notifications.Settings) map[pubsub.EventType]handlers.Handler {
+// feed adds the items an event creates to the inboxes of the users it concerns.
+func feed(event *pubsub.Event, feeder *inbox.Feeder) {
+	ctx, span := event.StartSpan("inbox.feed")
+	defer span.End()
+
+	err := feeder.Handle(ctx, event)
+	if err != nil {
+		log.Ctx(ctx).Printf("feeder.Handle err: %v", err)
+	}
+}
+
+func runServer(ctx context.Context, addr conf.AddrConf, service *notificationsGRPC.Service) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", addr.Host, addr.Port))
+	if err != nil {
+		return errors.Wrap(err, "failed to start server")
+	}
+
+	gs := grpc.NewServer(grpc.ChainUnaryInterceptor(grpcutil.UnaryServerRequestID, grpcutil.UnaryServerTracing))
+	pb.RegisterNotificationServiceServer(gs, service)
+
+	return grpcutil.Serve(ctx, gs, lis)
+}
+
+func setupHandlers(db *sqldb.DB, metadata roompb.RoomServiceClient, settings *notifications.Settings) map[pubsub.EventType]handlers.Handler {
 	userBackend := users.NewBackend(db)
 
 	notificationHandlers := make(map[pubsub.EventType]handlers.Handler)
@@ -162,13 +258,6 @@ func setupHandlers(db *sqldb.DB, roomsAddr conf.AddrConf, settings *notification
 	followers := handlers.NewFollowerNotificationHandler(settings, userBackend)
 	notificationHandlers[followers.Type()] = followers
 
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", roomsAddr.Host, roomsAddr.Port), grpc.WithInsecure())
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	metadata := roompb.NewRoomServiceClient(conn)
-
 	creation := handlers.NewRoomCreationNotificationHandler(settings, userBackend, metadata)
 	notificationHandlers[creation.Type()] = creation
 
@@ -184,5 +273,8 @@ func setupHandlers(db *sqldb.DB, roomsAddr conf.AddrConf, settings *notification
 	recommendations := handlers.NewFollowRecommendationsNotificationHandler(settings, follows.NewBackend(db))
 	notificationHandlers[recommendations.Type()] = recommendations
 
+	replies := handlers.NewStoryReplyNotificationHandler(settings, userBackend)
+	notificationHandlers[replies.Type()] = replies
+
 	return notificationHandlers
 }
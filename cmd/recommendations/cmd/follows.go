@@ -50,7 +50,7 @@ func runFollows(*cobra.Command, []string) error {
 		log.Fatal(err)
 	}
 
-	log.Printf("found %d accounts\n", count)
+	log.Printf("found %d accounts", count)
 
 	rows, err := db.Query("SELECT user_id FROM linked_accounts")
 	if err != nil {
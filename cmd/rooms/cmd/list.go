@@ -16,8 +16,11 @@ var list = &cobra.Command{
 	RunE:  runList,
 }
 
+var user int64
+
 func init() {
 	list.Flags().StringVarP(&addr, "addr", "a", "127.0.0.1:50052", "grpc address")
+	list.Flags().Int64VarP(&user, "user", "u", 0, "list the rooms in the order they are ranked for a user")
 }
 
 func runList(*cobra.Command, []string) error {
@@ -30,7 +33,7 @@ func runList(*cobra.Command, []string) error {
 
 	client := pb.NewRoomServiceClient(conn)
 
-	resp, err := client.ListRooms(context.TODO(), &pb.ListRoomsRequest{})
+	resp, err := client.ListRooms(context.TODO(), &pb.ListRoomsRequest{UserId: user})
 	if err != nil {
 		return err
 	}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
-	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	plog "github.com/pion/ion-sfu/pkg/logger"
 	"github.com/pion/ion-sfu/pkg/middlewares/datachannel"
@@ -15,8 +18,12 @@ import (
 
 	"github.com/soapboxsocial/soapbox/pkg/blocks"
 	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/followers"
+	grpcutil "github.com/soapboxsocial/soapbox/pkg/grpc"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
 	"github.com/soapboxsocial/soapbox/pkg/http/middlewares"
+	"github.com/soapboxsocial/soapbox/pkg/metrics"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
 	"github.com/soapboxsocial/soapbox/pkg/minis"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/redis"
@@ -25,15 +32,18 @@ import (
 	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
 	"github.com/soapboxsocial/soapbox/pkg/sessions"
 	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
 	"github.com/soapboxsocial/soapbox/pkg/users"
 )
 
 type Conf struct {
-	SFU   sfu.Config        `mapstructure:"sfu"`
-	Redis conf.RedisConf    `mapstructure:"redis"`
-	DB    conf.PostgresConf `mapstructure:"db"`
-	GRPC  conf.AddrConf     `mapstructure:"grpc"`
-	API   conf.AddrConf     `mapstructure:"api"`
+	SFU     sfu.Config        `mapstructure:"sfu"`
+	Redis   conf.RedisConf    `mapstructure:"redis"`
+	DB      conf.PostgresConf `mapstructure:"db"`
+	GRPC    conf.AddrConf     `mapstructure:"grpc"`
+	API     conf.AddrConf     `mapstructure:"api"`
+	Metrics conf.AddrConf     `mapstructure:"metrics"`
+	Tracing tracing.Config    `mapstructure:"tracing"`
 }
 
 var server = &cobra.Command{
@@ -55,6 +65,16 @@ func runServer(*cobra.Command, []string) error {
 		return errors.Wrap(err, "failed to parse config")
 	}
 
+	provider, err := tracing.Init(config.Tracing)
+	if err != nil {
+		return errors.Wrap(err, "failed to init tracing")
+	}
+
+	defer provider.Shutdown(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	rdb := redis.NewRedis(config.Redis)
 
 	db, err := sql.Open(config.DB)
@@ -62,24 +82,53 @@ func runServer(*cobra.Command, []string) error {
 		return errors.Wrap(err, "failed to open db")
 	}
 
+	err = migrations.Check(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "incompatible database schema")
+	}
+
 	repository := rooms.NewRepository()
 	sm := sessions.NewSessionManager(rdb)
 	ws := rooms.NewWelcomeStore(rdb)
-	auth := rooms.NewAuth(repository, blocks.NewBackend(db))
+	blocked := blocks.NewBackend(db)
+	auth := rooms.NewAuth(repository, blocked)
+	ranker := rooms.NewRanker(repository, auth, followers.NewFollowersBackend(db), blocked)
+
+	err = metrics.RegisterRooms(repository)
+	if err != nil {
+		return errors.Wrap(err, "failed to register metrics")
+	}
+
+	metricsServer := metrics.NewServer()
+	metricsServer.AddCheck("postgres", metrics.PostgresCheck(db))
+	metricsServer.AddCheck("redis", metrics.RedisCheck(rdb))
+
+	go func() {
+		err := metricsServer.ListenAndServe(ctx, config.Metrics)
+		if err != nil {
+			log.Printf("failed to serve metrics: %v", err)
+		}
+	}()
 
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", config.GRPC.Host, config.GRPC.Port))
 	if err != nil {
 		return errors.Wrap(err, "failed to listen")
 	}
 
-	gs := grpc.NewServer()
+	gs := grpc.NewServer(grpc.ChainUnaryInterceptor(grpcutil.UnaryServerRequestID, grpcutil.UnaryServerTracing))
 	pb.RegisterRoomServiceServer(
 		gs,
-		roomGRPC.NewService(repository, ws, auth),
+		roomGRPC.NewService(repository, ws, auth, ranker),
 	)
 
+	// the grpc server and the rooms are both drained before we return.
+	var wg sync.WaitGroup
+
+	wg.Add(1)
 	go func() {
-		err = gs.Serve(lis)
+		defer wg.Done()
+
+		err := grpcutil.Serve(ctx, gs, lis)
 		if err != nil {
 			log.Panicf("failed to serve: %v", err)
 		}
@@ -96,11 +145,13 @@ func runServer(*cobra.Command, []string) error {
 	dc := s.NewDatachannel(sfu.APIChannelLabel)
 	dc.Use(datachannel.SubscriberAPI)
 
+	queue := pubsub.NewQueue(rdb)
+
 	server := rooms.NewServer(
 		s,
 		sm,
 		users.NewBackend(db),
-		pubsub.NewQueue(rdb),
+		queue,
 		rooms.NewCurrentRoomBackend(db),
 		ws,
 		repository,
@@ -108,11 +159,63 @@ func runServer(*cobra.Command, []string) error {
 		auth,
 	)
 
-	endpoint := rooms.NewEndpoint(repository, server, auth)
+	endpoint := rooms.NewEndpoint(repository, server, auth, ranker)
 	router := endpoint.Router()
+	router.Use(tracing.RouteMiddleware(""))
 
 	amw := middlewares.NewAuthenticationMiddleware(sm)
 	router.Use(amw.Middleware)
 
-	return http.ListenAndServe(fmt.Sprintf(":%d", config.API.Port), httputil.CORS(router))
+	events := queue.Subscribe(pubsub.UserTopic)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// accounts scheduled for deletion disappear from rooms right away.
+		for event := range events {
+			if event.Type != pubsub.EventTypeUserDeletionScheduled {
+				continue
+			}
+
+			user, err := event.GetInt("id")
+			if err != nil {
+				log.Printf("failed to get user from event: %v", err)
+				continue
+			}
+
+			server.RemoveUser(user)
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		<-ctx.Done()
+
+		err := queue.Close()
+		if err != nil {
+			log.Printf("queue.Close err: %v", err)
+		}
+
+		shutdown, cancel := context.WithTimeout(context.Background(), httputil.ShutdownTimeout)
+		defer cancel()
+
+		err = server.Shutdown(shutdown)
+		if err != nil {
+			log.Printf("failed to close rooms: %v", err)
+		}
+	}()
+
+	err = httputil.ListenAndServe(ctx, fmt.Sprintf(":%d", config.API.Port), httputil.RequestID(tracing.Handler(httputil.CORS(metrics.InstrumentHandler("/v1/rooms", router)))))
+	if err != nil {
+		return err
+	}
+
+	wg.Wait()
+
+	log.Print("shut down")
+
+	return nil
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+)
+
+type Conf struct {
+	Copy []struct {
+		From conf.StorageConf `mapstructure:"from"`
+		To   conf.StorageConf `mapstructure:"to"`
+	} `mapstructure:"copy"`
+}
+
+func parse() (*Conf, error) {
+	var file string
+	flag.StringVar(&file, "c", "config.toml", "config file")
+	flag.Parse()
+
+	config := &Conf{}
+	err := conf.Load(file, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// copies existing files between storages, for example when moving from local directories to a bucket.
+func main() {
+	config, err := parse()
+	if err != nil {
+		log.Fatal("failed to parse config")
+	}
+
+	ctx := context.Background()
+
+	for _, c := range config.Copy {
+		src, err := storage.Open(c.From)
+		if err != nil {
+			log.Fatalf("failed to open source storage: %s", err)
+		}
+
+		dst, err := storage.Open(c.To)
+		if err != nil {
+			log.Fatalf("failed to open destination storage: %s", err)
+		}
+
+		count, err := storage.Copy(ctx, src, dst)
+		if err != nil {
+			log.Fatalf("failed to copy %s after %d files: %s", c.From.Path, count, err)
+		}
+
+		log.Printf("copied %d files from %s", count, c.From.Path)
+	}
+}
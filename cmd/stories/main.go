@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"time"
@@ -9,14 +10,14 @@ import (
 
 	"github.com/soapboxsocial/soapbox/pkg/conf"
 	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
 	"github.com/soapboxsocial/soapbox/pkg/stories"
 )
 
 type Conf struct {
-	Data struct {
-		Path string `mapstructure:"path"`
-	} `mapstructure:"data"`
-	DB conf.PostgresConf `mapstructure:"db"`
+	Data    conf.StorageConf  `mapstructure:"data"`
+	Replies conf.StorageConf  `mapstructure:"replies"`
+	DB      conf.PostgresConf `mapstructure:"db"`
 }
 
 func parse() (*Conf, error) {
@@ -44,20 +45,45 @@ func main() {
 		log.Fatalf("failed to open db: %s", err)
 	}
 
+	blob, err := storage.Open(config.Data)
+	if err != nil {
+		log.Fatalf("failed to open storage: %s", err)
+	}
+
+	repliesBlob, err := storage.Open(config.Replies)
+	if err != nil {
+		log.Fatalf("failed to open replies storage: %s", err)
+	}
+
 	backend := stories.NewBackend(db)
-	files := stories.NewFileBackend(config.Data.Path)
+	files := stories.NewFileBackend(blob)
+	replies := stories.NewFileBackend(repliesBlob)
 
+	ctx := context.Background()
 	now := time.Now().Unix()
 
-	ids, err := backend.DeleteExpired(now)
+	// replies are deleted first, they would otherwise be removed along with their story without us learning their IDs.
+	replyIDs, err := backend.DeleteExpiredReplies(ctx, now)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, id := range replyIDs {
+		err := replies.Remove(ctx, id+".aac")
+		if err != nil {
+			log.Printf("replies.Remove err: %v", err)
+		}
+	}
+
+	ids, err := backend.DeleteExpired(ctx, now)
 	if err != nil {
 		panic(err)
 	}
 
 	for _, id := range ids {
-		err := files.Remove(id + ".aac")
+		err := files.Remove(ctx, id+".aac")
 		if err != nil {
-			log.Printf("files.Remove err: %v\n", err)
+			log.Printf("files.Remove err: %v", err)
 		}
 	}
 }
@@ -1,32 +1,56 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/dukex/mixpanel"
 
 	"github.com/soapboxsocial/soapbox/pkg/activeusers"
 	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/metrics"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
+	"github.com/soapboxsocial/soapbox/pkg/presence"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/redis"
 	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
 	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
 	"github.com/soapboxsocial/soapbox/pkg/tracking/trackers"
 )
 
 type Conf struct {
 	Trackers struct {
-		RoomTimeLog bool `mapstructure:"roomtimelog"`
-		Mixpanel    bool `mapstructure:"mixpanel"`
-		LastActive  bool `mapstructure:"lastactive"`
+		RoomTimeLog  bool `mapstructure:"roomtimelog"`
+		Mixpanel     bool `mapstructure:"mixpanel"`
+		LastActive   bool `mapstructure:"lastactive"`
+		Presence     bool `mapstructure:"presence"`
+		RoomSessions bool `mapstructure:"roomsessions"`
+		Files        bool `mapstructure:"files"`
+		EventLog     bool `mapstructure:"eventlog"`
 	} `mapstructure:"trackers"`
 	Mixpanel struct {
-		Token string `mapstructure:"token"`
-		URL   string `mapstructure:"url"`
+		Token  string          `mapstructure:"token"`
+		URL    string          `mapstructure:"url"`
+		Filter trackers.Filter `mapstructure:"filter"`
 	} `mapstructure:"mixpanel"`
-	Redis conf.RedisConf    `mapstructure:"redis"`
-	DB    conf.PostgresConf `mapstructure:"db"`
+	Files struct {
+		Path    string          `mapstructure:"path"`
+		Rotate  time.Duration   `mapstructure:"rotate"`
+		MaxSize int64           `mapstructure:"max-size"`
+		Filter  trackers.Filter `mapstructure:"filter"`
+	} `mapstructure:"files"`
+	EventLog struct {
+		Filter trackers.Filter `mapstructure:"filter"`
+	} `mapstructure:"eventlog"`
+	Redis   conf.RedisConf    `mapstructure:"redis"`
+	DB      conf.PostgresConf `mapstructure:"db"`
+	Metrics conf.AddrConf     `mapstructure:"metrics"`
+	Tracing tracing.Config    `mapstructure:"tracing"`
 }
 
 func parse() (*Conf, error) {
@@ -49,6 +73,16 @@ func main() {
 		log.Fatal("failed to parse config")
 	}
 
+	provider, err := tracing.Init(config.Tracing)
+	if err != nil {
+		log.Fatalf("failed to init tracing: %s", err)
+	}
+
+	defer provider.Shutdown(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	rdb := redis.NewRedis(config.Redis)
 	queue := pubsub.NewQueue(rdb)
 
@@ -57,11 +91,32 @@ func main() {
 		log.Fatalf("failed to open db: %s", err)
 	}
 
+	err = migrations.Check(ctx, db)
+	if err != nil {
+		log.Fatalf("incompatible database schema: %s", err)
+	}
+
+	metricsServer := metrics.NewServer()
+	metricsServer.AddCheck("postgres", metrics.PostgresCheck(db))
+	metricsServer.AddCheck("redis", metrics.RedisCheck(rdb))
+
+	go func() {
+		err := metricsServer.ListenAndServe(ctx, config.Metrics)
+		if err != nil {
+			log.Printf("failed to serve metrics: %v", err)
+		}
+	}()
+
 	t := make([]trackers.Tracker, 0)
 
 	if config.Trackers.Mixpanel {
 		client := mixpanel.New(config.Mixpanel.Token, config.Mixpanel.URL)
-		mt := trackers.NewMixpanelTracker(client)
+
+		mt, err := trackers.NewFilteredTracker(trackers.NewMixpanelTracker(client), config.Mixpanel.Filter)
+		if err != nil {
+			log.Fatalf("invalid mixpanel filter: %s", err)
+		}
+
 		t = append(t, mt)
 	}
 
@@ -71,24 +126,104 @@ func main() {
 		t = append(t, rt)
 	}
 
+	// room sessions are summarised from the room logs, so they have to be tracked after them.
+	if config.Trackers.RoomSessions {
+		backend := backends.NewRoomSessionBackend(db)
+		t = append(t, trackers.NewRoomSessionTracker(backend, rdb))
+	}
+
 	if config.Trackers.LastActive {
 		backend := activeusers.NewBackend(db)
 		at := trackers.NewRecentlyActiveTracker(backend, redis.NewTimeoutStore(rdb))
 		t = append(t, at)
 	}
 
+	if config.Trackers.Presence {
+		backend := presence.NewBackend(rdb, db, queue)
+		t = append(t, trackers.NewPresenceTracker(backend))
+
+		go expirePresence(ctx, backend)
+	}
+
+	if config.Trackers.Files {
+		files, err := trackers.NewFileTracker(config.Files.Path, config.Files.Rotate, config.Files.MaxSize)
+		if err != nil {
+			log.Fatalf("failed to create file tracker: %s", err)
+		}
+
+		defer func() {
+			err := files.Close()
+			if err != nil {
+				log.Printf("files.Close err: %v", err)
+			}
+		}()
+
+		ft, err := trackers.NewFilteredTracker(files, config.Files.Filter)
+		if err != nil {
+			log.Fatalf("invalid files filter: %s", err)
+		}
+
+		t = append(t, ft)
+	}
+
+	if config.Trackers.EventLog {
+		et, err := trackers.NewFilteredTracker(
+			trackers.NewEventLogTracker(backends.NewEventLogBackend(db)),
+			config.EventLog.Filter,
+		)
+		if err != nil {
+			log.Fatalf("invalid eventlog filter: %s", err)
+		}
+
+		t = append(t, et)
+	}
+
 	events := queue.Subscribe(pubsub.RoomTopic, pubsub.UserTopic, pubsub.StoryTopic)
 
+	go func() {
+		<-ctx.Done()
+
+		err := queue.Close()
+		if err != nil {
+			log.Printf("queue.Close err: %v", err)
+		}
+	}()
+
 	for evt := range events {
+		spanCtx, span := evt.StartSpan("tracking.handle")
+
 		for _, tracker := range t {
 			if !tracker.CanTrack(evt) {
 				continue
 			}
 
-			err := tracker.Track(evt)
+			err := tracker.Track(spanCtx, evt)
 			if err != nil {
+				span.RecordError(err)
 				log.Printf("tacker.Track err %v", err)
 			}
 		}
+
+		span.End()
+	}
+
+	log.Print("shut down")
+}
+
+// expirePresence periodically takes users offline that have not been active for a while.
+func expirePresence(ctx context.Context, backend *presence.Backend) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := backend.Expire(ctx, time.Now().Add(-presence.Timeout))
+			if err != nil {
+				log.Printf("backend.Expire err: %v", err)
+			}
+		}
 	}
 }
@@ -0,0 +1,9 @@
+// Package db contains the database schema as a list of versioned migrations that are embedded into the binaries.
+package db
+
+import "embed"
+
+// Migrations contains the up and down migrations, named "<version>_<name>.<up|down>.sql".
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,28 @@
+DROP TRIGGER IF EXISTS delete_follow_recommendations_trigger ON followers;
+DROP TRIGGER IF EXISTS insert_last_follow_recommended_trigger ON users;
+DROP TRIGGER IF EXISTS insert_notification_settings_trigger ON users;
+
+DROP FUNCTION IF EXISTS delete_follow_recommendations();
+DROP FUNCTION IF EXISTS insert_last_follow_recommended();
+DROP FUNCTION IF EXISTS insert_notification_settings();
+DROP FUNCTION IF EXISTS update_user_active_times(INT, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS update_current_rooms(INT, VARCHAR);
+
+DROP TABLE IF EXISTS last_follow_recommended;
+DROP TABLE IF EXISTS follow_recommendations;
+DROP TABLE IF EXISTS notification_analytics;
+DROP TABLE IF EXISTS notification_settings;
+DROP TABLE IF EXISTS user_active_times;
+DROP TABLE IF EXISTS user_room_logs;
+DROP TABLE IF EXISTS mini_scores;
+DROP TABLE IF EXISTS minis;
+DROP TABLE IF EXISTS current_rooms;
+DROP TABLE IF EXISTS mini_developers;
+DROP TABLE IF EXISTS blocks;
+DROP TABLE IF EXISTS story_reactions;
+DROP TABLE IF EXISTS stories;
+DROP TABLE IF EXISTS linked_accounts;
+DROP TABLE IF EXISTS devices;
+DROP TABLE IF EXISTS followers;
+DROP TABLE IF EXISTS apple_authentication;
+DROP TABLE IF EXISTS users;
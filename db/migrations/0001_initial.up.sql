@@ -1,4 +1,7 @@
-SET timezone = 'Europe/Zurich';
+-- The initial schema, it is written so that it can also be applied to databases that were created before migrations existed.
+
+-- LOCAL scopes the timezone to the migration transaction, so it does not leak into the connection pool.
+SET LOCAL timezone = 'Europe/Zurich';
 
 CREATE TABLE IF NOT EXISTS users (
     id SERIAL PRIMARY KEY,
@@ -10,8 +13,8 @@ CREATE TABLE IF NOT EXISTS users (
     joined TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
-CREATE UNIQUE INDEX idx_email ON users (email);
-CREATE UNIQUE INDEX idx_username ON users (username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email ON users (email);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_username ON users (username);
 
 CREATE TABLE IF NOT EXISTS apple_authentication (
     user_id INT NOT NULL,
@@ -19,7 +22,7 @@ CREATE TABLE IF NOT EXISTS apple_authentication (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_apple_authentication ON apple_authentication (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_apple_authentication ON apple_authentication (user_id);
 
 CREATE TABLE IF NOT EXISTS followers (
     follower INT NOT NULL,
@@ -48,8 +51,8 @@ CREATE TABLE IF NOT EXISTS linked_accounts (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_profiles ON linked_accounts (provider, profile_id);
-CREATE UNIQUE INDEX idx_provider ON linked_accounts (provider, user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_profiles ON linked_accounts (provider, profile_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_provider ON linked_accounts (provider, user_id);
 
 CREATE TABLE IF NOT EXISTS stories (
     id VARCHAR(256) PRIMARY KEY,
@@ -59,7 +62,7 @@ CREATE TABLE IF NOT EXISTS stories (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_stories ON stories (id, user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stories ON stories (id, user_id);
 
 CREATE TABLE IF NOT EXISTS story_reactions (
     story_id VARCHAR(256) NOT NULL,
@@ -69,7 +72,7 @@ CREATE TABLE IF NOT EXISTS story_reactions (
     FOREIGN KEY (story_id) REFERENCES stories(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_stories_react ON story_reactions (story_id, user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stories_react ON story_reactions (story_id, user_id);
 
 CREATE TABLE IF NOT EXISTS blocks (
     user_id INT NOT NULL,
@@ -78,14 +81,14 @@ CREATE TABLE IF NOT EXISTS blocks (
     FOREIGN KEY (blocked) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_blocks ON blocks (user_id, blocked);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blocks ON blocks (user_id, blocked);
 
 CREATE TABLE IF NOT EXISTS mini_developers (
     id SERIAL PRIMARY KEY,
     name VARCHAR(100) NOT NULL
 );
 
-CREATE UNIQUE INDEX idx_mini_developers_name ON mini_developers (name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mini_developers_name ON mini_developers (name);
 
 CREATE TABLE IF NOT EXISTS current_rooms (
     user_id INT NOT NULL,
@@ -93,9 +96,9 @@ CREATE TABLE IF NOT EXISTS current_rooms (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE INDEX idx_current_rooms ON current_rooms (room);
-CREATE UNIQUE INDEX idx_current_rooms_user_id ON current_rooms (user_id, room);
-CREATE UNIQUE INDEX idx_current_rooms_user ON current_rooms (user_id);
+CREATE INDEX IF NOT EXISTS idx_current_rooms ON current_rooms (room);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_current_rooms_user_id ON current_rooms (user_id, room);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_current_rooms_user ON current_rooms (user_id);
 
 CREATE OR REPLACE FUNCTION update_current_rooms(id INT, room_id VARCHAR(27))
     RETURNS VOID
@@ -124,8 +127,10 @@ CREATE TABLE IF NOT EXISTS minis (
 );
 
 -- Inserting apps
-INSERT INTO mini_developers (name) VALUES ('Soapbox');
-INSERT INTO minis (name, image, slug, size, developer_id) VALUES ('Polls', '', '/polls', 1, 1);
+INSERT INTO mini_developers (name) VALUES ('Soapbox') ON CONFLICT DO NOTHING;
+INSERT INTO minis (name, image, slug, size, developer_id)
+    SELECT 'Polls', '', '/polls', 1, id FROM mini_developers
+    WHERE name = 'Soapbox' AND NOT EXISTS (SELECT 1 FROM minis WHERE slug = '/polls');
 
 CREATE TABLE IF NOT EXISTS mini_scores (
     room VARCHAR(27) NOT NULL,
@@ -137,7 +142,7 @@ CREATE TABLE IF NOT EXISTS mini_scores (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_mini_scores ON mini_scores (user_id, mini_id, room, time);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mini_scores ON mini_scores (user_id, mini_id, room, time);
 
 CREATE TABLE IF NOT EXISTS user_room_logs (
     user_id INT NOT NULL,
@@ -149,7 +154,7 @@ CREATE TABLE IF NOT EXISTS user_room_logs (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_user_room_logs_user_join ON user_room_logs (user_id, room, join_time);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_room_logs_user_join ON user_room_logs (user_id, room, join_time);
 
 CREATE TABLE IF NOT EXISTS user_active_times (
     user_id INT NOT NULL,
@@ -157,7 +162,7 @@ CREATE TABLE IF NOT EXISTS user_active_times (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_user_active_times ON user_active_times (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_active_times ON user_active_times (user_id);
 
 CREATE OR REPLACE FUNCTION update_user_active_times(id INT, active TIMESTAMPTZ)
     RETURNS VOID
@@ -191,6 +196,7 @@ CREATE OR REPLACE FUNCTION insert_notification_settings() RETURNS TRIGGER AS
     $notification_settings$
     language plpgsql;
 
+DROP TRIGGER IF EXISTS insert_notification_settings_trigger ON users;
 CREATE TRIGGER insert_notification_settings_trigger
     AFTER INSERT ON users
     FOR EACH ROW
@@ -208,7 +214,7 @@ CREATE TABLE IF NOT EXISTS notification_analytics (
     FOREIGN KEY (origin) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_notification_analytics ON notification_analytics (id, target);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_analytics ON notification_analytics (id, target);
 
 CREATE TABLE IF NOT EXISTS follow_recommendations (
     user_id INT NOT NULL,
@@ -218,7 +224,7 @@ CREATE TABLE IF NOT EXISTS follow_recommendations (
     FOREIGN KEY (recommendation) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_follow_recommendations ON follow_recommendations (user_id, recommendation);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_follow_recommendations ON follow_recommendations (user_id, recommendation);
 
 CREATE TABLE IF NOT EXISTS last_follow_recommended (
     user_id INT NOT NULL,
@@ -226,7 +232,7 @@ CREATE TABLE IF NOT EXISTS last_follow_recommended (
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
-CREATE UNIQUE INDEX idx_last_follow_recommended ON last_follow_recommended (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_last_follow_recommended ON last_follow_recommended (user_id);
 
 CREATE OR REPLACE FUNCTION insert_last_follow_recommended() RETURNS TRIGGER AS
     $last_follow_recommended$
@@ -237,6 +243,7 @@ CREATE OR REPLACE FUNCTION insert_last_follow_recommended() RETURNS TRIGGER AS
     $last_follow_recommended$
     language plpgsql;
 
+DROP TRIGGER IF EXISTS insert_last_follow_recommended_trigger ON users;
 CREATE TRIGGER insert_last_follow_recommended_trigger
     AFTER INSERT ON users
     FOR EACH ROW
@@ -251,6 +258,7 @@ CREATE OR REPLACE FUNCTION delete_follow_recommendations() RETURNS TRIGGER AS
     $follow_recommendations$
 language plpgsql;
 
+DROP TRIGGER IF EXISTS delete_follow_recommendations_trigger ON followers;
 CREATE TRIGGER delete_follow_recommendations_trigger
     AFTER INSERT ON followers
     FOR EACH ROW
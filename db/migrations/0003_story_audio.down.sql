@@ -0,0 +1,3 @@
+ALTER TABLE stories DROP COLUMN IF EXISTS peaks;
+
+ALTER TABLE stories DROP COLUMN IF EXISTS duration;
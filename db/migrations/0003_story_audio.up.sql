@@ -0,0 +1,3 @@
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS duration INT NOT NULL DEFAULT 0;
+
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS peaks JSONB NOT NULL DEFAULT '[]';
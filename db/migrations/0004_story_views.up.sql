@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS story_views (
+    story_id VARCHAR(256) NOT NULL,
+    user_id INT NOT NULL,
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (story_id) REFERENCES stories(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_story_views ON story_views (story_id, user_id);
+
+CREATE INDEX IF NOT EXISTS idx_story_views_viewed_at ON story_views (story_id, viewed_at DESC, user_id DESC);
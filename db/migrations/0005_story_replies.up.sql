@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS story_replies (
+    id VARCHAR(256) PRIMARY KEY,
+    story_id VARCHAR(256) NOT NULL,
+    user_id INT NOT NULL,
+    duration INT NOT NULL,
+    peaks JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (story_id) REFERENCES stories(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_story_replies_created_at ON story_replies (story_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_story_replies_user_id ON story_replies (user_id);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS presence_settings (
+    user_id INT PRIMARY KEY,
+    appear_offline BOOLEAN NOT NULL DEFAULT FALSE,
+    hide_room BOOLEAN NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS room_sessions (
+    room VARCHAR(27) PRIMARY KEY,
+    host INT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    visibility VARCHAR(7) NOT NULL,
+    started TIMESTAMPTZ NOT NULL,
+    ended TIMESTAMPTZ NOT NULL,
+    peak_members INT NOT NULL DEFAULT 0,
+    unique_listeners INT NOT NULL DEFAULT 0,
+    average_listen_time INT NOT NULL DEFAULT 0,
+    new_followers INT NOT NULL DEFAULT 0,
+    reactions INT NOT NULL DEFAULT 0,
+    CHECK (visibility IN ('public', 'private')),
+    FOREIGN KEY (host) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_sessions_host_ended ON room_sessions (host, ended DESC);
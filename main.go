@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	signinwithapple "github.com/Timothylock/go-signin-with-apple/apple"
 	"github.com/dghubble/oauth1"
@@ -23,15 +26,20 @@ import (
 	"github.com/soapboxsocial/soapbox/pkg/conf"
 	"github.com/soapboxsocial/soapbox/pkg/devices"
 	"github.com/soapboxsocial/soapbox/pkg/followers"
+	grpcutil "github.com/soapboxsocial/soapbox/pkg/grpc"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
 	"github.com/soapboxsocial/soapbox/pkg/http/middlewares"
 	"github.com/soapboxsocial/soapbox/pkg/images"
+	"github.com/soapboxsocial/soapbox/pkg/inbox"
 	"github.com/soapboxsocial/soapbox/pkg/linkedaccounts"
 	"github.com/soapboxsocial/soapbox/pkg/login"
 	"github.com/soapboxsocial/soapbox/pkg/mail"
 	"github.com/soapboxsocial/soapbox/pkg/me"
+	"github.com/soapboxsocial/soapbox/pkg/metrics"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
 	"github.com/soapboxsocial/soapbox/pkg/minis"
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
+	"github.com/soapboxsocial/soapbox/pkg/presence"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/recommendations/follows"
 	"github.com/soapboxsocial/soapbox/pkg/redis"
@@ -39,7 +47,10 @@ import (
 	"github.com/soapboxsocial/soapbox/pkg/search"
 	"github.com/soapboxsocial/soapbox/pkg/sessions"
 	"github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
 	"github.com/soapboxsocial/soapbox/pkg/stories"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
 	"github.com/soapboxsocial/soapbox/pkg/users"
 )
 
@@ -52,16 +63,22 @@ type Conf struct {
 		Key string `mapstructure:"key"`
 	} `mapstructure:"sendgrid"`
 	CDN struct {
-		Images  string `mapstructure:"images"`
-		Stories string `mapstructure:"stories"`
+		Images  conf.StorageConf `mapstructure:"images"`
+		Stories conf.StorageConf `mapstructure:"stories"`
 	} `mapstructure:"cdn"`
-	Apple  conf.AppleConf    `mapstructure:"apple"`
-	Redis  conf.RedisConf    `mapstructure:"redis"`
-	DB     conf.PostgresConf `mapstructure:"db"`
-	GRPC   conf.AddrConf     `mapstructure:"grpc"`
-	Listen conf.AddrConf     `mapstructure:"listen"`
-	Login  login.Config      `mapstructure:"login"`
-	Minis  []struct {
+	Exports struct {
+		Storage conf.StorageConf `mapstructure:"storage"`
+	} `mapstructure:"exports"`
+	Replies conf.StorageConf  `mapstructure:"replies"`
+	Apple   conf.AppleConf    `mapstructure:"apple"`
+	Redis   conf.RedisConf    `mapstructure:"redis"`
+	DB      conf.PostgresConf `mapstructure:"db"`
+	GRPC    conf.AddrConf     `mapstructure:"grpc"`
+	Listen  conf.AddrConf     `mapstructure:"listen"`
+	Metrics conf.AddrConf     `mapstructure:"metrics"`
+	Tracing tracing.Config    `mapstructure:"tracing"`
+	Login   login.Config      `mapstructure:"login"`
+	Minis   []struct {
 		Key string `mapstructure:"key"`
 		ID  int    `mapstructure:"id"`
 	} `mapstructure:"mini"`
@@ -87,6 +104,16 @@ func main() {
 		log.Fatalf("failed to parse config err: %v", err)
 	}
 
+	provider, err := tracing.Init(config.Tracing)
+	if err != nil {
+		log.Fatalf("failed to init tracing err: %v", err)
+	}
+
+	defer provider.Shutdown(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	rdb := redis.NewRedis(config.Redis)
 	queue := pubsub.NewQueue(rdb)
 
@@ -95,6 +122,11 @@ func main() {
 		log.Fatalf("failed to open db: %s", err)
 	}
 
+	err = migrations.Check(ctx, db)
+	if err != nil {
+		log.Fatalf("incompatible database schema: %s", err)
+	}
+
 	s := sessions.NewSessionManager(rdb)
 	ub := users.NewBackend(db)
 	fb := followers.NewFollowersBackend(db)
@@ -114,7 +146,28 @@ func main() {
 	r.MethodNotAllowedHandler = http.HandlerFunc(httputil.NotAllowedHandler)
 	r.NotFoundHandler = http.HandlerFunc(httputil.NotFoundHandler)
 
-	ib := images.NewImagesBackend(config.CDN.Images)
+	imagesStorage, err := storage.Open(config.CDN.Images)
+	if err != nil {
+		log.Fatalf("failed to open images storage err: %v", err)
+	}
+
+	storiesStorage, err := storage.Open(config.CDN.Stories)
+	if err != nil {
+		log.Fatalf("failed to open stories storage err: %v", err)
+	}
+
+	repliesStorage, err := storage.Open(config.Replies)
+	if err != nil {
+		log.Fatalf("failed to open replies storage err: %v", err)
+	}
+
+	// exports are only served by the api when the storage can not presign download urls.
+	exportsStorage, err := storage.Open(config.Exports.Storage)
+	if err != nil {
+		log.Fatalf("failed to open exports storage err: %v", err)
+	}
+
+	ib := images.NewImagesBackend(imagesStorage)
 	ms := mail.NewMailService(sendgrid.NewSendClient(config.Sendgrid.Key))
 
 	loginState := login.NewStateManager(rdb)
@@ -136,7 +189,11 @@ func main() {
 		panic(err)
 	}
 
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", config.GRPC.Host, config.GRPC.Port), grpc.WithInsecure())
+	conn, err := grpc.Dial(
+		fmt.Sprintf("%s:%d", config.GRPC.Host, config.GRPC.Port),
+		grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(grpcutil.UnaryClientRequestID, grpcutil.UnaryClientTracing),
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -145,7 +202,21 @@ func main() {
 
 	roomService := pb.NewRoomServiceClient(conn)
 
-	loginEndpoints := login.NewEndpoint(ub, loginState, s, ms, ib, queue, appleClient, roomService, config.Login)
+	metricsServer := metrics.NewServer()
+	metricsServer.AddCheck("postgres", metrics.PostgresCheck(db))
+	metricsServer.AddCheck("redis", metrics.RedisCheck(rdb))
+	metricsServer.AddCheck("rooms", metrics.GRPCCheck(conn))
+
+	go func() {
+		err := metricsServer.ListenAndServe(ctx, config.Metrics)
+		if err != nil {
+			log.Printf("failed to serve metrics: %v", err)
+		}
+	}()
+
+	accountBackend := account.NewBackend(db)
+
+	loginEndpoints := login.NewEndpoint(ub, accountBackend, loginState, s, ms, ib, queue, appleClient, roomService, config.Login)
 	loginRouter := loginEndpoints.Router()
 	mount(r, "/v1/login", loginRouter)
 
@@ -163,7 +234,15 @@ func main() {
 	usersRouter.Use(amw.Middleware)
 	mount(r, "/v1/users", usersRouter)
 
-	storiesEndpoint := stories.NewEndpoint(storiesBackend, stories.NewFileBackend(config.CDN.Stories), queue)
+	blocksBackend := blocks.NewBackend(db)
+
+	storiesEndpoint := stories.NewEndpoint(
+		storiesBackend,
+		stories.NewFileBackend(storiesStorage),
+		stories.NewFileBackend(repliesStorage),
+		blocksBackend,
+		queue,
+	)
 	storiesRouter := storiesEndpoint.Router()
 	storiesRouter.Use(amw.Middleware)
 	mount(r, "/v1/stories", storiesRouter)
@@ -173,13 +252,11 @@ func main() {
 	devicesRoutes.Use(amw.Middleware)
 	mount(r, "/v1/devices", devicesRoutes)
 
-	accountEndpoint := account.NewEndpoint(account.NewBackend(db), queue, s)
+	accountEndpoint := account.NewEndpoint(accountBackend, queue, s, account.NewExportStore(rdb, exportsStorage), amw)
 	accountRouter := accountEndpoint.Router()
-	accountRouter.Use(amw.Middleware)
 	mount(r, "/v1/account", accountRouter)
 
-	blocksBackend := blocks.NewBackend(db)
-	blocksEndpoint := blocks.NewEndpoint(blocksBackend)
+	blocksEndpoint := blocks.NewEndpoint(blocksBackend, queue)
 	blocksRouter := blocksEndpoint.Router()
 	blocksRouter.Use(amw.Middleware)
 	mount(r, "/v1/blocks", blocksRouter)
@@ -192,13 +269,68 @@ func main() {
 
 	pb := linkedaccounts.NewLinkedAccountsBackend(db)
 
-	meEndpoint := me.NewEndpoint(ub, ns, oauth, pb, storiesBackend, queue, activeusers.NewBackend(db), notifications.NewSettings(db), follows.NewBackend(db))
+	presenceBackend := presence.NewBackend(rdb, db, queue)
+
+	// presence updates are consumed on their own queue so closing it does not affect publishing.
+	presenceQueue := pubsub.NewQueue(rdb)
+	presenceHub := presence.NewHub()
+	go presenceHub.Run(presenceQueue.Subscribe(pubsub.PresenceTopic))
+
+	go func() {
+		<-ctx.Done()
+
+		err := presenceQueue.Close()
+		if err != nil {
+			log.Printf("presenceQueue.Close err: %v", err)
+		}
+	}()
+
+	inboxBackend := inbox.NewBackend(rdb, queue)
+
+	inboxQueue := pubsub.NewQueue(rdb)
+	inboxHub := inbox.NewHub()
+	go inboxHub.Run(inboxQueue.Subscribe(pubsub.InboxTopic))
+
+	go func() {
+		<-ctx.Done()
+
+		err := inboxQueue.Close()
+		if err != nil {
+			log.Printf("inboxQueue.Close err: %v", err)
+		}
+	}()
+
+	inboxEndpoint := inbox.NewEndpoint(inboxBackend, inboxHub)
+	inboxRouter := inboxEndpoint.Router()
+	inboxRouter.Use(amw.Middleware)
+	mount(r, "/v1/inbox", inboxRouter)
+
+	presenceEndpoint := presence.NewEndpoint(presenceBackend, presenceHub, fb)
+	presenceRouter := presenceEndpoint.Router()
+	presenceRouter.Use(amw.Middleware)
+	mount(r, "/v1/presence", presenceRouter)
+
+	meEndpoint := me.NewEndpoint(
+		ub,
+		ns,
+		oauth,
+		pb,
+		storiesBackend,
+		queue,
+		activeusers.NewBackend(db),
+		presenceBackend,
+		fb,
+		inboxBackend,
+		notifications.NewSettings(db),
+		follows.NewBackend(db),
+		backends.NewRoomSessionBackend(db),
+	)
 	meRoutes := meEndpoint.Router()
 
 	meRoutes.Use(amw.Middleware)
 	mount(r, "/v1/me", meRoutes)
 
-	searchEndpoint := search.NewEndpoint(client)
+	searchEndpoint := search.NewEndpoint(search.NewElasticsearchEngine(client), roomService, fb, blocksBackend)
 	searchRouter := searchEndpoint.Router()
 	searchRouter.Use(amw.Middleware)
 	mount(r, "/v1/search", searchRouter)
@@ -221,17 +353,21 @@ func main() {
 	analyticsRouter.Use(amw.Middleware)
 	mount(r, "/v1/analytics", analyticsRouter)
 
-	err = http.ListenAndServe(fmt.Sprintf(":%d", config.Listen.Port), httputil.CORS(r))
+	err = httputil.ListenAndServe(ctx, fmt.Sprintf(":%d", config.Listen.Port), httputil.RequestID(tracing.Handler(httputil.CORS(r))))
 	if err != nil {
 		log.Print(err)
 	}
+
+	log.Print("shut down")
 }
 
-func mount(r *mux.Router, path string, handler http.Handler) {
+func mount(r *mux.Router, path string, handler *mux.Router) {
+	handler.Use(tracing.RouteMiddleware(path))
+
 	r.PathPrefix(path).Handler(
 		http.StripPrefix(
 			strings.TrimSuffix(path, "/"),
-			AddSlashForRoot(handler),
+			AddSlashForRoot(metrics.InstrumentHandler(path, handler)),
 		),
 	)
 }
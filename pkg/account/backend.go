@@ -1,6 +1,15 @@
 package account
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+)
+
+// DeletionGracePeriod is how long a user can cancel the deletion of their account for by logging in.
+const DeletionGracePeriod = 30 * 24 * time.Hour
 
 type Backend struct {
 	db *sql.DB
@@ -12,12 +21,86 @@ func NewBackend(db *sql.DB) *Backend {
 	}
 }
 
-func (b *Backend) DeleteAccount(id int) error {
-	stmt, err := b.db.Prepare("DELETE FROM users WHERE id = $1")
+// ScheduleDeletion hides an account until it is either purged or the deletion is cancelled.
+func (b *Backend) ScheduleDeletion(ctx context.Context, id int) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "UPDATE users SET deletion_scheduled_at = NOW() WHERE id = $1 AND deletion_scheduled_at IS NULL;")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(ctx, id)
 	return err
 }
+
+// CancelDeletion restores an account scheduled for deletion, it returns false if no deletion was scheduled.
+func (b *Backend) CancelDeletion(ctx context.Context, id int) (bool, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "UPDATE users SET deletion_scheduled_at = NULL WHERE id = $1 AND deletion_scheduled_at IS NOT NULL;")
+	if err != nil {
+		return false, err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetAccountsScheduledForDeletionBefore returns the accounts whose deletion was scheduled before the passed time.
+func (b *Backend) GetAccountsScheduledForDeletionBefore(ctx context.Context, before time.Time) ([]int, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT id FROM users WHERE deletion_scheduled_at < $1;")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]int, 0)
+
+	for rows.Next() {
+		var id int
+
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, id)
+	}
+
+	return result, rows.Err()
+}
+
+// DeleteAccount permanently deletes an account and all of its data if its deletion is still scheduled and was
+// scheduled before the passed time. It returns false if the account was not deleted, as the deletion was cancelled.
+func (b *Backend) DeleteAccount(ctx context.Context, id int, before time.Time) (bool, error) {
+	query := "DELETE FROM users WHERE id = $1 AND deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $2;"
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := stmt.ExecContext(ctx, id, before)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
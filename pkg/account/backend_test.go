@@ -0,0 +1,106 @@
+package account_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/soapboxsocial/soapbox/pkg/account"
+)
+
+func TestBackend_ScheduleDeletion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := account.NewBackend(db)
+
+	mock.ExpectPrepare("^UPDATE users SET deletion_scheduled_at = NOW()").ExpectExec().
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = backend.ScheduleDeletion(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackend_CancelDeletion(t *testing.T) {
+	var tests = []struct {
+		affected int64
+		expected bool
+	}{
+		{0, false},
+		{1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.FormatInt(tt.affected, 10), func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			backend := account.NewBackend(db)
+
+			mock.ExpectPrepare("^UPDATE users SET deletion_scheduled_at = NULL").ExpectExec().
+				WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			cancelled, err := backend.CancelDeletion(context.Background(), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cancelled != tt.expected {
+				t.Fatalf("expected %v actual %v", tt.expected, cancelled)
+			}
+		})
+	}
+}
+
+func TestBackend_DeleteAccount(t *testing.T) {
+	var tests = []struct {
+		affected int64
+		expected bool
+	}{
+		{0, false},
+		{1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.FormatInt(tt.affected, 10), func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			backend := account.NewBackend(db)
+			before := time.Now()
+
+			mock.ExpectPrepare("^DELETE FROM users WHERE id = \\$1 AND deletion_scheduled_at IS NOT NULL").ExpectExec().
+				WithArgs(1, before).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			deleted, err := backend.DeleteAccount(context.Background(), 1, before)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if deleted != tt.expected {
+				t.Fatalf("expected %v actual %v", tt.expected, deleted)
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package account
 
 import (
-	"log"
+	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/http/middlewares"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/sessions"
 )
@@ -15,20 +17,34 @@ type Endpoint struct {
 	backend  *Backend
 	queue    *pubsub.Queue
 	sessions *sessions.SessionManager
+	exports  *ExportStore
+	auth     *middlewares.AuthenticationMiddleware
 }
 
-func NewEndpoint(backend *Backend, queue *pubsub.Queue, sessions *sessions.SessionManager) *Endpoint {
+func NewEndpoint(
+	backend *Backend,
+	queue *pubsub.Queue,
+	sessions *sessions.SessionManager,
+	exports *ExportStore,
+	auth *middlewares.AuthenticationMiddleware,
+) *Endpoint {
 	return &Endpoint{
 		backend:  backend,
 		queue:    queue,
 		sessions: sessions,
+		exports:  exports,
+		auth:     auth,
 	}
 }
 
 func (e *Endpoint) Router() *mux.Router {
 	r := mux.NewRouter()
 
-	r.HandleFunc("/", e.delete).Methods("DELETE")
+	r.Path("/").Methods("DELETE").Handler(e.auth.Middleware(http.HandlerFunc(e.delete)))
+	r.Path("/export").Methods("POST").Handler(e.auth.Middleware(http.HandlerFunc(e.export)))
+
+	// downloads are opened from an email, the token authenticates them.
+	r.HandleFunc("/export/{token:[0-9a-f]+}", e.download).Methods("GET")
 
 	return r
 }
@@ -40,24 +56,82 @@ func (e *Endpoint) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := e.backend.DeleteAccount(id)
+	// the account is only purged after the grace period, logging in before then cancels the deletion.
+	err := e.backend.ScheduleDeletion(r.Context(), id)
 	if err != nil {
-		log.Printf("backend.DeleteAccount err: %s", err)
+		log.Ctx(r.Context()).Printf("backend.ScheduleDeletion err: %s", err)
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeNotFound, "failed to delete")
 		return
 	}
 
-	log.Printf("deleted user %d", id)
+	log.Ctx(r.Context()).Printf("scheduled deletion of user %d", id)
 
-	err = e.queue.Publish(pubsub.UserTopic, pubsub.NewDeleteUserEvent(id))
+	err = e.queue.Publish(r.Context(), pubsub.UserTopic, pubsub.NewUserDeletionScheduledEvent(id))
 	if err != nil {
-		log.Printf("failed to write delete event: %v", err)
+		log.Ctx(r.Context()).Printf("failed to write deletion scheduled event: %v", err)
 	}
 
-	err = e.sessions.CloseSession(r.Header.Get("Authorization"))
+	err = e.sessions.CloseAllSessions(id)
 	if err != nil {
-		log.Printf("failed to close session: %v", err)
+		log.Ctx(r.Context()).Printf("failed to close sessions: %v", err)
 	}
 
 	httputil.JsonSuccess(w)
 }
+
+func (e *Endpoint) export(w http.ResponseWriter, r *http.Request) {
+	id, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "invalid id")
+		return
+	}
+
+	ok, err := e.exports.Request(r.Context(), id)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("exports.Request err: %s", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "failed to request export")
+		return
+	}
+
+	if !ok {
+		httputil.JsonError(w, http.StatusTooManyRequests, httputil.ErrorCodeInvalidRequestBody, "export already requested")
+		return
+	}
+
+	err = e.queue.Publish(r.Context(), pubsub.UserTopic, pubsub.NewUserExportRequestedEvent(id))
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to write export event: %v", err)
+
+		err := e.exports.Release(r.Context(), id)
+		if err != nil {
+			log.Ctx(r.Context()).Printf("exports.Release err: %v", err)
+		}
+
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "failed to request export")
+		return
+	}
+
+	httputil.JsonSuccess(w)
+}
+
+func (e *Endpoint) download(w http.ResponseWriter, r *http.Request) {
+	export, err := e.exports.Open(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		if err != ErrExportNotFound {
+			log.Ctx(r.Context()).Printf("exports.Open err: %s", err)
+		}
+
+		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "not found")
+		return
+	}
+
+	defer export.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="soapbox-export.zip"`)
+
+	_, err = io.Copy(w, export)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to write export: %s", err)
+	}
+}
@@ -1,6 +1,8 @@
 package account_test
 
 import (
+	"context"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -16,8 +18,10 @@ import (
 
 	"github.com/soapboxsocial/soapbox/pkg/account"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/http/middlewares"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/sessions"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
 )
 
 func TestMain(m *testing.M) {
@@ -50,6 +54,8 @@ func TestAccountEndpoint_Delete(t *testing.T) {
 		account.NewBackend(db),
 		pubsub.NewQueue(rdb),
 		sm,
+		account.NewExportStore(rdb, storage.NewLocal(t.TempDir())),
+		middlewares.NewAuthenticationMiddleware(sm),
 	)
 
 	rr := httptest.NewRecorder()
@@ -63,6 +69,13 @@ func TestAccountEndpoint_Delete(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// the user is also logged in on another device.
+	other := "5678"
+	err = sm.NewSession(other, userID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	r, err := http.NewRequest("DELETE", "/", strings.NewReader(""))
 	if err != nil {
 		t.Fatal(err)
@@ -71,7 +84,7 @@ func TestAccountEndpoint_Delete(t *testing.T) {
 	req := r.WithContext(httputil.WithUserID(r.Context(), userID))
 	req.Header.Set("Authorization", session)
 
-	smock.ExpectPrepare("^DELETE (.+)").
+	smock.ExpectPrepare("^UPDATE users SET deletion_scheduled_at").
 		ExpectExec().
 		WithArgs(userID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
@@ -82,4 +95,98 @@ func TestAccountEndpoint_Delete(t *testing.T) {
 		print(rr.Body.String())
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
 	}
+
+	for _, id := range []string{session, other} {
+		_, err := sm.GetUserIDForSession(id)
+		if err != redis.Nil {
+			t.Fatalf("expected session %s to be closed, err: %v", id, err)
+		}
+	}
+}
+
+func TestAccountEndpoint_Export(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	sm := sessions.NewSessionManager(rdb)
+	store := account.NewExportStore(rdb, storage.NewLocal(t.TempDir()))
+
+	endpoint := account.NewEndpoint(
+		account.NewBackend(db),
+		pubsub.NewQueue(rdb),
+		sm,
+		store,
+		middlewares.NewAuthenticationMiddleware(sm),
+	)
+
+	handler := endpoint.Router()
+
+	session := "1234"
+	userID := 1
+
+	err = sm.NewSession(session, userID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		r, err := http.NewRequest("POST", "/export", strings.NewReader(""))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r.Header.Set("Authorization", session)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, r)
+
+		if status := rr.Code; status != expected {
+			t.Errorf("handler returned wrong status code: got %v want %v", status, expected)
+		}
+	}
+
+	token, err := store.Create(context.Background(), userID, func(w io.Writer) error {
+		_, err := w.Write([]byte("export"))
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := http.NewRequest("GET", "/export/"+token, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+
+	if rr.Code != http.StatusOK || rr.Body.String() != "export" {
+		t.Fatalf("unexpected download response %d %s", rr.Code, rr.Body.String())
+	}
+
+	r, err = http.NewRequest("GET", "/export/abcdef", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusNotFound)
+	}
 }
@@ -0,0 +1,196 @@
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/followers"
+	"github.com/soapboxsocial/soapbox/pkg/minis"
+	"github.com/soapboxsocial/soapbox/pkg/notifications"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+	"github.com/soapboxsocial/soapbox/pkg/stories"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+	"github.com/soapboxsocial/soapbox/pkg/users/types"
+)
+
+// exportPageSize is the number of followers loaded at once while exporting.
+const exportPageSize = 1000
+
+// ExporterConfig contains the sources a user's data is exported from.
+type ExporterConfig struct {
+	Users         *users.Backend
+	Followers     *followers.FollowersBackend
+	Blocks        *blocks.Backend
+	Stories       *stories.Backend
+	StoryFiles    *stories.FileBackend
+	RoomLogs      *backends.UserRoomLogBackend
+	Minis         *minis.Backend
+	Notifications *notifications.Storage
+	Settings      *notifications.Settings
+}
+
+// Exporter assembles a zip archive containing all data stored about a user.
+type Exporter struct {
+	config *ExporterConfig
+}
+
+func NewExporter(config *ExporterConfig) *Exporter {
+	return &Exporter{config: config}
+}
+
+// Export writes the archive for a user to w.
+func (e *Exporter) Export(ctx context.Context, user int, w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		load func(ctx context.Context, user int) (interface{}, error)
+	}{
+		{"profile.json", e.profile},
+		{"followers.json", e.followers},
+		{"following.json", e.following},
+		{"blocks.json", e.blocks},
+		{"linked_accounts.json", e.linkedAccounts},
+		{"rooms.json", e.rooms},
+		{"mini_scores.json", e.scores},
+		{"notifications.json", e.notifications},
+		{"settings.json", e.settings},
+	}
+
+	for _, file := range files {
+		data, err := file.load(ctx, user)
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", file.name, err)
+		}
+
+		err = writeJSON(archive, file.name, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := e.stories(ctx, archive, user)
+	if err != nil {
+		return fmt.Errorf("failed to export stories: %w", err)
+	}
+
+	return archive.Close()
+}
+
+func (e *Exporter) profile(ctx context.Context, user int) (interface{}, error) {
+	return e.config.Users.FindByID(ctx, user)
+}
+
+func (e *Exporter) followers(ctx context.Context, user int) (interface{}, error) {
+	return allUsers(ctx, user, e.config.Followers.GetAllUsersFollowing)
+}
+
+func (e *Exporter) following(ctx context.Context, user int) (interface{}, error) {
+	return allUsers(ctx, user, e.config.Followers.GetAllUsersFollowedBy)
+}
+
+func (e *Exporter) blocks(ctx context.Context, user int) (interface{}, error) {
+	return e.config.Blocks.GetUsersBlockedBy(ctx, user)
+}
+
+func (e *Exporter) linkedAccounts(ctx context.Context, user int) (interface{}, error) {
+	return e.config.Users.LinkedAccounts(ctx, user)
+}
+
+func (e *Exporter) rooms(ctx context.Context, user int) (interface{}, error) {
+	return e.config.RoomLogs.GetLogsForUser(ctx, user)
+}
+
+func (e *Exporter) scores(ctx context.Context, user int) (interface{}, error) {
+	return e.config.Minis.GetScoresForUser(ctx, user)
+}
+
+func (e *Exporter) notifications(_ context.Context, user int) (interface{}, error) {
+	return e.config.Notifications.GetNotifications(user)
+}
+
+func (e *Exporter) settings(ctx context.Context, user int) (interface{}, error) {
+	return e.config.Settings.GetSettingsFor(ctx, user)
+}
+
+// stories writes the metadata of a user's stories along with their audio files.
+func (e *Exporter) stories(ctx context.Context, archive *zip.Writer, user int) error {
+	list, err := e.config.Stories.GetStoriesForUser(ctx, user, user, 0)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSON(archive, "stories.json", list)
+	if err != nil {
+		return err
+	}
+
+	for _, story := range list {
+		name := story.ID + ".aac"
+
+		err := e.copyStory(ctx, archive, name)
+		if err != nil {
+			if err == storage.ErrNotFound {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Exporter) copyStory(ctx context.Context, archive *zip.Writer, name string) error {
+	file, err := e.config.StoryFiles.Open(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	w, err := archive.Create("stories/" + name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, file)
+	return err
+}
+
+func allUsers(ctx context.Context, user int, list func(ctx context.Context, id, after, limit int) ([]*types.User, error)) ([]*types.User, error) {
+	result := make([]*types.User, 0)
+
+	after := 0
+	for {
+		page, err := list(ctx, user, after, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, page...)
+
+		if len(page) < exportPageSize {
+			return result, nil
+		}
+
+		after = page[len(page)-1].ID
+	}
+}
+
+func writeJSON(archive *zip.Writer, name string, v interface{}) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
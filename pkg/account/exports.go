@@ -0,0 +1,172 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+)
+
+const (
+	// ExportExpiration is how long an export can be downloaded for.
+	ExportExpiration = 24 * time.Hour
+
+	// exportRequestCooldown is how long a user has to wait before requesting another export.
+	exportRequestCooldown = time.Hour
+
+	// exportsKey is a sorted set of the tokens of stored exports, scored by when they were created.
+	exportsKey = "account_exports"
+)
+
+// ErrExportNotFound is returned when an export does not exist or has expired.
+var ErrExportNotFound = errors.New("export not found")
+
+// ExportStore stores finished exports in a blob store, they can be downloaded with a token until they expire.
+type ExportStore struct {
+	rdb  *redis.Client
+	blob storage.Blob
+}
+
+func NewExportStore(rdb *redis.Client, blob storage.Blob) *ExportStore {
+	return &ExportStore{rdb: rdb, blob: blob}
+}
+
+// Request marks that a user requested an export, it returns false if the user already requested one recently.
+func (s *ExportStore) Request(ctx context.Context, user int) (bool, error) {
+	return s.rdb.SetNX(ctx, exportRequestKey(user), user, exportRequestCooldown).Result()
+}
+
+// Release allows a user to request another export straight away, it is used when an export failed.
+func (s *ExportStore) Release(ctx context.Context, user int) error {
+	return s.rdb.Del(ctx, exportRequestKey(user)).Err()
+}
+
+// Create stores the export written by write, it returns the token the export can be downloaded with.
+func (s *ExportStore) Create(ctx context.Context, user int, write func(w io.Writer) error) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	file, err := ioutil.TempFile("", "export-*.zip")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	err = write(file)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.blob.Put(ctx, exportName(token), file, "application/zip")
+	if err != nil {
+		return "", err
+	}
+
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, exportKey(token), user, ExportExpiration)
+	pipe.ZAdd(ctx, exportsKey, &redis.Z{Score: float64(time.Now().Unix()), Member: token})
+
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		_ = s.blob.Delete(ctx, exportName(token))
+		return "", err
+	}
+
+	return token, nil
+}
+
+// URL returns the link to download an export from. Exports are downloaded from the blob store directly if it can
+// presign URLs, otherwise they are served by the API under base followed by the token.
+func (s *ExportStore) URL(token, base string) (string, error) {
+	presigner, ok := s.blob.(storage.Presigner)
+	if !ok {
+		return base + token, nil
+	}
+
+	return presigner.PresignedURL(exportName(token), ExportExpiration)
+}
+
+// Open returns the export for a token.
+func (s *ExportStore) Open(ctx context.Context, token string) (io.ReadCloser, error) {
+	_, err := s.rdb.Get(ctx, exportKey(token)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrExportNotFound
+		}
+
+		return nil, err
+	}
+
+	r, err := s.blob.Get(ctx, exportName(token))
+	if err == storage.ErrNotFound {
+		return nil, ErrExportNotFound
+	}
+
+	return r, err
+}
+
+// RemoveExpired deletes all exports that can no longer be downloaded.
+func (s *ExportStore) RemoveExpired(ctx context.Context) error {
+	max := strconv.FormatInt(time.Now().Add(-ExportExpiration).Unix(), 10)
+
+	tokens, err := s.rdb.ZRangeByScore(ctx, exportsKey, &redis.ZRangeBy{Min: "-inf", Max: max}).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		err := s.blob.Delete(ctx, exportName(token))
+		if err != nil && err != storage.ErrNotFound {
+			return err
+		}
+
+		err = s.rdb.ZRem(ctx, exportsKey, token).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", b), nil
+}
+
+func exportName(token string) string {
+	return token + ".zip"
+}
+
+func exportKey(token string) string {
+	return "account_export_" + token
+}
+
+func exportRequestKey(user int) string {
+	return "account_export_requested_" + strconv.Itoa(user)
+}
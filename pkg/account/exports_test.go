@@ -0,0 +1,238 @@
+package account_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/account"
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+)
+
+func TestExportStore(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	dir := t.TempDir()
+	store := account.NewExportStore(rdb, storage.NewLocal(dir))
+	ctx := context.Background()
+
+	token, err := store.Create(ctx, 1, func(w io.Writer) error {
+		_, err := w.Write([]byte("export"))
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	export, err := store.Open(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadAll(export)
+	_ = export.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "export" {
+		t.Fatalf("unexpected export %s", data)
+	}
+
+	url, err := store.URL(token, "http://localhost/export/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if url != "http://localhost/export/"+token {
+		t.Fatalf("unexpected url %s", url)
+	}
+
+	mr.FastForward(account.ExportExpiration)
+
+	_, err = store.Open(ctx, token)
+	if err != account.ErrExportNotFound {
+		t.Fatalf("expected %v actual %v", account.ErrExportNotFound, err)
+	}
+}
+
+func TestExportStore_RemoveExpired(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	dir := t.TempDir()
+	store := account.NewExportStore(rdb, storage.NewLocal(dir))
+	ctx := context.Background()
+
+	_, err = store.Create(ctx, 1, func(w io.Writer) error {
+		_, err := w.Write([]byte("export"))
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.RemoveExpired(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected export to be kept, found %d files", len(files))
+	}
+
+	// the export is backdated, as the expiration is based on the time it was created at.
+	members, err := mr.ZMembers("account_exports")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, member := range members {
+		_, err := mr.ZAdd("account_exports", 0, member)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = store.RemoveExpired(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 0 {
+		t.Fatalf("expected export to be removed, found %d files", len(files))
+	}
+}
+
+func TestExportStore_URL(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	blob, err := storage.NewS3(conf.StorageConf{
+		Endpoint:  "https://s3.example.com",
+		Region:    "eu-central-1",
+		Bucket:    "exports",
+		AccessKey: "key",
+		SecretKey: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url, err := account.NewExportStore(rdb, blob).URL("abcdef", "http://localhost/export/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(url, "https://s3.example.com/exports/abcdef.zip?") || !strings.Contains(url, "X-Amz-Signature=") {
+		t.Fatalf("unexpected url %s", url)
+	}
+}
+
+func TestExportStore_CreateFails(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	dir := t.TempDir()
+	store := account.NewExportStore(rdb, storage.NewLocal(dir))
+
+	_, err = store.Create(context.Background(), 1, func(w io.Writer) error {
+		return errors.New("boom")
+	})
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 0 {
+		t.Fatalf("expected failed export to be removed, found %d files", len(files))
+	}
+}
+
+func TestExportStore_Request(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	store := account.NewExportStore(rdb, storage.NewLocal(t.TempDir()))
+	ctx := context.Background()
+
+	for i, expected := range []bool{true, false} {
+		ok, err := store.Request(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ok != expected {
+			t.Fatalf("request %d: expected %v actual %v", i, expected, ok)
+		}
+	}
+
+	err = store.Release(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := store.Request(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Fatal("expected request to be allowed after a release")
+	}
+}
@@ -1,8 +1,15 @@
 package activeusers
 
 import (
+	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	"github.com/soapboxsocial/soapbox/pkg/presence"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 )
 
 type Backend struct {
@@ -15,56 +22,63 @@ func NewBackend(db *sql.DB) *Backend {
 	}
 }
 
-func (b *Backend) SetLastActiveTime(user int, time time.Time) error {
-	stmt, err := b.db.Prepare("SELECT update_user_active_times($1, $2);")
+func (b *Backend) SetLastActiveTime(ctx context.Context, user int, time time.Time) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT update_user_active_times($1, $2);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(user, time)
+	_, err = stmt.ExecContext(ctx, user, time)
 	return err
 }
 
-func (b *Backend) GetActiveUsersForFollower(user int) ([]ActiveUser, error) {
-	query := `SELECT users.id, users.display_name, users.username, users.image, active.room FROM users
-		INNER JOIN (
-		    SELECT user_id, MAX(room) AS room, MAX(last_active) as last_active
-		    FROM (
-		        SELECT user_id, room, NOW() as last_active FROM current_rooms
-		        UNION
-		        SELECT user_id, NULL as room, last_active FROM user_active_times WHERE last_active > (NOW() - INTERVAL '15 MINUTE')
-			) AS foo GROUP BY user_id) active ON users.id = active.user_id
-		WHERE active.user_id IN (
-		    SELECT user_id AS user from followers WHERE follower = $1
-		    INTERSECT
-		    SELECT follower as user FROM followers WHERE user_id = $1
-		) ORDER BY room, active.last_active DESC;`
-
-	stmt, err := b.db.Prepare(query)
+// GetActiveUsers returns the users that are online, in the same order, along with the room they are in.
+// Users that are being deleted are left out.
+func (b *Backend) GetActiveUsers(ctx context.Context, online []presence.Presence) ([]ActiveUser, error) {
+	ids := make([]int, len(online))
+	for i, p := range online {
+		ids[i] = p.ID
+	}
+
+	query := "SELECT id, display_name, username, image FROM users WHERE id = ANY($1) AND deletion_scheduled_at IS NULL;"
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(user)
+	rows, err := stmt.QueryContext(ctx, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]ActiveUser, 0)
+	defer rows.Close()
 
+	users := make(map[int]ActiveUser)
 	for rows.Next() {
 		user := ActiveUser{}
-		var room sql.NullString
 
-		err := rows.Scan(&user.ID, &user.DisplayName, &user.Username, &user.Image, &room)
+		err := rows.Scan(&user.ID, &user.DisplayName, &user.Username, &user.Image)
 		if err != nil {
-			continue
+			return nil, err
 		}
 
-		if room.Valid {
-			user.Room = &room.String
+		user.Images = images.Variants(user.Image)
+		users[user.ID] = user
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]ActiveUser, 0, len(online))
+	for _, p := range online {
+		user, ok := users[p.ID]
+		if !ok {
+			continue
 		}
 
+		user.Room = p.Room
 		result = append(result, user)
 	}
 
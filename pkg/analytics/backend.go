@@ -1,7 +1,10 @@
 package analytics
 
 import (
+	"context"
 	"database/sql"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 )
 
 type Backend struct {
@@ -12,22 +15,22 @@ func NewBackend(db *sql.DB) *Backend {
 	return &Backend{db: db}
 }
 
-func (b *Backend) AddSentNotification(user int, notification Notification) error {
-	stmt, err := b.db.Prepare("INSERT INTO notification_analytics (id, target, origin, category, sent, room) VALUES($1, $2, $3, $4, NOW(), $5);")
+func (b *Backend) AddSentNotification(ctx context.Context, user int, notification Notification) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO notification_analytics (id, target, origin, category, sent, room) VALUES($1, $2, $3, $4, NOW(), $5);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(notification.ID, user, notification.Origin, notification.Category, notification.Room)
+	_, err = stmt.ExecContext(ctx, notification.ID, user, notification.Origin, notification.Category, notification.Room)
 	return err
 }
 
-func (b *Backend) MarkNotificationRead(user int, uuid string) error {
-	stmt, err := b.db.Prepare("UPDATE notification_analytics SET opened = NOW() WHERE target = $1 AND id = $2;")
+func (b *Backend) MarkNotificationRead(ctx context.Context, user int, uuid string) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "UPDATE notification_analytics SET opened = NOW() WHERE target = $1 AND id = $2;")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(user, uuid)
+	_, err = stmt.ExecContext(ctx, user, uuid)
 	return err
 }
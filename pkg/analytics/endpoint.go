@@ -1,12 +1,12 @@
 package analytics
 
 import (
-	"log"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 )
 
 type Endpoint struct {
@@ -43,9 +43,9 @@ func (e *Endpoint) openedNotification(w http.ResponseWriter, r *http.Request) {
 	}
 
 	go func() {
-		err := e.backend.MarkNotificationRead(userID, id)
+		err := e.backend.MarkNotificationRead(r.Context(), userID, id)
 		if err != nil {
-			log.Printf("backend.MarkNotificationRead err: %s", err)
+			log.Ctx(r.Context()).Printf("backend.MarkNotificationRead err: %s", err)
 		}
 	}()
 
@@ -3,6 +3,8 @@ package blocks
 import (
 	"context"
 	"database/sql"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 )
 
 type Backend struct {
@@ -13,64 +15,66 @@ func NewBackend(db *sql.DB) *Backend {
 	return &Backend{db: db}
 }
 
-func (b *Backend) BlockUser(user, block int) error {
-	ctx := context.Background()
-	tx, err := b.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
+// BlockUser blocks a user and removes any follows between the two users.
+func (b *Backend) BlockUser(ctx context.Context, user, block int) error {
+	return sqlutil.Transaction(ctx, b.db, func(ctx context.Context) error {
+		tx := sqlutil.ExecutorFrom(ctx, b.db)
 
-	_, err = tx.ExecContext(
-		ctx,
-		"INSERT INTO blocks (user_id, blocked) VALUES ($1, $2);",
-		user, block,
-	)
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO blocks (user_id, blocked) VALUES ($1, $2);",
+			user, block,
+		)
 
-	if err != nil {
-		_ = tx.Rollback()
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	_, err = tx.ExecContext(
-		ctx,
-		"DELETE FROM followers WHERE (follower = $1 AND user_id = $2) OR (follower = $2 AND user_id = $1);",
-		user, block,
-	)
+		_, err = tx.ExecContext(
+			ctx,
+			"DELETE FROM followers WHERE (follower = $1 AND user_id = $2) OR (follower = $2 AND user_id = $1);",
+			user, block,
+		)
 
+		return err
+	})
+}
+
+func (b *Backend) UnblockUser(ctx context.Context, user, block int) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "DELETE FROM blocks WHERE user_id = $1 AND blocked = $2;")
 	if err != nil {
 		return err
 	}
 
-	err = tx.Commit()
+	_, err = stmt.ExecContext(ctx, user, block)
 	if err != nil {
-		_ = tx.Rollback()
 		return err
 	}
 
 	return nil
 }
 
-func (b *Backend) UnblockUser(user, block int) error {
-	stmt, err := b.db.Prepare("DELETE FROM blocks WHERE user_id = $1 AND blocked = $2;")
-	if err != nil {
-		return err
-	}
+// IsBlocked returns whether either of the users blocked the other.
+func (b *Backend) IsBlocked(ctx context.Context, user, other int) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM blocks WHERE (user_id = $1 AND blocked = $2) OR (user_id = $2 AND blocked = $1));"
 
-	_, err = stmt.Exec(user, block)
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	var blocked bool
+	err = stmt.QueryRowContext(ctx, user, other).Scan(&blocked)
+	return blocked, err
 }
 
-func (b *Backend) GetUsersWhoBlocked(user int) ([]int, error) {
-	stmt, err := b.db.Prepare("SELECT user_id FROM blocks WHERE blocked = $1;")
+func (b *Backend) GetUsersWhoBlocked(ctx context.Context, user int) ([]int, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT user_id FROM blocks WHERE blocked = $1;")
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(user)
+	rows, err := stmt.QueryContext(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -90,13 +94,13 @@ func (b *Backend) GetUsersWhoBlocked(user int) ([]int, error) {
 	return result, nil
 }
 
-func (b *Backend) GetUsersBlockedBy(user int) ([]int, error) {
-	stmt, err := b.db.Prepare("SELECT blocked FROM blocks WHERE user_id = $1;")
+func (b *Backend) GetUsersBlockedBy(ctx context.Context, user int) ([]int, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT blocked FROM blocks WHERE user_id = $1;")
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(user)
+	rows, err := stmt.QueryContext(ctx, user)
 	if err != nil {
 		return nil, err
 	}
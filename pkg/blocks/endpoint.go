@@ -7,15 +7,19 @@ import (
 	"github.com/gorilla/mux"
 
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 )
 
 type Endpoint struct {
 	backend *Backend
+	queue   *pubsub.Queue
 }
 
-func NewEndpoint(backend *Backend) *Endpoint {
+func NewEndpoint(backend *Backend, queue *pubsub.Queue) *Endpoint {
 	return &Endpoint{
 		backend: backend,
+		queue:   queue,
 	}
 }
 
@@ -47,7 +51,7 @@ func (e *Endpoint) unblock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = e.backend.UnblockUser(userID, id)
+	err = e.backend.UnblockUser(r.Context(), userID, id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "failed to unblock")
 		return
@@ -73,11 +77,16 @@ func (e *Endpoint) block(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = e.backend.BlockUser(userID, id)
+	err = e.backend.BlockUser(r.Context(), userID, id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "failed to block")
 		return
 	}
 
+	err = e.queue.Publish(r.Context(), pubsub.UserTopic, pubsub.NewUserBlockedEvent(userID, id))
+	if err != nil {
+		log.Ctx(r.Context()).Printf("queue.Publish err: %v", err)
+	}
+
 	httputil.JsonSuccess(w)
 }
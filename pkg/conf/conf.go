@@ -40,6 +40,19 @@ type AddrConf struct {
 	Port int    `mapstructure:"port"`
 }
 
+// StorageConf describes where blobs like images and stories are stored.
+// Type is either "local", storing files in Path, or "s3" for an S3-compatible bucket.
+type StorageConf struct {
+	Type      string `mapstructure:"type"`
+	Path      string `mapstructure:"path"`
+	Endpoint  string `mapstructure:"endpoint"`
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	Prefix    string `mapstructure:"prefix"`
+	AccessKey string `mapstructure:"access-key"`
+	SecretKey string `mapstructure:"secret-key"`
+}
+
 // Load opens and parses a configuration file.
 func Load(file string, conf interface{}) error {
 	_, err := os.Stat(file)
@@ -1,9 +1,12 @@
 package devices
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strconv"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 )
 
 type Backend struct {
@@ -16,13 +19,13 @@ func NewBackend(db *sql.DB) *Backend {
 	}
 }
 
-func (db *Backend) AddDeviceForUser(id int, token string) error {
-	stmt, err := db.db.Prepare("INSERT INTO devices (token, user_id) VALUES ($1, $2);")
+func (db *Backend) AddDeviceForUser(ctx context.Context, id int, token string) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, db.db).PrepareContext(ctx, "INSERT INTO devices (token, user_id) VALUES ($1, $2);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(token, id)
+	_, err = stmt.ExecContext(ctx, token, id)
 	if err != nil {
 		return err
 	}
@@ -30,13 +33,13 @@ func (db *Backend) AddDeviceForUser(id int, token string) error {
 	return nil
 }
 
-func (db *Backend) GetDevicesForUser(id int) ([]string, error) {
-	stmt, err := db.db.Prepare("SELECT token FROM devices WHERE user_id = $1;")
+func (db *Backend) GetDevicesForUser(ctx context.Context, id int) ([]string, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, db.db).PrepareContext(ctx, "SELECT token FROM devices WHERE user_id = $1;")
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(id)
+	rows, err := stmt.QueryContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -56,18 +59,18 @@ func (db *Backend) GetDevicesForUser(id int) ([]string, error) {
 	return result, nil
 }
 
-func (db *Backend) GetDevicesForUsers(ids []int) ([]string, error) {
+func (db *Backend) GetDevicesForUsers(ctx context.Context, ids []int) ([]string, error) {
 	query := fmt.Sprintf(
 		"SELECT token FROM devices WHERE user_id IN (%s);",
 		join(ids, ","),
 	)
 
-	stmt, err := db.db.Prepare(query)
+	stmt, err := sqlutil.ExecutorFrom(ctx, db.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query()
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -87,13 +90,13 @@ func (db *Backend) GetDevicesForUsers(ids []int) ([]string, error) {
 	return result, nil
 }
 
-func (db *Backend) RemoveDevice(token string) error {
-	stmt, err := db.db.Prepare("DELETE FROM devices WHERE token = $1;")
+func (db *Backend) RemoveDevice(ctx context.Context, token string) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, db.db).PrepareContext(ctx, "DELETE FROM devices WHERE token = $1;")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(token)
+	_, err = stmt.ExecContext(ctx, token)
 	if err != nil {
 		return err
 	}
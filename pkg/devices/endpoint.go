@@ -45,7 +45,7 @@ func (d *Endpoint) add(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = d.db.AddDeviceForUser(userID, token)
+	err = d.db.AddDeviceForUser(r.Context(), userID, token)
 	if err != nil && err.Error() != "pq: duplicate key value violates unique constraint \"devices_pkey\"" {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToStoreDevice, "failed")
 		return
@@ -1,8 +1,11 @@
 package followers
 
 import (
+	"context"
 	"database/sql"
 
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 	"github.com/soapboxsocial/soapbox/pkg/users/types"
 )
 
@@ -16,13 +19,13 @@ func NewFollowersBackend(db *sql.DB) *FollowersBackend {
 	}
 }
 
-func (fb *FollowersBackend) FollowUser(follower, user int) error {
-	stmt, err := fb.db.Prepare("INSERT INTO followers (follower, user_id) VALUES ($1, $2);")
+func (fb *FollowersBackend) FollowUser(ctx context.Context, follower, user int) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, fb.db).PrepareContext(ctx, "INSERT INTO followers (follower, user_id) VALUES ($1, $2);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(follower, user)
+	_, err = stmt.ExecContext(ctx, follower, user)
 	if err != nil {
 		return err
 	}
@@ -30,13 +33,13 @@ func (fb *FollowersBackend) FollowUser(follower, user int) error {
 	return nil
 }
 
-func (fb *FollowersBackend) UnfollowUser(follower, user int) error {
-	stmt, err := fb.db.Prepare("DELETE FROM followers WHERE follower = $1 AND user_id = $2;")
+func (fb *FollowersBackend) UnfollowUser(ctx context.Context, follower, user int) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, fb.db).PrepareContext(ctx, "DELETE FROM followers WHERE follower = $1 AND user_id = $2;")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(follower, user)
+	_, err = stmt.ExecContext(ctx, follower, user)
 	if err != nil {
 		return err
 	}
@@ -44,31 +47,33 @@ func (fb *FollowersBackend) UnfollowUser(follower, user int) error {
 	return nil
 }
 
-func (fb *FollowersBackend) GetAllUsersFollowing(id, limit, offset int) ([]*types.User, error) {
-	stmt, err := fb.db.Prepare("SELECT users.id, users.display_name, users.username, users.image FROM users INNER JOIN followers ON (users.id = followers.follower) WHERE followers.user_id = $1 ORDER BY users.id LIMIT $2 OFFSET $3;")
+// GetAllUsersFollowing returns up to limit followers of a user, ordered by ID, starting after the user with the ID after.
+func (fb *FollowersBackend) GetAllUsersFollowing(ctx context.Context, id, after, limit int) ([]*types.User, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, fb.db).PrepareContext(ctx, "SELECT users.id, users.display_name, users.username, users.image FROM users INNER JOIN followers ON (users.id = followers.follower) WHERE followers.user_id = $1 AND users.id > $2 AND users.deletion_scheduled_at IS NULL ORDER BY users.id LIMIT $3;")
 	if err != nil {
 		return nil, err
 	}
 
-	return fb.executeUserQuery(stmt, id, limit, offset)
+	return fb.executeUserQuery(ctx, stmt, id, after, limit)
 }
 
-func (fb *FollowersBackend) GetAllUsersFollowedBy(id, limit, offset int) ([]*types.User, error) {
-	stmt, err := fb.db.Prepare("SELECT users.id, users.display_name, users.username, users.image FROM users INNER JOIN followers ON (users.id = followers.user_id) WHERE followers.follower = $1 ORDER BY users.id LIMIT $2 OFFSET $3;")
+// GetAllUsersFollowedBy returns up to limit users followed by a user, ordered by ID, starting after the user with the ID after.
+func (fb *FollowersBackend) GetAllUsersFollowedBy(ctx context.Context, id, after, limit int) ([]*types.User, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, fb.db).PrepareContext(ctx, "SELECT users.id, users.display_name, users.username, users.image FROM users INNER JOIN followers ON (users.id = followers.user_id) WHERE followers.follower = $1 AND users.id > $2 AND users.deletion_scheduled_at IS NULL ORDER BY users.id LIMIT $3;")
 	if err != nil {
 		return nil, err
 	}
 
-	return fb.executeUserQuery(stmt, id, limit, offset)
+	return fb.executeUserQuery(ctx, stmt, id, after, limit)
 }
 
-func (fb *FollowersBackend) GetAllFollowerIDsFor(id int) ([]int, error) {
-	stmt, err := fb.db.Prepare("SELECT follower FROM followers WHERE user_id = $1;")
+func (fb *FollowersBackend) GetAllFollowerIDsFor(ctx context.Context, id int) ([]int, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, fb.db).PrepareContext(ctx, "SELECT follower FROM followers WHERE user_id = $1;")
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(id)
+	rows, err := stmt.QueryContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -89,17 +94,47 @@ func (fb *FollowersBackend) GetAllFollowerIDsFor(id int) ([]int, error) {
 	return result, nil
 }
 
-func (fb *FollowersBackend) GetFriends(id int) ([]*types.User, error) {
-	stmt, err := fb.db.Prepare("SELECT users.id, users.display_name, users.username, users.image FROM users WHERE id in (SELECT user_id AS user from followers WHERE follower = $1 INTERSECT SELECT follower as user FROM followers WHERE user_id = $1);")
+// GetAllFollowingIDsFor returns the IDs of all users followed by a user.
+func (fb *FollowersBackend) GetAllFollowingIDsFor(ctx context.Context, id int) ([]int, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, fb.db).PrepareContext(ctx, "SELECT user_id FROM followers WHERE follower = $1;")
 	if err != nil {
 		return nil, err
 	}
 
-	return fb.executeUserQuery(stmt, id)
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]int, 0)
+	for rows.Next() {
+		var id int
+
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, id)
+	}
+
+	return result, rows.Err()
+}
+
+// GetFriends returns up to limit users that follow each other with a user, ordered by ID, starting after the user with the ID after.
+func (fb *FollowersBackend) GetFriends(ctx context.Context, id, after, limit int) ([]*types.User, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, fb.db).PrepareContext(ctx, "SELECT users.id, users.display_name, users.username, users.image FROM users WHERE id in (SELECT user_id AS user from followers WHERE follower = $1 INTERSECT SELECT follower as user FROM followers WHERE user_id = $1) AND id > $2 AND deletion_scheduled_at IS NULL ORDER BY id LIMIT $3;")
+	if err != nil {
+		return nil, err
+	}
+
+	return fb.executeUserQuery(ctx, stmt, id, after, limit)
 }
 
-func (fb *FollowersBackend) executeUserQuery(stmt *sql.Stmt, args ...interface{}) ([]*types.User, error) {
-	rows, err := stmt.Query(args...)
+func (fb *FollowersBackend) executeUserQuery(ctx context.Context, stmt *sql.Stmt, args ...interface{}) ([]*types.User, error) {
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +149,7 @@ func (fb *FollowersBackend) executeUserQuery(stmt *sql.Stmt, args ...interface{}
 			return nil, err // @todo
 		}
 
+		user.Images = images.Variants(user.Image)
 		result = append(result, user)
 	}
 
@@ -0,0 +1,51 @@
+// Package grpc contains utilities shared by the grpc servers and clients.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+// RequestIDKey is the metadata key used to pass request IDs between services.
+const RequestIDKey = "x-request-id"
+
+// UnaryClientRequestID adds the request ID stored in the context to the outgoing metadata.
+func UnaryClientRequestID(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	id, ok := httputil.GetRequestIDFromContext(ctx)
+	if ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// UnaryServerRequestID stores the request ID from the incoming metadata in the context.
+func UnaryServerRequestID(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	ids := md.Get(RequestIDKey)
+	if len(ids) == 0 || ids[0] == "" {
+		return handler(ctx, req)
+	}
+
+	return handler(httputil.WithRequestID(ctx, ids[0]), req)
+}
@@ -0,0 +1,49 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	grpcutil "github.com/soapboxsocial/soapbox/pkg/grpc"
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+func TestUnaryClientRequestID(t *testing.T) {
+	ctx := httputil.WithRequestID(context.Background(), "1234")
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		ids := md.Get(grpcutil.RequestIDKey)
+		if len(ids) != 1 || ids[0] != "1234" {
+			t.Fatalf("unexpected request ids %v", ids)
+		}
+
+		return nil
+	}
+
+	err := grpcutil.UnaryClientRequestID(ctx, "/test", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnaryServerRequestID(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcutil.RequestIDKey, "1234"))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		id, ok := httputil.GetRequestIDFromContext(ctx)
+		if !ok || id != "1234" {
+			t.Fatalf("unexpected request id %s", id)
+		}
+
+		return nil, nil
+	}
+
+	_, err := grpcutil.UnaryServerRequestID(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// ShutdownTimeout is how long a server waits for pending calls to finish before they are canceled.
+const ShutdownTimeout = 15 * time.Second
+
+// Serve accepts connections on lis until ctx is canceled.
+// It then stops accepting new calls and waits for pending ones to finish before returning.
+func Serve(ctx context.Context, server *grpc.Server, lis net.Listener) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(lis)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(ShutdownTimeout):
+		server.Stop()
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
+)
+
+// StatusCodeKey is the span attribute containing the grpc status code of a call.
+const StatusCodeKey = attribute.Key("rpc.grpc.status_code")
+
+// UnaryClientTracing starts a client span for the call and passes the trace context in the outgoing metadata.
+func UnaryClientTracing(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ctx, span := tracing.Start(
+		ctx,
+		strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributesFor(method)...),
+	)
+	defer span.End()
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	} else {
+		md = md.Copy()
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+
+	err := invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+	setStatus(span, err)
+
+	return err
+}
+
+// UnaryServerTracing starts a server span for the call, continuing the trace passed in the incoming metadata.
+func UnaryServerTracing(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+
+	ctx, span := tracing.Start(
+		ctx,
+		strings.TrimPrefix(info.FullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attributesFor(info.FullMethod)...),
+	)
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+	setStatus(span, err)
+
+	return resp, err
+}
+
+func setStatus(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(StatusCodeKey.Int64(int64(s.Code())))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, s.Message())
+	}
+}
+
+// attributesFor splits a full method name in the form of "/package.Service/Method".
+func attributesFor(method string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemKey.String("grpc")}
+
+	parts := strings.SplitN(strings.TrimPrefix(method, "/"), "/", 2)
+	if len(parts) != 2 {
+		return attrs
+	}
+
+	return append(attrs, semconv.RPCServiceKey.String(parts[0]), semconv.RPCMethodKey.String(parts[1]))
+}
+
+// metadataCarrier adapts grpc metadata to be used by propagators.
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	values := metadata.MD(m).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
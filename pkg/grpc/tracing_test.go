@@ -0,0 +1,67 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	grpcutil "github.com/soapboxsocial/soapbox/pkg/grpc"
+)
+
+func TestUnaryTracing(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var serverSpan trace.SpanContext
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		serverSpan = trace.SpanContextFromContext(ctx)
+		return nil, nil
+	}
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		ctx = metadata.NewIncomingContext(context.Background(), md)
+
+		_, err := grpcutil.UnaryServerTracing(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	err := grpcutil.UnaryClientTracing(context.Background(), "/soapbox.v1.RoomService/GetRoom", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = provider.ForceFlush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("unexpected amount of spans %d", len(spans))
+	}
+
+	server, client := spans[0], spans[1]
+	if server.SpanKind != trace.SpanKindServer || client.SpanKind != trace.SpanKindClient {
+		t.Fatalf("unexpected span kinds %s %s", server.SpanKind, client.SpanKind)
+	}
+
+	if server.Name != "soapbox.v1.RoomService/GetRoom" {
+		t.Fatalf("unexpected span name %s", server.Name)
+	}
+
+	if server.Parent.SpanID() != client.SpanContext.SpanID() || serverSpan.TraceID() != client.SpanContext.TraceID() {
+		t.Fatal("server span is not a child of the client span")
+	}
+}
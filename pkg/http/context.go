@@ -1,6 +1,10 @@
 package http
 
-import "context"
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
 
 type key string
 
@@ -17,3 +21,34 @@ func GetUserIDFromContext(ctx context.Context) (int, bool) {
 func WithUserID(ctx context.Context, id int) context.Context {
 	return context.WithValue(ctx, userID, id)
 }
+
+const requestID key = "request_id"
+
+// GetRequestIDFromContext returns a request ID from a context
+func GetRequestIDFromContext(ctx context.Context) (string, bool) {
+	val := ctx.Value(requestID)
+	id, ok := val.(string)
+	return id, ok
+}
+
+// WithRequestID stores a request ID in the context
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestID, id)
+}
+
+// DetachedContext returns a context that is never canceled but keeps the request ID and trace of the parent.
+// It is used for work that outlives the request, like publishing events from goroutines.
+func DetachedContext(parent context.Context) context.Context {
+	ctx := context.Background()
+
+	if sc := trace.SpanContextFromContext(parent); sc.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, sc)
+	}
+
+	id, ok := GetRequestIDFromContext(parent)
+	if !ok {
+		return ctx
+	}
+
+	return WithRequestID(ctx, id)
+}
@@ -22,3 +22,19 @@ func TestWithUserID(t *testing.T) {
 		t.Fatalf("%d does not match %d", val, id)
 	}
 }
+
+func TestWithRequestID(t *testing.T) {
+	ctx := context.Background()
+	id := "1234"
+
+	with := http.WithRequestID(ctx, id)
+
+	val, ok := http.GetRequestIDFromContext(with)
+	if !ok {
+		t.Fatal("no request ID stored")
+	}
+
+	if val != id {
+		t.Fatalf("%s does not match %s", val, id)
+	}
+}
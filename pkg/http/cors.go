@@ -15,6 +15,7 @@ func AllowedHeaders() handlers.CORSOption {
 		"Accept-Encoding",
 		"Content-Language",
 		"Origin",
+		RequestIDHeader,
 	})
 }
 
@@ -33,6 +34,10 @@ func AllowedMethods() handlers.CORSOption {
 	})
 }
 
+func ExposedHeaders() handlers.CORSOption {
+	return handlers.ExposedHeaders([]string{RequestIDHeader})
+}
+
 func CORS(h http.Handler) http.Handler {
-	return handlers.CORS(AllowedOrigins(), AllowedHeaders(), AllowedMethods())(h)
+	return handlers.CORS(AllowedOrigins(), AllowedHeaders(), AllowedMethods(), ExposedHeaders())(h)
 }
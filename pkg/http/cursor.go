@@ -0,0 +1,87 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+const (
+	// DefaultLimit is the page size used when a request does not pass a limit.
+	DefaultLimit = 10
+
+	// MaxLimit is the largest page size a request can ask for.
+	MaxLimit = 100
+)
+
+// ErrInvalidCursor is returned when a cursor can not be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Page is returned by paginated list endpoints.
+// Clients request the next page by passing NextCursor as the `cursor` query parameter, it is nil on the last page.
+type Page struct {
+	Items      interface{} `json:"items"`
+	NextCursor *string     `json:"next_cursor"`
+}
+
+// NewPage returns a page of items, if next is not nil it is encoded as the cursor for the following page.
+func NewPage(items interface{}, next interface{}) (*Page, error) {
+	page := &Page{Items: items}
+	if next == nil {
+		return page, nil
+	}
+
+	cursor, err := EncodeCursor(next)
+	if err != nil {
+		return nil, err
+	}
+
+	page.NextCursor = &cursor
+	return page, nil
+}
+
+// EncodeCursor encodes the position v as an opaque cursor.
+func EncodeCursor(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes the cursor in the `cursor` query parameter into v.
+// It returns false if the request did not pass a cursor, meaning the first page was requested.
+func DecodeCursor(values url.Values, v interface{}) (bool, error) {
+	cursor := values.Get("cursor")
+	if cursor == "" {
+		return false, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return false, ErrInvalidCursor
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return false, ErrInvalidCursor
+	}
+
+	return true, nil
+}
+
+// GetLimit returns the `limit` query parameter, bounded by MaxLimit.
+func GetLimit(values url.Values) int {
+	limit := GetInt(values, "limit", DefaultLimit)
+	if limit <= 0 {
+		return DefaultLimit
+	}
+
+	if limit > MaxLimit {
+		return MaxLimit
+	}
+
+	return limit
+}
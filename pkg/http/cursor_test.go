@@ -0,0 +1,96 @@
+package http_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+func TestCursor(t *testing.T) {
+	type position struct {
+		ID int `json:"id"`
+	}
+
+	page, err := http.NewPage([]int{1, 2}, position{ID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if page.NextCursor == nil {
+		t.Fatal("expected next cursor")
+	}
+
+	values := url.Values{}
+	values.Set("cursor", *page.NextCursor)
+
+	result := position{}
+	ok, err := http.DecodeCursor(values, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok || result.ID != 2 {
+		t.Fatalf("unexpected cursor %v", result)
+	}
+}
+
+func TestNewPage_LastPage(t *testing.T) {
+	page, err := http.NewPage([]int{1, 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if page.NextCursor != nil {
+		t.Fatal("unexpected next cursor")
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	var tests = []struct {
+		cursor string
+		ok     bool
+		err    error
+	}{
+		{"", false, nil},
+		{"not base64!", false, http.ErrInvalidCursor},
+		{"bm90IGpzb24", false, http.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cursor, func(t *testing.T) {
+			values := url.Values{}
+			values.Set("cursor", tt.cursor)
+
+			var v struct{}
+			ok, err := http.DecodeCursor(values, &v)
+			if ok != tt.ok || err != tt.err {
+				t.Fatalf("expected (%v, %v) actual (%v, %v)", tt.ok, tt.err, ok, err)
+			}
+		})
+	}
+}
+
+func TestGetLimit(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected int
+	}{
+		{"", http.DefaultLimit},
+		{"-1", http.DefaultLimit},
+		{"20", 20},
+		{"1000", http.MaxLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			values := url.Values{}
+			values.Set("limit", tt.value)
+
+			result := http.GetLimit(values)
+			if result != tt.expected {
+				t.Fatalf("expected %d does not match actual %d", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// KeepAliveInterval is how often idle event streams should send a comment so proxies do not close them.
+const KeepAliveInterval = 30 * time.Second
+
+var ErrStreamingUnsupported = errors.New("streaming unsupported")
+
+// EventStream writes server-sent events to a response.
+type EventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// NewEventStream starts a server-sent events response.
+// It fails with ErrStreamingUnsupported before anything is written if the response can not be flushed.
+func NewEventStream(w http.ResponseWriter) (*EventStream, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, ErrStreamingUnsupported
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	// stops nginx from buffering the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &EventStream{w: w, flusher: flusher}, nil
+}
+
+// Send writes an event with data encoded as JSON, the id is left out when empty.
+// Events are buffered until Flush is called.
+func (s *EventStream) Send(id, event string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		_, err = fmt.Fprintf(s.w, "id: %s\n", id)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, encoded)
+	return err
+}
+
+// KeepAlive writes a comment and flushes the stream.
+func (s *EventStream) KeepAlive() error {
+	_, err := io.WriteString(s.w, ": keep-alive\n\n")
+	if err != nil {
+		return err
+	}
+
+	s.Flush()
+	return nil
+}
+
+// Flush sends all buffered events to the client.
+func (s *EventStream) Flush() {
+	s.flusher.Flush()
+}
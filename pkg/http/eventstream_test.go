@@ -0,0 +1,42 @@
+package http_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+func TestEventStream(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	stream, err := httputil.NewEventStream(rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = stream.Send("1", "foo", map[string]int{"bar": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = stream.Send("", "baz", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream.Flush()
+
+	if rr.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected content type %s", rr.Header().Get("Content-Type"))
+	}
+
+	expected := "id: 1\nevent: foo\ndata: {\"bar\":1}\n\nevent: baz\ndata: null\n\n"
+	if rr.Body.String() != expected {
+		t.Fatalf("expected %q actual %q", expected, rr.Body.String())
+	}
+
+	if !rr.Flushed {
+		t.Fatal("stream was not flushed")
+	}
+}
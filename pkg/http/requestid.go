@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header used to pass request IDs to and from clients.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID is a middleware that sets a request ID on the context.
+// If the request already contains an ID it is reused, otherwise a new one is generated.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+
+		next.ServeHTTP(w, req.WithContext(WithRequestID(req.Context(), id)))
+	})
+}
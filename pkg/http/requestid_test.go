@@ -0,0 +1,48 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+func TestRequestID(t *testing.T) {
+	var tests = []struct {
+		name     string
+		incoming string
+	}{
+		{"generated", ""},
+		{"reused", "1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id string
+			handler := httputil.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				id, _ = httputil.GetRequestIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(httputil.RequestIDHeader, tt.incoming)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if id == "" {
+				t.Fatal("no request ID set")
+			}
+
+			if tt.incoming != "" && id != tt.incoming {
+				t.Fatalf("expected %s actual %s", tt.incoming, id)
+			}
+
+			if rr.Header().Get(httputil.RequestIDHeader) != id {
+				t.Fatalf("header %s does not match %s", rr.Header().Get(httputil.RequestIDHeader), id)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ShutdownTimeout is how long a server waits for in-flight requests to finish when shutting down.
+const ShutdownTimeout = 15 * time.Second
+
+// ListenAndServe serves handler on addr until ctx is canceled.
+// It then stops accepting new connections and waits for in-flight requests to finish before returning.
+func ListenAndServe(ctx context.Context, addr string, handler http.Handler) error {
+	server := &http.Server{Addr: addr, Handler: handler}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+
+	return server.Shutdown(shutdown)
+}
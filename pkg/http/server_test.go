@@ -0,0 +1,85 @@
+package http_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+func TestListenAndServe_DrainsRequests(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := lis.Addr().String()
+	_ = lis.Close()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		_, _ = w.Write([]byte("done"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	served := make(chan error, 1)
+	go func() {
+		served <- httputil.ListenAndServe(ctx, addr, handler)
+	}()
+
+	body := make(chan string, 1)
+	go func() {
+		var (
+			resp *http.Response
+			err  error
+		)
+
+		for i := 0; i < 50; i++ {
+			resp, err = http.Get("http://" + addr)
+			if err == nil {
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+
+		defer resp.Body.Close()
+
+		data, _ := ioutil.ReadAll(resp.Body)
+		body <- string(data)
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-served:
+		t.Fatalf("server returned before the request finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	if val := <-body; val != "done" {
+		t.Fatalf("unexpected body %s", val)
+	}
+
+	err = <-served
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
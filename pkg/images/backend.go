@@ -1,41 +1,113 @@
 package images
 
 import (
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+
+	"github.com/soapboxsocial/soapbox/pkg/storage"
 )
 
 type Backend struct {
-	path string
+	blob storage.Blob
 }
 
-func NewImagesBackend(path string) *Backend {
+func NewImagesBackend(blob storage.Blob) *Backend {
 	return &Backend{
-		path: path,
+		blob: blob,
 	}
 }
 
-func (ib *Backend) Store(bytes []byte) (string, error) {
-	return ib.store(ib.path, bytes)
-}
+// Store processes an uploaded image and stores it along with its variants, it returns the name of the image.
+func (ib *Backend) Store(ctx context.Context, data []byte) (string, error) {
+	// every variant is advertised in both formats, so nothing is stored unless WebP can be encoded.
+	err := checkWebP()
+	if err != nil {
+		return "", err
+	}
 
-func (ib *Backend) Remove(name string) error {
-	return os.Remove(ib.path + "/" + name)
-}
+	img, err := Decode(data)
+	if err != nil {
+		return "", err
+	}
 
-func (ib *Backend) store(path string, bytes []byte) (string, error) {
-	file, err := ioutil.TempFile(path, "*.png")
+	name, err := storage.NewName(".png")
 	if err != nil {
 		return "", err
 	}
 
-	defer file.Close()
+	// the original is capped at the largest variant, clients should never need more.
+	err = ib.put(ctx, name, Resize(img, Sizes[len(Sizes)-1]))
+	if err != nil {
+		return "", err
+	}
+
+	err = ib.writeVariants(ctx, name, img)
+	if err != nil {
+		_ = ib.Remove(ctx, name)
+		return "", err
+	}
+
+	return name, nil
+}
+
+// CreateVariants generates the variants of an already stored image.
+func (ib *Backend) CreateVariants(ctx context.Context, name string) error {
+	r, err := ib.blob.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return err
+	}
+
+	return ib.writeVariants(ctx, name, img)
+}
+
+// Remove deletes an image along with its variants.
+func (ib *Backend) Remove(ctx context.Context, name string) error {
+	for _, variant := range Variants(name) {
+		_ = ib.blob.Delete(ctx, variant.PNG)
+		_ = ib.blob.Delete(ctx, variant.WebP)
+	}
+
+	return ib.blob.Delete(ctx, name)
+}
+
+func (ib *Backend) writeVariants(ctx context.Context, name string, img image.Image) error {
+	for _, size := range Sizes {
+		resized := Resize(img, size)
+
+		err := ib.put(ctx, VariantName(name, size, FormatPNG), resized)
+		if err != nil {
+			return err
+		}
+
+		data, err := encodeWebP(resized)
+		if err != nil {
+			return err
+		}
+
+		err = ib.blob.Put(ctx, VariantName(name, size, FormatWebP), bytes.NewReader(data), "image/webp")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-	_, err = file.Write(bytes)
+func (ib *Backend) put(ctx context.Context, name string, img image.Image) error {
+	buf := new(bytes.Buffer)
+	err := png.Encode(buf, img)
 	if err != nil {
-		return "", nil
+		return err
 	}
 
-	return filepath.Base(file.Name()), nil
+	return ib.blob.Put(ctx, name, buf, "image/png")
 }
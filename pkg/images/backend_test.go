@@ -0,0 +1,42 @@
+package images_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+)
+
+func TestBackend_StoreRequiresWebP(t *testing.T) {
+	// cwebp can not be found on an empty path.
+	path := os.Getenv("PATH")
+	t.Cleanup(func() {
+		_ = os.Setenv("PATH", path)
+	})
+
+	err := os.Setenv("PATH", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob := storage.NewLocal(t.TempDir())
+	backend := images.NewImagesBackend(blob)
+
+	ctx := context.Background()
+
+	_, err = backend.Store(ctx, encodePNG(t, 200, 100))
+	if err != images.ErrWebPUnavailable {
+		t.Fatalf("unexpected err %v", err)
+	}
+
+	stored, err := blob.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stored) != 0 {
+		t.Fatalf("unexpected files %v", stored)
+	}
+}
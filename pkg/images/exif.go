@@ -0,0 +1,129 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const orientationTag = 0x0112
+
+// orientation returns the EXIF orientation of a JPEG image, 1 meaning it is stored upright.
+func orientation(data []byte) int {
+	// skip the SOI marker and walk the segments until the image data starts.
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return 1
+		}
+
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+
+		// SOS, the image data follows and there is no more metadata.
+		if marker == 0xDA {
+			return 1
+		}
+
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+
+		i = end
+	}
+
+	return 1
+}
+
+// tiffOrientation reads the orientation tag from the first IFD of a TIFF header.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < entries; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:]) != orientationTag {
+			continue
+		}
+
+		value := int(order.Uint16(tiff[entry+8:]))
+		if value < 1 || value > 8 {
+			return 1
+		}
+
+		return value
+	}
+
+	return 1
+}
+
+// orient transforms an image stored with the given EXIF orientation so it is upright.
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	src := toNRGBA(img)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+
+	// orientations 5 to 8 swap the width and height.
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // mirrored horizontally, rotated 270 clockwise
+				dx, dy = y, x
+			case 6: // rotated 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // mirrored horizontally, rotated 90 clockwise
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotated 270 clockwise
+				dx, dy = y, w-1-x
+			}
+
+			copy(dst.Pix[dy*dst.Stride+dx*4:dy*dst.Stride+dx*4+4], src.Pix[y*src.Stride+x*4:y*src.Stride+x*4+4])
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,144 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	// MinDimension is the smallest width or height an uploaded image can have.
+	MinDimension = 64
+
+	// MaxDimension is the largest width or height an uploaded image can have.
+	MaxDimension = 4096
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrInvalidDimensions = errors.New("invalid image dimensions")
+)
+
+// Decode validates and decodes an uploaded PNG or JPEG image.
+// JPEG images are rotated according to their EXIF orientation, all other metadata is dropped.
+func Decode(data []byte) (image.Image, error) {
+	contentType := http.DetectContentType(data)
+	if contentType != "image/png" && contentType != "image/jpeg" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// the header is checked before decoding so we never allocate huge images.
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if !validDimension(config.Width) || !validDimension(config.Height) {
+		return nil, ErrInvalidDimensions
+	}
+
+	if contentType == "image/png" {
+		return png.Decode(bytes.NewReader(data))
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return orient(img, orientation(data)), nil
+}
+
+// Resize scales an image down so that neither side is longer than size, keeping its aspect ratio.
+// Images that already fit are returned as is.
+func Resize(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*size/width)
+		width = size
+	} else {
+		width = max(1, width*size/height)
+		height = size
+	}
+
+	return scale(toNRGBA(img), width, height)
+}
+
+// scale resizes src to width x height by averaging the source pixels covered by each destination pixel.
+func scale(src *image.NRGBA, width, height int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, max((y+1)*sh/height, y*sh/height+1)
+
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, max((x+1)*sw/width, x*sw/width+1)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				i := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					pa := uint64(src.Pix[i+3])
+					r += uint64(src.Pix[i]) * pa
+					g += uint64(src.Pix[i+1]) * pa
+					b += uint64(src.Pix[i+2]) * pa
+					a += pa
+					count++
+					i += 4
+				}
+			}
+
+			o := y*dst.Stride + x*4
+			if a > 0 {
+				dst.Pix[o] = uint8(r / a)
+				dst.Pix[o+1] = uint8(g / a)
+				dst.Pix[o+2] = uint8(b / a)
+			}
+
+			dst.Pix[o+3] = uint8(a / count)
+		}
+	}
+
+	return dst
+}
+
+// toNRGBA returns img as an NRGBA image whose bounds start at the origin.
+func toNRGBA(img image.Image) *image.NRGBA {
+	if nrgba, ok := img.(*image.NRGBA); ok && nrgba.Rect.Min == (image.Point{}) {
+		return nrgba
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Rect, img, bounds.Min, draw.Src)
+	return dst
+}
+
+func validDimension(n int) bool {
+	return n >= MinDimension && n <= MaxDimension
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// IsInvalid returns whether err was caused by an image that can not be accepted, rather than a failure to store it.
+func IsInvalid(err error) bool {
+	return errors.Is(err, ErrUnsupportedFormat) || errors.Is(err, ErrInvalidDimensions)
+}
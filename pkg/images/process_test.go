@@ -0,0 +1,107 @@
+package images_test
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/images"
+)
+
+func TestDecode(t *testing.T) {
+	var tests = []struct {
+		name   string
+		data   []byte
+		err    error
+		width  int
+		height int
+	}{
+		{"png", encodePNG(t, 200, 100), nil, 200, 100},
+		{"jpeg", encodeJPEG(t, 200, 100), nil, 200, 100},
+		{"rotated jpeg", withOrientation(encodeJPEG(t, 200, 100), 6), nil, 100, 200},
+		{"too small", encodePNG(t, 32, 100), images.ErrInvalidDimensions, 0, 0},
+		{"too large", encodePNG(t, images.MaxDimension+1, 100), images.ErrInvalidDimensions, 0, 0},
+		{"unsupported", []byte("GIF89a"), images.ErrUnsupportedFormat, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := images.Decode(tt.data)
+			if err != tt.err {
+				t.Fatalf("expected err %v actual %v", tt.err, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			bounds := img.Bounds()
+			if bounds.Dx() != tt.width || bounds.Dy() != tt.height {
+				t.Fatalf("expected %dx%d actual %dx%d", tt.width, tt.height, bounds.Dx(), bounds.Dy())
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	var tests = []struct {
+		width    int
+		height   int
+		size     int
+		expected image.Point
+	}{
+		{1000, 500, 256, image.Pt(256, 128)},
+		{500, 1000, 64, image.Pt(32, 64)},
+		{100, 100, 256, image.Pt(100, 100)},
+	}
+
+	for _, tt := range tests {
+		img := image.NewNRGBA(image.Rect(0, 0, tt.width, tt.height))
+
+		result := images.Resize(img, tt.size).Bounds().Size()
+		if result != tt.expected {
+			t.Fatalf("expected %v actual %v", tt.expected, result)
+		}
+	}
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	buf := new(bytes.Buffer)
+	err := png.Encode(buf, image.NewNRGBA(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	buf := new(bytes.Buffer)
+	err := jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF segment containing only the orientation tag after the SOI marker.
+func withOrientation(data []byte, orientation byte) []byte {
+	tiff := []byte{
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08, // header, first IFD at offset 8
+		0x00, 0x01, // one entry
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, orientation, 0x00, 0x00, // orientation, SHORT
+		0x00, 0x00, 0x00, 0x00, // no next IFD
+	}
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	length := len(payload) + 2
+
+	segment := append([]byte{0xFF, 0xE1, byte(length >> 8), byte(length)}, payload...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
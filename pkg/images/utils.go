@@ -1,50 +0,0 @@
-package images
-
-import (
-	"bytes"
-	"fmt"
-	"image/jpeg"
-	"image/png"
-	"io/ioutil"
-	"mime/multipart"
-	"net/http"
-
-	"github.com/pkg/errors"
-)
-
-func MultipartFileToPng(file multipart.File) ([]byte, error) {
-	imgBytes, err := ioutil.ReadAll(file)
-	if err != nil {
-		return nil, err
-	}
-
-	pngBytes, err := ToPNG(imgBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	return pngBytes, nil
-}
-
-func ToPNG(imageBytes []byte) ([]byte, error) {
-	contentType := http.DetectContentType(imageBytes)
-
-	switch contentType {
-	case "image/png":
-		return imageBytes, nil
-	case "image/jpeg":
-		img, err := jpeg.Decode(bytes.NewReader(imageBytes))
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to decode jpeg")
-		}
-
-		buf := new(bytes.Buffer)
-		if err := png.Encode(buf, img); err != nil {
-			return nil, errors.Wrap(err, "unable to encode png")
-		}
-
-		return buf.Bytes(), nil
-	}
-
-	return nil, fmt.Errorf("unable to convert %#v to png", contentType)
-}
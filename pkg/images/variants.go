@@ -0,0 +1,57 @@
+package images
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Sizes are the longest side in pixels of the variants generated for every image.
+var Sizes = []int{64, 256, 1024}
+
+// Format is a file format variants are stored in.
+type Format string
+
+const (
+	FormatPNG  Format = "png"
+	FormatWebP Format = "webp"
+)
+
+// Variant contains the file names of an image resized to Size, relative to where the original image is served.
+type Variant struct {
+	Size int    `json:"size"`
+	PNG  string `json:"png"`
+	WebP string `json:"webp"`
+}
+
+var variantName = regexp.MustCompile(`_[0-9]+\.(png|webp)$`)
+
+// Variants returns the resized versions available for the image called name.
+func Variants(name string) []Variant {
+	if name == "" {
+		return nil
+	}
+
+	variants := make([]Variant, 0, len(Sizes))
+	for _, size := range Sizes {
+		variants = append(variants, Variant{
+			Size: size,
+			PNG:  VariantName(name, size, FormatPNG),
+			WebP: VariantName(name, size, FormatWebP),
+		})
+	}
+
+	return variants
+}
+
+// VariantName returns the name of the variant of an image for a size and format.
+func VariantName(name string, size int, format Format) string {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	return base + "_" + strconv.Itoa(size) + "." + string(format)
+}
+
+// IsVariant returns whether a file name belongs to a variant rather than an original image.
+func IsVariant(name string) bool {
+	return variantName.MatchString(name)
+}
@@ -0,0 +1,42 @@
+package images_test
+
+import (
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/images"
+)
+
+func TestVariants(t *testing.T) {
+	variants := images.Variants("123.png")
+	if len(variants) != len(images.Sizes) {
+		t.Fatalf("expected %d variants actual %d", len(images.Sizes), len(variants))
+	}
+
+	expected := images.Variant{Size: 64, PNG: "123_64.png", WebP: "123_64.webp"}
+	if variants[0] != expected {
+		t.Fatalf("expected %v actual %v", expected, variants[0])
+	}
+
+	if images.Variants("") != nil {
+		t.Fatal("expected no variants without an image")
+	}
+}
+
+func TestIsVariant(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected bool
+	}{
+		{"123.png", false},
+		{"123_64.png", true},
+		{"123_1024.webp", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if images.IsVariant(tt.name) != tt.expected {
+				t.Fatalf("expected %v for %s", tt.expected, tt.name)
+			}
+		})
+	}
+}
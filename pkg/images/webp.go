@@ -0,0 +1,63 @@
+package images
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// webpQuality is the quality WebP variants are encoded with, from 0 to 100.
+const webpQuality = 80
+
+var ErrWebPUnavailable = errors.New("cwebp is not installed")
+
+// checkWebP returns ErrWebPUnavailable when cwebp can not be found.
+func checkWebP() error {
+	_, err := exec.LookPath("cwebp")
+	if err != nil {
+		return ErrWebPUnavailable
+	}
+
+	return nil
+}
+
+// encodeWebP encodes an image as WebP using cwebp from libwebp.
+func encodeWebP(img image.Image) ([]byte, error) {
+	bin, err := exec.LookPath("cwebp")
+	if err != nil {
+		return nil, ErrWebPUnavailable
+	}
+
+	dir, err := ioutil.TempDir("", "webp")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "image.png")
+	dst := filepath.Join(dir, "image.webp")
+
+	file, err := os.Create(src)
+	if err != nil {
+		return nil, err
+	}
+
+	err = png.Encode(file, img)
+	_ = file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := exec.Command(bin, "-quiet", "-metadata", "none", "-q", fmt.Sprint(webpQuality), src, "-o", dst).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("cwebp failed: %w: %s", err, out)
+	}
+
+	return ioutil.ReadFile(dst)
+}
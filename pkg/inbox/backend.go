@@ -0,0 +1,187 @@
+// Package inbox keeps a short log of updates for every user and streams them to connected clients.
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+const (
+	// MaxItems is how many items are kept for every user so clients can resume where they left off.
+	MaxItems = 100
+
+	// retention is how long items are kept after the last item was added.
+	retention = 7 * 24 * time.Hour
+)
+
+type Backend struct {
+	rdb   *redis.Client
+	queue *pubsub.Queue
+}
+
+// addScript numbers and stores an item in one step, so items are always stored in the order of their IDs.
+// The encoded item is passed without its ID and unread count, they are prepended to the JSON object.
+var addScript = redis.NewScript(`
+local id = redis.call("INCR", KEYS[1])
+
+local unread
+if ARGV[2] == "1" then
+	unread = redis.call("INCR", KEYS[2])
+else
+	unread = tonumber(redis.call("GET", KEYS[2]) or "0")
+end
+
+local item = '{"id":' .. id .. ',"unread":' .. unread .. ',' .. string.sub(ARGV[1], 2)
+
+redis.call("ZADD", KEYS[3], id, item)
+redis.call("ZREMRANGEBYRANK", KEYS[3], 0, -(tonumber(ARGV[3]) + 1))
+redis.call("EXPIRE", KEYS[3], ARGV[4])
+
+return {id, unread}
+`)
+
+func NewBackend(rdb *redis.Client, queue *pubsub.Queue) *Backend {
+	return &Backend{rdb: rdb, queue: queue}
+}
+
+// Add appends an item to the inbox of a user and wakes up the clients streaming it.
+// Every item except ItemTypeUnread counts as unread.
+func (b *Backend) Add(ctx context.Context, user int, t ItemType, data map[string]interface{}) (*Item, error) {
+	item := &Item{Type: t, Timestamp: time.Now().Unix(), Data: data}
+
+	// the ID and unread count are set by the script, everything else is encoded here.
+	encoded, err := json.Marshal(struct {
+		Type      ItemType               `json:"type"`
+		Timestamp int64                  `json:"timestamp"`
+		Data      map[string]interface{} `json:"data,omitempty"`
+	}{Type: item.Type, Timestamp: item.Timestamp, Data: item.Data})
+	if err != nil {
+		return nil, err
+	}
+
+	unread := "1"
+	if t == ItemTypeUnread {
+		unread = "0"
+	}
+
+	res, err := addScript.Run(
+		ctx,
+		b.rdb,
+		[]string{sequenceKey(user), unreadKey(user), itemsKey(user)},
+		encoded, unread, MaxItems, int(retention.Seconds()),
+	).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 2 {
+		return nil, fmt.Errorf("unexpected result %v", res)
+	}
+
+	item.ID, _ = values[0].(int64)
+	item.Unread, _ = values[1].(int64)
+
+	err = b.queue.Publish(ctx, pubsub.InboxTopic, pubsub.NewInboxUpdateEvent(user))
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// MarkRead resets the unread count of a user, clients are informed through an ItemTypeUnread item.
+func (b *Backend) MarkRead(ctx context.Context, user int) error {
+	err := b.rdb.Set(ctx, unreadKey(user), 0, 0).Err()
+	if err != nil {
+		return err
+	}
+
+	_, err = b.Add(ctx, user, ItemTypeUnread, nil)
+	return err
+}
+
+// Latest returns the ID of the last item added for a user along with their unread count.
+func (b *Backend) Latest(ctx context.Context, user int) (int64, int64, error) {
+	last, err := b.last(ctx, user)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	unread, err := b.unread(ctx, user)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return last, unread, nil
+}
+
+// Since returns up to MaxItems items added for a user after the item with the ID after.
+// It also returns whether items after after were already dropped, in which case clients are out of sync.
+func (b *Backend) Since(ctx context.Context, user int, after int64) ([]Item, bool, error) {
+	values, err := b.rdb.ZRangeByScore(ctx, itemsKey(user), &redis.ZRangeBy{
+		Min:   "(" + strconv.FormatInt(after, 10),
+		Max:   "+inf",
+		Count: MaxItems,
+	}).Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	items := make([]Item, 0, len(values))
+	for _, value := range values {
+		item := Item{}
+		err := json.Unmarshal([]byte(value), &item)
+		if err != nil {
+			return nil, false, err
+		}
+
+		items = append(items, item)
+	}
+
+	last, err := b.last(ctx, user)
+	if err != nil {
+		return nil, false, err
+	}
+
+	missing := after < last && (len(items) == 0 || items[0].ID > after+1)
+
+	return items, missing, nil
+}
+
+func (b *Backend) last(ctx context.Context, user int) (int64, error) {
+	last, err := b.rdb.Get(ctx, sequenceKey(user)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+
+	return last, err
+}
+
+func (b *Backend) unread(ctx context.Context, user int) (int64, error) {
+	unread, err := b.rdb.Get(ctx, unreadKey(user)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+
+	return unread, err
+}
+
+func itemsKey(user int) string {
+	return fmt.Sprintf("inbox_items_%d", user)
+}
+
+func sequenceKey(user int) string {
+	return fmt.Sprintf("inbox_sequence_%d", user)
+}
+
+func unreadKey(user int) string {
+	return fmt.Sprintf("inbox_unread_%d", user)
+}
@@ -0,0 +1,93 @@
+package inbox_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/inbox"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+func TestBackend(t *testing.T) {
+	backend, _ := newBackend(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		item, err := backend.Add(ctx, 1, inbox.ItemTypeNewFollower, map[string]interface{}{"from": 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if item.ID != int64(i+1) || item.Unread != int64(i+1) {
+			t.Fatalf("unexpected item %v", item)
+		}
+	}
+
+	items, missing, err := backend.Since(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if missing || len(items) != 2 || items[0].ID != 2 || items[1].ID != 3 {
+		t.Fatalf("unexpected items %v missing %v", items, missing)
+	}
+
+	if items[1].Unread != 3 {
+		t.Fatalf("expected 3 unread actual %d", items[1].Unread)
+	}
+
+	err = backend.MarkRead(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last, unread, err := backend.Latest(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last != 4 || unread != 0 {
+		t.Fatalf("expected last 4 unread 0 actual %d %d", last, unread)
+	}
+}
+
+func TestBackend_Since_Missing(t *testing.T) {
+	backend, _ := newBackend(t)
+	ctx := context.Background()
+
+	for i := 0; i < inbox.MaxItems+5; i++ {
+		_, err := backend.Add(ctx, 1, inbox.ItemTypeNewFollower, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, missing, err := backend.Since(ctx, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !missing || len(items) != inbox.MaxItems {
+		t.Fatalf("expected %d items to be missing actual %d %v", inbox.MaxItems, len(items), missing)
+	}
+}
+
+func newBackend(t *testing.T) (*inbox.Backend, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(mr.Close)
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	return inbox.NewBackend(rdb, pubsub.NewQueue(rdb)), mr
+}
@@ -0,0 +1,230 @@
+package inbox
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
+)
+
+// pollTimeout is how long a long-poll waits for new items before returning empty, it stays below common proxy timeouts.
+const pollTimeout = 25 * time.Second
+
+type Endpoint struct {
+	backend *Backend
+	hub     *Hub
+}
+
+func NewEndpoint(backend *Backend, hub *Hub) *Endpoint {
+	return &Endpoint{backend: backend, hub: hub}
+}
+
+func (e *Endpoint) Router() *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/stream", e.stream).Methods("GET")
+	r.HandleFunc("/poll", e.poll).Methods("GET")
+
+	return r
+}
+
+// stream sends new inbox items as server-sent events, using the item ID as the event ID.
+// Clients resume with the Last-Event-ID header, or the last_event_id parameter if they can not set it.
+// New streams start with an unread event containing the current unread count.
+func (e *Endpoint) stream(w http.ResponseWriter, r *http.Request) {
+	id, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "unauthorized")
+		return
+	}
+
+	after, resumed, err := lastEventID(r)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid last event id")
+		return
+	}
+
+	wake := e.hub.Subscribe(id)
+	defer e.hub.Unsubscribe(id, wake)
+
+	last, unread, err := e.backend.Latest(r.Context(), id)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.Latest err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	stream, err := httputil.NewEventStream(w)
+	if err != nil {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "streaming unsupported")
+		return
+	}
+
+	if !resumed {
+		after = last
+
+		err := stream.Send(strconv.FormatInt(last, 10), string(ItemTypeUnread), &Item{ID: last, Type: ItemTypeUnread, Unread: unread})
+		if err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(httputil.KeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		items, missing, err := e.backend.Since(r.Context(), id, after)
+		if err != nil {
+			log.Ctx(r.Context()).Printf("backend.Since err: %v", err)
+			return
+		}
+
+		if missing {
+			err := stream.Send("", "reset", struct{}{})
+			if err != nil {
+				return
+			}
+
+			// when all items expired the stream continues from the current state, otherwise every read reports the gap again.
+			if len(items) == 0 {
+				after, _, err = e.backend.Latest(r.Context(), id)
+				if err != nil {
+					log.Ctx(r.Context()).Printf("backend.Latest err: %v", err)
+					return
+				}
+			}
+		}
+
+		for _, item := range items {
+			err := stream.Send(strconv.FormatInt(item.ID, 10), string(item.Type), item)
+			if err != nil {
+				return
+			}
+
+			after = item.ID
+		}
+
+		stream.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case _, ok := <-wake:
+			if !ok {
+				return
+			}
+		case <-ticker.C:
+			err := stream.KeepAlive()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// poll is the long-poll fallback for clients that can not stream, it returns the items after the one passed in after.
+// If there are none it waits for new items for a while. Without after it returns the current state straight away.
+func (e *Endpoint) poll(w http.ResponseWriter, r *http.Request) {
+	id, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "unauthorized")
+		return
+	}
+
+	var after int64
+	param := r.URL.Query().Get("after")
+	if param != "" {
+		var err error
+		after, err = strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid after")
+			return
+		}
+	}
+
+	wake := e.hub.Subscribe(id)
+	defer e.hub.Unsubscribe(id, wake)
+
+	result, err := e.read(r, id, after, param == "")
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to read inbox err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	if param != "" && len(result.Items) == 0 && !result.Reset {
+		timer := time.NewTimer(pollTimeout)
+		defer timer.Stop()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-wake:
+		case <-timer.C:
+		}
+
+		result, err = e.read(r, id, after, false)
+		if err != nil {
+			log.Ctx(r.Context()).Printf("failed to read inbox err: %v", err)
+			httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+			return
+		}
+	}
+
+	err = httputil.JsonEncode(w, result)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to write inbox response: %s", err.Error())
+	}
+}
+
+// read returns the items after after, or only the current state if latest is set.
+func (e *Endpoint) read(r *http.Request, user int, after int64, latest bool) (*Poll, error) {
+	last, unread, err := e.backend.Latest(r.Context(), user)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Poll{Items: []Item{}, Last: last, Unread: unread}
+	if latest {
+		return result, nil
+	}
+
+	items, missing, err := e.backend.Since(r.Context(), user, after)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Items = items
+	result.Reset = missing
+
+	// items may have been added between reading the latest state and the items.
+	if len(items) > 0 {
+		result.Last = items[len(items)-1].ID
+		result.Unread = items[len(items)-1].Unread
+	}
+
+	return result, nil
+}
+
+// lastEventID returns the ID of the last event a client received, and whether it sent one.
+func lastEventID(r *http.Request) (int64, bool, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+
+	if value == "" {
+		return 0, false, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
@@ -0,0 +1,103 @@
+package inbox_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/inbox"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+func TestEndpoint_Stream_ResumeExpired(t *testing.T) {
+	backend, mr := newBackend(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := backend.Add(ctx, 1, inbox.ItemTypeNewFollower, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the items expire while the sequence is kept.
+	mr.FastForward(8 * 24 * time.Hour)
+
+	hub := inbox.NewHub()
+	events := make(chan *pubsub.Event)
+	defer close(events)
+
+	go hub.Run(events)
+
+	endpoint := inbox.NewEndpoint(backend, hub)
+	router := endpoint.Router()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ServeHTTP(w, r.WithContext(httputil.WithUserID(r.Context(), 1)))
+	}))
+	defer server.Close()
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, "GET", server.URL+"/stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Last-Event-ID", "1")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+
+	id, event := nextEvent(t, reader)
+	if event != "reset" {
+		t.Fatalf("expected reset actual %s", event)
+	}
+
+	_, err = backend.Add(ctx, 1, inbox.ItemTypeNewFollower, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events <- getRawEvent(t, pubsub.NewInboxUpdateEvent(1))
+
+	id, event = nextEvent(t, reader)
+	if id != "4" || event != string(inbox.ItemTypeNewFollower) {
+		t.Fatalf("unexpected event %s %s", id, event)
+	}
+}
+
+// nextEvent reads the ID and type of the next server-sent event.
+func nextEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	t.Helper()
+
+	var id, event string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && event != "":
+			return id, event
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package inbox
+
+import (
+	"context"
+
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+// Feeder turns events into inbox items for the users they concern.
+// There must only be a single feeder consuming events, otherwise items are added more than once.
+type Feeder struct {
+	backend *Backend
+	blocks  *blocks.Backend
+}
+
+func NewFeeder(backend *Backend, blocks *blocks.Backend) *Feeder {
+	return &Feeder{backend: backend, blocks: blocks}
+}
+
+// Handle adds an item to the inbox of the user an event concerns, other events are ignored.
+func (f *Feeder) Handle(ctx context.Context, event *pubsub.Event) error {
+	var user, from int
+	var err error
+	var t ItemType
+	data := make(map[string]interface{})
+
+	switch event.Type {
+	case pubsub.EventTypeNewFollower:
+		t = ItemTypeNewFollower
+		user, err = event.GetInt("id")
+		if err != nil {
+			return err
+		}
+
+		from, err = event.GetInt("follower")
+	case pubsub.EventTypeRoomInvite:
+		t = ItemTypeRoomInvite
+		user, err = event.GetInt("id")
+		if err != nil {
+			return err
+		}
+
+		from, err = event.GetInt("from")
+		data["room"] = event.Params["room"]
+		data["name"] = event.Params["name"]
+	case pubsub.EventTypeStoryReaction:
+		t = ItemTypeStoryReaction
+		user, err = event.GetInt("owner")
+		if err != nil {
+			return err
+		}
+
+		from, err = event.GetInt("id")
+		data["story"] = event.Params["story"]
+	case pubsub.EventTypeStoryReply:
+		t = ItemTypeStoryReply
+		user, err = event.GetInt("id")
+		if err != nil {
+			return err
+		}
+
+		from, err = event.GetInt("from")
+		data["story"] = event.Params["story"]
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// users reacting to their own stories do not need to be told about it.
+	if user == 0 || user == from {
+		return nil
+	}
+
+	// users do not hear from anyone they blocked or who blocked them.
+	blocked, err := f.blocks.IsBlocked(ctx, user, from)
+	if err != nil {
+		return err
+	}
+
+	if blocked {
+		return nil
+	}
+
+	data["from"] = from
+
+	_, err = f.backend.Add(ctx, user, t, data)
+	return err
+}
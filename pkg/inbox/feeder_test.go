@@ -0,0 +1,133 @@
+package inbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/inbox"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+func TestFeeder_Handle(t *testing.T) {
+	tests := []struct {
+		event    pubsub.Event
+		user     int
+		expected inbox.ItemType
+	}{
+		{event: pubsub.NewFollowerEvent(2, 1), user: 1, expected: inbox.ItemTypeNewFollower},
+		{event: pubsub.NewRoomInviteEvent("foo", "123", 2, 1), user: 1, expected: inbox.ItemTypeRoomInvite},
+		{event: pubsub.NewStoryReactionEvent(2, "123", 1), user: 1, expected: inbox.ItemTypeStoryReaction},
+		{event: pubsub.NewStoryReplyEvent("123", 2, 1), user: 1, expected: inbox.ItemTypeStoryReply},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.expected), func(t *testing.T) {
+			backend, _ := newBackend(t)
+			feeder, mock := newFeeder(t, backend)
+
+			expectBlocked(mock, tt.user, 2, false)
+
+			err := feeder.Handle(context.Background(), getRawEvent(t, tt.event))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			items, _, err := backend.Since(context.Background(), tt.user, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(items) != 1 || items[0].Type != tt.expected || items[0].Data["from"] != 2.0 {
+				t.Fatalf("unexpected items %v", items)
+			}
+		})
+	}
+}
+
+func TestFeeder_Handle_OwnStory(t *testing.T) {
+	backend, _ := newBackend(t)
+	feeder, _ := newFeeder(t, backend)
+
+	err := feeder.Handle(context.Background(), getRawEvent(t, pubsub.NewStoryReactionEvent(1, "123", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, _, err := backend.Since(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 0 {
+		t.Fatalf("expected no items actual %v", items)
+	}
+}
+
+func TestFeeder_Handle_Blocked(t *testing.T) {
+	backend, _ := newBackend(t)
+	feeder, mock := newFeeder(t, backend)
+
+	expectBlocked(mock, 1, 2, true)
+
+	err := feeder.Handle(context.Background(), getRawEvent(t, pubsub.NewStoryReactionEvent(2, "123", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items, _, err := backend.Since(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 0 {
+		t.Fatalf("expected no items actual %v", items)
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newFeeder(t *testing.T, backend *inbox.Backend) (*inbox.Feeder, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return inbox.NewFeeder(backend, blocks.NewBackend(db)), mock
+}
+
+func expectBlocked(mock sqlmock.Sqlmock, user, other int, blocked bool) {
+	mock.ExpectPrepare("^SELECT EXISTS").
+		ExpectQuery().
+		WithArgs(user, other).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(blocked))
+}
+
+func getRawEvent(t *testing.T, event pubsub.Event) *pubsub.Event {
+	t.Helper()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evt := &pubsub.Event{}
+	err = json.Unmarshal(data, evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return evt
+}
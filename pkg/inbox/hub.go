@@ -0,0 +1,93 @@
+package inbox
+
+import (
+	"sync"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+// Hub wakes up the clients connected to this server when the inbox of their user changes.
+// Every API server runs its own hub, items themselves are always read from redis.
+type Hub struct {
+	mux     sync.Mutex
+	waiters map[int]map[chan struct{}]bool
+	closed  bool
+}
+
+func NewHub() *Hub {
+	return &Hub{waiters: make(map[int]map[chan struct{}]bool)}
+}
+
+// Subscribe returns a channel that receives a value whenever the inbox of a user changes.
+// Wake ups are coalesced, and the channel is closed once the hub stops.
+func (h *Hub) Subscribe(user int) chan struct{} {
+	c := make(chan struct{}, 1)
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if h.closed {
+		close(c)
+		return c
+	}
+
+	if h.waiters[user] == nil {
+		h.waiters[user] = make(map[chan struct{}]bool)
+	}
+
+	h.waiters[user][c] = true
+
+	return c
+}
+
+// Unsubscribe stops wake ups for a channel returned by Subscribe.
+func (h *Hub) Unsubscribe(user int, c chan struct{}) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	delete(h.waiters[user], c)
+
+	if len(h.waiters[user]) == 0 {
+		delete(h.waiters, user)
+	}
+}
+
+// Run wakes up subscribers from inbox update events until events is closed, all channels are closed afterwards.
+func (h *Hub) Run(events <-chan *pubsub.Event) {
+	for event := range events {
+		if event.Type != pubsub.EventTypeInboxUpdate {
+			continue
+		}
+
+		user, err := event.GetInt("id")
+		if err != nil {
+			continue
+		}
+
+		h.wake(user)
+	}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.closed = true
+	for user, waiters := range h.waiters {
+		for c := range waiters {
+			close(c)
+		}
+
+		delete(h.waiters, user)
+	}
+}
+
+func (h *Hub) wake(user int) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	for c := range h.waiters[user] {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package inbox_test
+
+import (
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/inbox"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+func TestHub(t *testing.T) {
+	hub := inbox.NewHub()
+
+	events := make(chan *pubsub.Event)
+	done := make(chan struct{})
+
+	go func() {
+		hub.Run(events)
+		close(done)
+	}()
+
+	wake := hub.Subscribe(1)
+	other := hub.Subscribe(2)
+
+	events <- getRawEvent(t, pubsub.NewInboxUpdateEvent(1))
+	events <- getRawEvent(t, pubsub.NewInboxUpdateEvent(1))
+
+	<-wake
+
+	select {
+	case <-other:
+		t.Fatal("unexpected wake up")
+	default:
+	}
+
+	hub.Unsubscribe(2, other)
+
+	close(events)
+	<-done
+
+	for range wake {
+	}
+}
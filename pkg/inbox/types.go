@@ -0,0 +1,36 @@
+package inbox
+
+type ItemType string
+
+const (
+	ItemTypeNewFollower   ItemType = "new_follower"
+	ItemTypeRoomInvite    ItemType = "room_invite"
+	ItemTypeStoryReaction ItemType = "story_reaction"
+	ItemTypeStoryReply    ItemType = "story_reply"
+
+	// ItemTypeUnread only updates the unread count, it is added when the inbox was read.
+	ItemTypeUnread ItemType = "unread"
+)
+
+// Item is a single update to the inbox of a user, items of a user are numbered in the order they were added.
+type Item struct {
+	ID        int64                  `json:"id"`
+	Type      ItemType               `json:"type"`
+	Timestamp int64                  `json:"timestamp"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+
+	// Unread is the number of unread items once this item was added.
+	Unread int64 `json:"unread"`
+}
+
+// Poll is returned to clients that long-poll instead of streaming the inbox.
+type Poll struct {
+	Items []Item `json:"items"`
+
+	// Last is the ID of the last item, which the next poll continues after.
+	Last   int64 `json:"last"`
+	Unread int64 `json:"unread"`
+
+	// Reset is set when items after the requested one are no longer kept, clients should reload their notifications.
+	Reset bool `json:"reset,omitempty"`
+}
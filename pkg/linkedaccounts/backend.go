@@ -1,6 +1,11 @@
 package linkedaccounts
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+)
 
 type LinkedAccount struct {
 	ID        int
@@ -21,13 +26,13 @@ func NewLinkedAccountsBackend(db *sql.DB) *Backend {
 	}
 }
 
-func (pb *Backend) LinkTwitterProfile(user, profile int, token, secret, username string) error {
-	stmt, err := pb.db.Prepare("INSERT INTO linked_accounts (user_id, provider, profile_id, token, secret, username) VALUES ($1, $2, $3, $4, $5, $6);")
+func (pb *Backend) LinkTwitterProfile(ctx context.Context, user, profile int, token, secret, username string) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, pb.db).PrepareContext(ctx, "INSERT INTO linked_accounts (user_id, provider, profile_id, token, secret, username) VALUES ($1, $2, $3, $4, $5, $6);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(user, "twitter", profile, token, secret, username)
+	_, err = stmt.ExecContext(ctx, user, "twitter", profile, token, secret, username)
 	if err != nil {
 		return err
 	}
@@ -35,13 +40,13 @@ func (pb *Backend) LinkTwitterProfile(user, profile int, token, secret, username
 	return nil
 }
 
-func (pb *Backend) UnlinkTwitterProfile(user int) error {
-	stmt, err := pb.db.Prepare("DELETE FROM linked_accounts WHERE user_id = $1 AND provider = $2;")
+func (pb *Backend) UnlinkTwitterProfile(ctx context.Context, user int) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, pb.db).PrepareContext(ctx, "DELETE FROM linked_accounts WHERE user_id = $1 AND provider = $2;")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(user, "twitter")
+	_, err = stmt.ExecContext(ctx, user, "twitter")
 	if err != nil {
 		return err
 	}
@@ -49,25 +54,25 @@ func (pb *Backend) UnlinkTwitterProfile(user int) error {
 	return nil
 }
 
-func (pb *Backend) UpdateTwitterUsernameFor(user int, username string) error {
-	stmt, err := pb.db.Prepare("UPDATE linked_accounts SET username = $1 WHERE user_id = $2 AND provider = $3")
+func (pb *Backend) UpdateTwitterUsernameFor(ctx context.Context, user int, username string) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, pb.db).PrepareContext(ctx, "UPDATE linked_accounts SET username = $1 WHERE user_id = $2 AND provider = $3")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(username, user, "twitter")
+	_, err = stmt.ExecContext(ctx, username, user, "twitter")
 	return err
 }
 
-func (pb *Backend) GetTwitterProfileFor(user int) (*LinkedAccount, error) {
-	stmt, err := pb.db.Prepare("SELECT profile_id, token, secret, username FROM linked_accounts WHERE user_id = $1 AND provider = $2")
+func (pb *Backend) GetTwitterProfileFor(ctx context.Context, user int) (*LinkedAccount, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, pb.db).PrepareContext(ctx, "SELECT profile_id, token, secret, username FROM linked_accounts WHERE user_id = $1 AND provider = $2")
 	if err != nil {
 		return nil, err
 	}
 
 	account := &LinkedAccount{ID: user, Provider: "twitter"}
 
-	row := stmt.QueryRow(user, "twitter")
+	row := stmt.QueryRowContext(ctx, user, "twitter")
 
 	err = row.Scan(&account.ProfileID, &account.Token, &account.Secret, &account.Username)
 	if err != nil {
@@ -77,18 +82,18 @@ func (pb *Backend) GetTwitterProfileFor(user int) (*LinkedAccount, error) {
 	return account, nil
 }
 
-func (pb *Backend) GetAllTwitterProfilesForUsersNotRecommendedToAndNotFollowedBy(user int) ([]LinkedAccount, error) {
+func (pb *Backend) GetAllTwitterProfilesForUsersNotRecommendedToAndNotFollowedBy(ctx context.Context, user int) ([]LinkedAccount, error) {
 	query := `
 		SELECT user_id, profile_id, token, secret, username FROM linked_accounts 
 		WHERE user_id NOT IN (SELECT user_id FROM followers WHERE follower = $1) 
    		AND user_id NOT IN (SELECT recommendation FROM follow_recommendations WHERE user_id = $1) AND user_id != $1`
 
-	stmt, err := pb.db.Prepare(query)
+	stmt, err := sqlutil.ExecutorFrom(ctx, pb.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(user)
+	rows, err := stmt.QueryContext(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,78 @@
+// Package log contains the structured logger shared by all services.
+package log
+
+import (
+	"context"
+	stdlog "log"
+	"os"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+// Fields under which the request and trace IDs are logged.
+const (
+	RequestIDField = "request_id"
+	TraceIDField   = "trace_id"
+)
+
+// Logger writes structured log lines.
+type Logger struct {
+	logger zerolog.Logger
+}
+
+var base = zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+func init() {
+	// Any output written through the standard logger is also structured.
+	stdlog.SetFlags(0)
+	stdlog.SetOutput(base)
+}
+
+// Ctx returns a logger that includes the request and trace IDs stored in the context, if any.
+func Ctx(ctx context.Context) *Logger {
+	id, ok := httputil.GetRequestIDFromContext(ctx)
+	sc := trace.SpanContextFromContext(ctx)
+
+	if (!ok || id == "") && !sc.IsValid() {
+		return &Logger{logger: base}
+	}
+
+	with := base.With()
+	if ok && id != "" {
+		with = with.Str(RequestIDField, id)
+	}
+
+	if sc.IsValid() {
+		with = with.Str(TraceIDField, sc.TraceID().String())
+	}
+
+	return &Logger{logger: with.Logger()}
+}
+
+// Printf logs a formatted message.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	l.logger.Log().Msgf(format, v...)
+}
+
+// Println logs a message.
+func (l *Logger) Println(v ...interface{}) {
+	l.logger.Log().Msg(sprintln(v...))
+}
+
+// Printf logs a formatted message without any request context.
+func Printf(format string, v ...interface{}) {
+	base.Log().Msgf(format, v...)
+}
+
+// Println logs a message without any request context.
+func Println(v ...interface{}) {
+	base.Log().Msg(sprintln(v...))
+}
+
+// Panicf logs a formatted message and panics.
+func Panicf(format string, v ...interface{}) {
+	base.Panic().Msgf(format, v...)
+}
@@ -0,0 +1,74 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+func TestCtx(t *testing.T) {
+	buf := capture(t)
+
+	ctx := httputil.WithRequestID(context.Background(), "1234")
+	Ctx(ctx).Printf("foo %d", 1)
+
+	line := make(map[string]interface{})
+	err := json.Unmarshal(buf.Bytes(), &line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if line[RequestIDField] != "1234" {
+		t.Fatalf("unexpected request id %v", line[RequestIDField])
+	}
+
+	if line["message"] != "foo 1" {
+		t.Fatalf("unexpected message %v", line["message"])
+	}
+}
+
+func TestCtx_WithTraceID(t *testing.T) {
+	buf := capture(t)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	Ctx(ctx).Println("foo")
+
+	line := make(map[string]interface{})
+	err := json.Unmarshal(buf.Bytes(), &line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if line[TraceIDField] != traceID.String() {
+		t.Fatalf("unexpected trace id %v", line[TraceIDField])
+	}
+
+	if _, ok := line[RequestIDField]; ok {
+		t.Fatal("unexpected request id")
+	}
+}
+
+// capture replaces the base logger with one writing to the returned buffer until the test finishes.
+func capture(t *testing.T) *bytes.Buffer {
+	original := base
+	t.Cleanup(func() {
+		base = original
+	})
+
+	buf := &bytes.Buffer{}
+	base = zerolog.New(buf)
+	return buf
+}
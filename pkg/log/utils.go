@@ -0,0 +1,10 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+)
+
+func sprintln(v ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(v...), "\n")
+}
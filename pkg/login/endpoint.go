@@ -5,7 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
-	"log"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"strings"
@@ -14,10 +14,12 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/soapboxsocial/soapbox/pkg/account"
 	"github.com/soapboxsocial/soapbox/pkg/apple"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
 	"github.com/soapboxsocial/soapbox/pkg/http/middlewares"
 	"github.com/soapboxsocial/soapbox/pkg/images"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/login/internal"
 	"github.com/soapboxsocial/soapbox/pkg/mail"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
@@ -53,6 +55,7 @@ type Endpoint struct {
 
 	state    *StateManager
 	users    *users.Backend
+	accounts *account.Backend
 	sessions *sessions.SessionManager
 
 	ib *images.Backend
@@ -69,6 +72,7 @@ type Endpoint struct {
 
 func NewEndpoint(
 	ub *users.Backend,
+	accounts *account.Backend,
 	state *StateManager,
 	manager *sessions.SessionManager,
 	mail *mail.Service,
@@ -80,6 +84,7 @@ func NewEndpoint(
 ) Endpoint {
 	return Endpoint{
 		users:           ub,
+		accounts:        accounts,
 		state:           state,
 		sessions:        manager,
 		mail:            mail,
@@ -137,7 +142,7 @@ func (e *Endpoint) start(w http.ResponseWriter, r *http.Request) {
 		pin = "098316"
 	}
 
-	isApple, err := e.users.IsAppleIDAccount(email)
+	isApple, err := e.users.IsAppleIDAccount(r.Context(), email)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 		return
@@ -149,7 +154,7 @@ func (e *Endpoint) start(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !e.config.RegisterWithEmailEnabled {
-		isRegistered, err := e.users.IsRegistered(email)
+		isRegistered, err := e.users.IsRegistered(r.Context(), email)
 		if err != nil {
 			httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 			return
@@ -170,14 +175,14 @@ func (e *Endpoint) start(w http.ResponseWriter, r *http.Request) {
 	if email != TestEmail {
 		err = e.mail.SendPinEmail(email, pin)
 		if err != nil {
-			log.Println("failed to send code: ", err.Error())
+			log.Ctx(r.Context()).Println("failed to send code: ", err.Error())
 			httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToLogin, "failed to send code")
 		}
 	}
 
 	err = json.NewEncoder(w).Encode(map[string]string{"token": token})
 	if err != nil {
-		log.Println("error writing response: " + err.Error())
+		log.Ctx(r.Context()).Println("error writing response: " + err.Error())
 	}
 }
 
@@ -196,7 +201,7 @@ func (e *Endpoint) loginWithApple(w http.ResponseWriter, r *http.Request) {
 
 	userInfo, err := e.signInWithApple.Validate(jwt)
 	if err != nil {
-		log.Printf("apple validation err: %v", err)
+		log.Ctx(r.Context()).Printf("apple validation err: %v", err)
 		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "failed to validate")
 		return
 	}
@@ -207,10 +212,10 @@ func (e *Endpoint) loginWithApple(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := e.users.FindByAppleID(userInfo.ID)
+	user, err := e.users.FindByAppleID(r.Context(), userInfo.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			e.enterAppleRegistrationState(w, token, userInfo.Email, userInfo.ID)
+			e.enterAppleRegistrationState(r.Context(), w, token, userInfo.Email, userInfo.ID)
 			return
 		}
 
@@ -218,6 +223,13 @@ func (e *Endpoint) loginWithApple(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = e.cancelDeletion(r.Context(), user.ID)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to cancel deletion err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToLogin, "")
+		return
+	}
+
 	err = e.sessions.NewSession(token, user.ID, expiration)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToLogin, "")
@@ -227,7 +239,7 @@ func (e *Endpoint) loginWithApple(w http.ResponseWriter, r *http.Request) {
 	expires := int(expiration.Seconds())
 	err = httputil.JsonEncode(w, loginState{State: LoginStateSuccess, User: user, ExpiresIn: &expires, Token: &token})
 	if err != nil {
-		log.Println("error writing response: " + err.Error())
+		log.Ctx(r.Context()).Println("error writing response: " + err.Error())
 
 	}
 }
@@ -255,10 +267,10 @@ func (e *Endpoint) submitPin(w http.ResponseWriter, r *http.Request) {
 
 	e.state.RemoveState(token)
 
-	user, err := e.users.FindByEmail(state.Email)
+	user, err := e.users.FindByEmail(r.Context(), state.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			e.enterRegistrationState(w, token, state.Email)
+			e.enterRegistrationState(r.Context(), w, token, state.Email)
 			return
 		}
 
@@ -266,6 +278,13 @@ func (e *Endpoint) submitPin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = e.cancelDeletion(r.Context(), user.ID)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to cancel deletion err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToLogin, "")
+		return
+	}
+
 	err = e.sessions.NewSession(token, user.ID, expiration)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToLogin, "")
@@ -275,12 +294,33 @@ func (e *Endpoint) submitPin(w http.ResponseWriter, r *http.Request) {
 	expires := int(expiration.Seconds())
 	err = httputil.JsonEncode(w, loginState{State: LoginStateSuccess, User: user, ExpiresIn: &expires})
 	if err != nil {
-		log.Println("error writing response: " + err.Error())
+		log.Ctx(r.Context()).Println("error writing response: " + err.Error())
+
+	}
+}
+
+// cancelDeletion restores the account of a user logging in if it was scheduled for deletion.
+func (e *Endpoint) cancelDeletion(ctx context.Context, user int) error {
+	cancelled, err := e.accounts.CancelDeletion(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if !cancelled {
+		return nil
+	}
 
+	log.Ctx(ctx).Printf("cancelled deletion of user %d", user)
+
+	err = e.queue.Publish(ctx, pubsub.UserTopic, pubsub.NewUserDeletionCancelledEvent(user))
+	if err != nil {
+		log.Ctx(ctx).Printf("queue.Publish err: %v", err)
 	}
+
+	return nil
 }
 
-func (e *Endpoint) enterRegistrationState(w http.ResponseWriter, token, email string) {
+func (e *Endpoint) enterRegistrationState(ctx context.Context, w http.ResponseWriter, token, email string) {
 	err := e.state.SetRegistrationState(token, email)
 	if err != nil {
 		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "")
@@ -289,11 +329,11 @@ func (e *Endpoint) enterRegistrationState(w http.ResponseWriter, token, email st
 
 	err = httputil.JsonEncode(w, loginState{State: LoginStateRegister})
 	if err != nil {
-		log.Println("error writing response: " + err.Error())
+		log.Ctx(ctx).Println("error writing response: " + err.Error())
 	}
 }
-func (e *Endpoint) enterAppleRegistrationState(w http.ResponseWriter, token, email, userID string) {
-	_, err := e.users.FindByEmail(email)
+func (e *Endpoint) enterAppleRegistrationState(ctx context.Context, w http.ResponseWriter, token, email, userID string) {
+	_, err := e.users.FindByEmail(ctx, email)
 	if err == nil { // @TODO THIS MEANS THE USER IS ALREADY EXISTING
 		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid login method for user")
 		return
@@ -312,7 +352,7 @@ func (e *Endpoint) enterAppleRegistrationState(w http.ResponseWriter, token, ema
 
 	err = httputil.JsonEncode(w, loginState{State: LoginStateRegister, Token: &token})
 	if err != nil {
-		log.Println("error writing response: " + err.Error())
+		log.Ctx(ctx).Println("error writing response: " + err.Error())
 	}
 }
 
@@ -353,22 +393,28 @@ func (e *Endpoint) register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	image, err := e.processProfilePicture(file)
+	image, err := e.processProfilePicture(r.Context(), file)
 	if err != nil {
+		if images.IsInvalid(err) {
+			httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid image")
+			return
+		}
+
+		log.Ctx(r.Context()).Printf("failed to store profile picture err: %v", err)
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 		return
 	}
 
 	var lastID int
 	if state.AppleUserID != "" {
-		lastID, err = e.users.CreateUserWithAppleLogin(state.Email, name, "", image, username, state.AppleUserID)
+		lastID, err = e.users.CreateUserWithAppleLogin(r.Context(), state.Email, name, "", image, username, state.AppleUserID)
 	} else {
-		lastID, err = e.users.CreateUser(state.Email, name, "", image, username)
+		lastID, err = e.users.CreateUser(r.Context(), state.Email, name, "", image, username)
 	}
 
 	// @TODO ALLOW BIO DURING ON-BOARDING
 	if err != nil {
-		_ = e.ib.Remove(image)
+		_ = e.ib.Remove(r.Context(), image)
 
 		if err.Error() == "pq: duplicate key value violates unique constraint \"idx_username\"" {
 			httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeUsernameAlreadyExists, "username already exists")
@@ -387,13 +433,14 @@ func (e *Endpoint) register(w http.ResponseWriter, r *http.Request) {
 		Username:    username,
 		Email:       &state.Email,
 		Image:       image,
+		Images:      images.Variants(image),
 	}
 
 	err = e.sessions.NewSession(token, user.ID, expiration)
 	if err != nil {
-		_ = e.ib.Remove(image)
+		_ = e.ib.Remove(r.Context(), image)
 
-		log.Println("failed to create session: ", err.Error())
+		log.Ctx(r.Context()).Println("failed to create session: ", err.Error())
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToLogin, "")
 		return
 	}
@@ -401,12 +448,12 @@ func (e *Endpoint) register(w http.ResponseWriter, r *http.Request) {
 	expires := int(expiration.Seconds())
 	err = httputil.JsonEncode(w, loginState{State: LoginStateSuccess, User: &user, ExpiresIn: &expires})
 	if err != nil {
-		log.Println("error writing response: " + err.Error())
+		log.Ctx(r.Context()).Println("error writing response: " + err.Error())
 	}
 
-	err = e.queue.Publish(pubsub.UserTopic, pubsub.NewUserEvent(lastID, username))
+	err = e.queue.Publish(r.Context(), pubsub.UserTopic, pubsub.NewUserEvent(lastID, username))
 	if err != nil {
-		log.Printf("queue.Publish err: %v\n", err)
+		log.Ctx(r.Context()).Printf("queue.Publish err: %v", err)
 	}
 }
 
@@ -418,30 +465,30 @@ func (e *Endpoint) completed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp, err := e.roomService.RegisterWelcomeRoom(
-		context.Background(),
+		r.Context(),
 		&pb.RegisterWelcomeRoomRequest{UserId: int64(userID)},
 	)
 
 	if err != nil {
-		log.Printf("client.RegisterWelcomeRoom err %v", err)
+		log.Ctx(r.Context()).Printf("client.RegisterWelcomeRoom err %v", err)
 		return
 	}
 
-	err = e.queue.Publish(pubsub.RoomTopic, pubsub.NewWelcomeRoomEvent(userID, resp.Id))
+	err = e.queue.Publish(r.Context(), pubsub.RoomTopic, pubsub.NewWelcomeRoomEvent(userID, resp.Id))
 	if err != nil {
-		log.Printf("queue.Publish err: %v", err)
+		log.Ctx(r.Context()).Printf("queue.Publish err: %v", err)
 	}
 
 	httputil.JsonSuccess(w)
 }
 
-func (e *Endpoint) processProfilePicture(file multipart.File) (string, error) {
-	pngBytes, err := images.MultipartFileToPng(file)
+func (e *Endpoint) processProfilePicture(ctx context.Context, file multipart.File) (string, error) {
+	data, err := ioutil.ReadAll(file)
 	if err != nil {
 		return "", err
 	}
 
-	name, err := e.ib.Store(pngBytes)
+	name, err := e.ib.Store(ctx, data)
 	if err != nil {
 		return "", err
 	}
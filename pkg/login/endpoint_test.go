@@ -18,6 +18,8 @@ import (
 	"github.com/alicebob/miniredis"
 
 	"github.com/soapboxsocial/soapbox/mocks"
+	"github.com/soapboxsocial/soapbox/pkg/account"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
 
 	"github.com/soapboxsocial/soapbox/pkg/images"
 	"github.com/soapboxsocial/soapbox/pkg/login"
@@ -54,10 +56,11 @@ func TestLoginEndpoint_LoginWithTestAccount(t *testing.T) {
 
 	endpoint := login.NewEndpoint(
 		users.NewBackend(db),
+		account.NewBackend(db),
 		login.NewStateManager(rdb),
 		sessions.NewSessionManager(rdb),
 		mail.NewMailService(&sendgrid.Client{}),
-		images.NewImagesBackend("/foo"),
+		images.NewImagesBackend(storage.NewLocal("/foo")),
 		pubsub.NewQueue(rdb),
 		mocks.NewMockSignInWithApple(ctrl),
 		mocks.NewMockRoomServiceClient(ctrl),
@@ -111,10 +114,11 @@ func TestLoginEndpoint_PinSubmission(t *testing.T) {
 
 	endpoint := login.NewEndpoint(
 		users.NewBackend(db),
+		account.NewBackend(db),
 		state,
 		sessions.NewSessionManager(rdb),
 		mail.NewMailService(&sendgrid.Client{}),
-		images.NewImagesBackend("/foo"),
+		images.NewImagesBackend(storage.NewLocal("/foo")),
 		pubsub.NewQueue(rdb),
 		mocks.NewMockSignInWithApple(ctrl),
 		mocks.NewMockRoomServiceClient(ctrl),
@@ -139,6 +143,10 @@ func TestLoginEndpoint_PinSubmission(t *testing.T) {
 		WithArgs(email).
 		WillReturnRows(mock.NewRows([]string{"id", "display_name", "username", "image", "bio", "email"}).FromCSVString("1,dean,dean,123.png,my bio,test@apple.com"))
 
+	mock.ExpectPrepare("^UPDATE users SET deletion_scheduled_at = NULL").ExpectExec().
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
 	form := url.Values{}
 	form.Add("pin", pin)
 	form.Add("token", token)
@@ -180,10 +188,11 @@ func TestLoginEndpoint_RegistrationCompleted(t *testing.T) {
 
 	endpoint := login.NewEndpoint(
 		users.NewBackend(db),
+		account.NewBackend(db),
 		login.NewStateManager(rdb),
 		sm,
 		mail.NewMailService(&sendgrid.Client{}),
-		images.NewImagesBackend("/foo"),
+		images.NewImagesBackend(storage.NewLocal("/foo")),
 		pubsub.NewQueue(rdb),
 		mocks.NewMockSignInWithApple(ctrl),
 		m,
@@ -37,3 +37,24 @@ func (s *Service) SendPinEmail(recipient, pin string) error {
 
 	return nil
 }
+
+// SendExportEmail sends a user the link to download the export of their data.
+func (s *Service) SendExportEmail(recipient, link string) error {
+	m := mail.NewSingleEmailPlainText(
+		mail.NewEmail("Soapbox", "no-reply@mail.soapbox.social"),
+		"Your Soapbox data is ready",
+		mail.NewEmail("", recipient),
+		fmt.Sprintf("Your data export is ready, you can download it within the next 24 hours: %s", link),
+	)
+
+	resp, err := s.client.Send(m)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("failed to send email %v", resp.Body)
+	}
+
+	return nil
+}
@@ -1,8 +1,8 @@
 package me
 
 import (
-	"log"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
@@ -11,12 +11,17 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/soapboxsocial/soapbox/pkg/activeusers"
+	"github.com/soapboxsocial/soapbox/pkg/followers"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/inbox"
 	"github.com/soapboxsocial/soapbox/pkg/linkedaccounts"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
+	"github.com/soapboxsocial/soapbox/pkg/presence"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/recommendations/follows"
 	"github.com/soapboxsocial/soapbox/pkg/stories"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
 	"github.com/soapboxsocial/soapbox/pkg/users"
 	"github.com/soapboxsocial/soapbox/pkg/users/types"
 )
@@ -29,8 +34,12 @@ type Endpoint struct {
 	stories         *stories.Backend
 	queue           *pubsub.Queue
 	actives         *activeusers.Backend
+	presence        *presence.Backend
+	followers       *followers.FollowersBackend
+	inbox           *inbox.Backend
 	targets         *notifications.Settings
 	recommendations *follows.Backend
+	sessions        *backends.RoomSessionBackend
 }
 
 // Settings represents a users settings
@@ -54,6 +63,7 @@ type Notification struct {
 	Timestamp int64                              `json:"timestamp"`
 	From      *users.NotificationUser            `json:"from"`
 	Room      *string                            `json:"room,omitempty"`
+	Story     *string                            `json:"story,omitempty"`
 	Category  notifications.NotificationCategory `json:"category"`
 }
 
@@ -65,8 +75,12 @@ func NewEndpoint(
 	backend *stories.Backend,
 	queue *pubsub.Queue,
 	actives *activeusers.Backend,
+	presence *presence.Backend,
+	followers *followers.FollowersBackend,
+	inbox *inbox.Backend,
 	targets *notifications.Settings,
 	recommendations *follows.Backend,
+	sessions *backends.RoomSessionBackend,
 ) *Endpoint {
 	return &Endpoint{
 		users:           users,
@@ -76,8 +90,12 @@ func NewEndpoint(
 		stories:         backend,
 		queue:           queue,
 		actives:         actives,
+		presence:        presence,
+		followers:       followers,
+		inbox:           inbox,
 		targets:         targets,
 		recommendations: recommendations,
+		sessions:        sessions,
 	}
 }
 
@@ -93,6 +111,7 @@ func (m *Endpoint) Router() *mux.Router {
 	r.HandleFunc("/settings", m.settings).Methods("GET")
 	r.HandleFunc("/following/recommendations", m.followingRecommendations).Methods("GET")
 	r.HandleFunc("/settings/notifications", m.updateNotificationSettings).Methods("POST")
+	r.HandleFunc("/rooms/history", m.roomHistory).Methods("GET")
 
 	return r
 }
@@ -104,7 +123,7 @@ func (m *Endpoint) me(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := m.users.FindByID(id)
+	user, err := m.users.FindByID(r.Context(), id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeFailedToGetUser, "failed to get self")
 		return
@@ -113,16 +132,18 @@ func (m *Endpoint) me(w http.ResponseWriter, r *http.Request) {
 	has := m.ns.HasNewNotifications(id)
 	me := &Me{user, has}
 
+	ctx := httputil.DetachedContext(r.Context())
+
 	go func() {
-		err := m.queue.Publish(pubsub.UserTopic, pubsub.NewUserHeartbeatEvent(id))
+		err := m.queue.Publish(ctx, pubsub.UserTopic, pubsub.NewUserHeartbeatEvent(id))
 		if err != nil {
-			log.Printf("queue.Publish err %v", err)
+			log.Ctx(ctx).Printf("queue.Publish err %v", err)
 		}
 	}()
 
 	err = httputil.JsonEncode(w, me)
 	if err != nil {
-		log.Printf("failed to write me response: %s\n", err.Error())
+		log.Ctx(r.Context()).Printf("failed to write me response: %s", err.Error())
 	}
 }
 
@@ -133,20 +154,31 @@ func (m *Endpoint) notifications(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cursor := notificationCursor{}
+	paginated, err := httputil.DecodeCursor(r.URL.Query(), &cursor)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
+		return
+	}
+
+	limit := httputil.GetLimit(r.URL.Query())
+
 	list, err := m.ns.GetNotifications(id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToGetUser, "failed to get self")
 		return
 	}
 
+	list, next := paginateNotifications(list, cursor, paginated, limit)
+
 	populated := make([]Notification, 0)
 	for _, notification := range list {
 		populatedNotification := Notification{Timestamp: notification.Timestamp, Category: notification.Category}
 
 		if notification.From != 0 {
-			from, err := m.users.NotificationUserFor(notification.From)
+			from, err := m.users.NotificationUserFor(r.Context(), notification.From)
 			if err != nil {
-				log.Printf("users.NotificationUserFor err: %v\n", err)
+				log.Ctx(r.Context()).Printf("users.NotificationUserFor err: %v", err)
 				continue
 			}
 
@@ -158,15 +190,76 @@ func (m *Endpoint) notifications(w http.ResponseWriter, r *http.Request) {
 			populatedNotification.Room = &room
 		}
 
+		if notification.Category == notifications.STORY_REPLY {
+			story := notification.Arguments["story"].(string)
+			populatedNotification.Story = &story
+		}
+
 		populated = append(populated, populatedNotification)
 	}
 
-	m.ns.MarkNotificationsViewed(id)
+	if !paginated {
+		m.ns.MarkNotificationsViewed(id)
 
-	err = httputil.JsonEncode(w, populated)
-	if err != nil {
-		log.Printf("failed to write me response: %s\n", err.Error())
+		err := m.inbox.MarkRead(r.Context(), id)
+		if err != nil {
+			log.Ctx(r.Context()).Printf("inbox.MarkRead err: %v", err)
+		}
 	}
+
+	m.writePage(w, r, populated, next)
+}
+
+// notificationCursor is the position in the list of notifications, which is ordered from newest to oldest.
+// Notifications sharing a timestamp are told apart by how many of them were already returned.
+type notificationCursor struct {
+	Timestamp int64 `json:"timestamp"`
+	Skip      int   `json:"skip"`
+}
+
+// paginateNotifications returns up to limit notifications following the cursor, and the cursor for the next page.
+func paginateNotifications(list []*notifications.Notification, cursor notificationCursor, paginated bool, limit int) ([]*notifications.Notification, interface{}) {
+	start := 0
+	if paginated {
+		skipped := 0
+		for start < len(list) {
+			notification := list[start]
+			if notification.Timestamp < cursor.Timestamp {
+				break
+			}
+
+			if notification.Timestamp == cursor.Timestamp {
+				if skipped == cursor.Skip {
+					break
+				}
+
+				skipped++
+			}
+
+			start++
+		}
+	}
+
+	list = list[start:]
+	if len(list) <= limit {
+		return list, nil
+	}
+
+	list = list[:limit]
+
+	last := list[len(list)-1].Timestamp
+	next := notificationCursor{Timestamp: last}
+	if paginated && last == cursor.Timestamp {
+		next.Skip = cursor.Skip
+	}
+
+	for _, notification := range list {
+		if notification.Timestamp == last {
+			next.Skip++
+		}
+	}
+
+	return list, next
 }
 
 func (m *Endpoint) addTwitter(w http.ResponseWriter, r *http.Request) {
@@ -204,7 +297,7 @@ func (m *Endpoint) addTwitter(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = m.la.LinkTwitterProfile(id, int(user.ID), token, secret, user.ScreenName)
+	err = m.la.LinkTwitterProfile(r.Context(), id, int(user.ID), token, secret, user.ScreenName)
 	if err != nil {
 		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, err.Error())
 		return
@@ -220,7 +313,7 @@ func (m *Endpoint) removeTwitter(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := m.la.UnlinkTwitterProfile(id)
+	err := m.la.UnlinkTwitterProfile(r.Context(), id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, err.Error())
 		return
@@ -236,16 +329,75 @@ func (m *Endpoint) activeUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	au, err := m.actives.GetActiveUsersForFollower(id)
+	cursor := activeCursor{}
+	paginated, err := httputil.DecodeCursor(r.URL.Query(), &cursor)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
+		return
+	}
+
+	limit := httputil.GetLimit(r.URL.Query())
+
+	following, err := m.followers.GetAllFollowingIDsFor(r.Context(), id)
+	if err != nil {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	online, err := m.presence.GetOnline(r.Context(), following)
 	if err != nil {
+		log.Ctx(r.Context()).Printf("presence.GetOnline err: %v", err)
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 		return
 	}
 
-	err = httputil.JsonEncode(w, au)
+	start := 0
+	if paginated {
+		for start < len(online) && !cursor.before(online[start]) {
+			start++
+		}
+	}
+
+	online = online[start:]
+
+	var next interface{}
+	if len(online) > limit {
+		online = online[:limit]
+		next = newActiveCursor(online[limit-1])
+	}
+
+	au, err := m.actives.GetActiveUsers(r.Context(), online)
 	if err != nil {
-		log.Printf("httputil.JsonEncode err: %s", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
 	}
+
+	m.writePage(w, r, au, next)
+}
+
+// activeCursor is the position in the list of active users, users in a room come first followed by the most
+// recently active. Users that were last active at the same time are ordered by ID.
+type activeCursor struct {
+	InRoom   bool  `json:"in_room"`
+	LastSeen int64 `json:"last_seen"`
+	ID       int   `json:"id"`
+}
+
+func newActiveCursor(p presence.Presence) activeCursor {
+	return activeCursor{InRoom: p.Room != nil, LastSeen: p.LastSeen, ID: p.ID}
+}
+
+// before returns whether the cursor is positioned before p, meaning p belongs on the next page.
+func (c activeCursor) before(p presence.Presence) bool {
+	if inRoom := p.Room != nil; inRoom != c.InRoom {
+		return c.InRoom
+	}
+
+	if p.LastSeen != c.LastSeen {
+		return p.LastSeen < c.LastSeen
+	}
+
+	return p.ID > c.ID
 }
 
 func (m *Endpoint) feed(w http.ResponseWriter, r *http.Request) {
@@ -255,15 +407,31 @@ func (m *Endpoint) feed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s, err := m.stories.GetStoriesForFollower(id, time.Now().Unix())
+	cursor := feedCursor{}
+	_, err := httputil.DecodeCursor(r.URL.Query(), &cursor)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
+		return
+	}
+
+	limit := httputil.GetLimit(r.URL.Query())
+
+	s, err := m.stories.GetStoriesForFollower(r.Context(), id, time.Now().Unix(), cursor.After, limit)
 	if err != nil {
 		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "unauthorized")
 		return
 	}
 
+	ids := make([]int, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
 	feeds := make([]stories.StoryFeed, 0)
-	for id, results := range s {
-		user, err := m.users.FindByID(id)
+	for _, id := range ids {
+		user, err := m.users.FindByID(r.Context(), id)
 		if err != nil {
 			continue
 		}
@@ -273,13 +441,33 @@ func (m *Endpoint) feed(w http.ResponseWriter, r *http.Request) {
 
 		feeds = append(feeds, stories.StoryFeed{
 			User:    *user,
-			Stories: results,
+			Stories: s[id],
 		})
 	}
 
-	err = httputil.JsonEncode(w, feeds)
+	var next interface{}
+	if len(ids) == limit {
+		next = feedCursor{After: ids[len(ids)-1]}
+	}
+
+	m.writePage(w, r, feeds, next)
+}
+
+// feedCursor is the position in the feed, which is ordered by the ID of the user that posted the stories.
+type feedCursor struct {
+	After int `json:"after"`
+}
+
+func (m *Endpoint) writePage(w http.ResponseWriter, r *http.Request, items interface{}, next interface{}) {
+	page, err := httputil.NewPage(items, next)
+	if err != nil {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = httputil.JsonEncode(w, page)
 	if err != nil {
-		log.Printf("failed to write me response: %s\n", err.Error())
+		log.Ctx(r.Context()).Printf("failed to write me response: %s", err.Error())
 	}
 }
 
@@ -290,7 +478,7 @@ func (m *Endpoint) settings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	target, err := m.targets.GetSettingsFor(id)
+	target, err := m.targets.GetSettingsFor(r.Context(), id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 		return
@@ -298,7 +486,7 @@ func (m *Endpoint) settings(w http.ResponseWriter, r *http.Request) {
 
 	err = httputil.JsonEncode(w, &Settings{Notifications: *target})
 	if err != nil {
-		log.Printf("httputil.JsonEncode err: %s", err)
+		log.Ctx(r.Context()).Printf("httputil.JsonEncode err: %s", err)
 	}
 }
 
@@ -333,7 +521,7 @@ func (m *Endpoint) updateNotificationSettings(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	err = m.targets.UpdateSettingsFor(id, notifications.Frequency(frequency), follows, welcomeRooms)
+	err = m.targets.UpdateSettingsFor(r.Context(), id, notifications.Frequency(frequency), follows, welcomeRooms)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 		return
@@ -349,7 +537,7 @@ func (m *Endpoint) followingRecommendations(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	res, err := m.recommendations.RecommendationsFor(id)
+	res, err := m.recommendations.RecommendationsFor(r.Context(), id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 		return
@@ -357,3 +545,56 @@ func (m *Endpoint) followingRecommendations(w http.ResponseWriter, r *http.Reque
 
 	_ = httputil.JsonEncode(w, res)
 }
+
+// sessionCursor is the position in the room history, which is ordered by the time and ID of the room.
+type sessionCursor struct {
+	Ended time.Time `json:"ended"`
+	Room  string    `json:"room"`
+}
+
+// roomHistory lists the rooms hosted by the user with their statistics, most recent first.
+func (m *Endpoint) roomHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "unauthorized")
+		return
+	}
+
+	cursor := sessionCursor{}
+	ok, err := httputil.DecodeCursor(r.URL.Query(), &cursor)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
+		return
+	}
+
+	var before *time.Time
+	if ok {
+		before = &cursor.Ended
+	}
+
+	limit := httputil.GetLimit(r.URL.Query())
+
+	sessions, err := m.sessions.GetSessionsForHost(r.Context(), id, before, cursor.Room, limit)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("sessions.GetSessionsForHost err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	var next interface{}
+	if len(sessions) == limit {
+		last := sessions[len(sessions)-1]
+		next = sessionCursor{Ended: last.Ended, Room: last.Room}
+	}
+
+	page, err := httputil.NewPage(sessions, next)
+	if err != nil {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = httputil.JsonEncode(w, page)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to write room history response: %s", err.Error())
+	}
+}
@@ -1,13 +1,12 @@
 package metadata
 
 import (
-	"context"
-	"log"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
 	"github.com/soapboxsocial/soapbox/pkg/users"
 )
@@ -38,7 +37,7 @@ func (e *Endpoint) user(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 
 	username := params["username"]
-	user, err := e.usersBackend.GetUserByUsername(username)
+	user, err := e.usersBackend.GetUserByUsername(r.Context(), username)
 	if err != nil {
 		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "not found")
 		return
@@ -46,7 +45,7 @@ func (e *Endpoint) user(w http.ResponseWriter, r *http.Request) {
 
 	err = httputil.JsonEncode(w, user)
 	if err != nil {
-		log.Printf("failed to encode: %v", err)
+		log.Ctx(r.Context()).Printf("failed to encode: %v", err)
 	}
 }
 
@@ -59,7 +58,7 @@ func (e *Endpoint) room(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := e.roomService.GetRoom(context.Background(), &pb.GetRoomRequest{Id: id})
+	response, err := e.roomService.GetRoom(r.Context(), &pb.GetRoomRequest{Id: id})
 	if err != nil {
 		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "not found")
 		return
@@ -77,6 +76,6 @@ func (e *Endpoint) room(w http.ResponseWriter, r *http.Request) {
 
 	err = httputil.JsonEncode(w, response.State)
 	if err != nil {
-		log.Printf("failed to encode: %v", err)
+		log.Ctx(r.Context()).Printf("failed to encode: %v", err)
 	}
 }
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// Check returns an error when a dependency is not ready.
+type Check func(ctx context.Context) error
+
+// PostgresCheck ensures the database can be reached.
+func PostgresCheck(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// RedisCheck ensures redis can be reached.
+func RedisCheck(rdb *redis.Client) Check {
+	return func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	}
+}
+
+// GRPCCheck ensures a grpc connection is not failing.
+func GRPCCheck(conn *grpc.ClientConn) Check {
+	return func(ctx context.Context) error {
+		switch conn.GetState() {
+		case connectivity.TransientFailure:
+			return errors.New("connection failing")
+		case connectivity.Shutdown:
+			return errors.New("connection shutdown")
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// InstrumentHandler records the duration of all requests handled by a router mounted under name.
+func InstrumentHandler(name string, next http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerDuration(
+		HTTPRequestDuration.MustCurryWith(prometheus.Labels{"router": name}),
+		next,
+	)
+}
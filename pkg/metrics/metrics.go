@@ -0,0 +1,57 @@
+// Package metrics contains the prometheus collectors shared by all services.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "soapbox"
+
+var (
+	// HTTPRequestDuration tracks the latency of HTTP requests per mounted router.
+	HTTPRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"router", "method", "code"},
+	)
+
+	// EventsConsumed counts the pubsub events consumed by type.
+	EventsConsumed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pubsub",
+			Name:      "events_consumed_total",
+			Help:      "Number of pubsub events consumed.",
+		},
+		[]string{"type"},
+	)
+
+	// NotificationsDispatched counts the outcomes of dispatched notifications.
+	NotificationsDispatched = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "notifications",
+			Name:      "dispatched_total",
+			Help:      "Number of dispatched notifications by outcome.",
+		},
+		[]string{"category", "outcome"},
+	)
+
+	// IndexingDuration tracks the latency of search engine indexing requests.
+	IndexingDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "indexer",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of search engine indexing requests.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"operation", "status"},
+	)
+)
@@ -0,0 +1,36 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// RoomCounter is implemented by anything that can count the open rooms and connected peers.
+type RoomCounter interface {
+	Count() (rooms int, peers int)
+}
+
+type roomsCollector struct {
+	counter RoomCounter
+
+	rooms *prometheus.Desc
+	peers *prometheus.Desc
+}
+
+// RegisterRooms registers gauges for the amount of rooms and peers reported by the counter.
+func RegisterRooms(counter RoomCounter) error {
+	return prometheus.Register(&roomsCollector{
+		counter: counter,
+		rooms:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "rooms", "open"), "Number of open rooms.", nil, nil),
+		peers:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "rooms", "peers"), "Number of peers connected to rooms.", nil, nil),
+	})
+}
+
+func (c *roomsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rooms
+	ch <- c.peers
+}
+
+func (c *roomsCollector) Collect(ch chan<- prometheus.Metric) {
+	rooms, peers := c.counter.Count()
+
+	ch <- prometheus.MustNewConstMetric(c.rooms, prometheus.GaugeValue, float64(rooms))
+	ch <- prometheus.MustNewConstMetric(c.peers, prometheus.GaugeValue, float64(peers))
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+const checkTimeout = 2 * time.Second
+
+// Server exposes the prometheus metrics as well as the liveness and readiness of a process.
+type Server struct {
+	mux    sync.RWMutex
+	checks map[string]Check
+}
+
+func NewServer() *Server {
+	return &Server{
+		checks: make(map[string]Check),
+	}
+}
+
+// AddCheck adds a dependency that needs to be reachable for the process to be ready.
+func (s *Server) AddCheck(name string, check Check) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.checks[name] = check
+}
+
+// Handler returns the handler serving /metrics, /healthz and /readyz.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.HandleFunc("/readyz", s.readyz)
+	return mux
+}
+
+// ListenAndServe serves on the passed address until ctx is canceled.
+func (s *Server) ListenAndServe(ctx context.Context, addr conf.AddrConf) error {
+	return httputil.ListenAndServe(ctx, fmt.Sprintf("%s:%d", addr.Host, addr.Port), s.Handler())
+}
+
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	httputil.JsonSuccess(w)
+}
+
+func (s *Server) readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	failures := s.check(ctx)
+	if len(failures) == 0 {
+		httputil.JsonSuccess(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_ = httputil.JsonEncode(w, failures)
+}
+
+func (s *Server) check(ctx context.Context) map[string]string {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+
+	failures := make(map[string]string)
+
+	for name, check := range s.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			err := check(ctx)
+			if err == nil {
+				return
+			}
+
+			mux.Lock()
+			failures[name] = err.Error()
+			mux.Unlock()
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	return failures
+}
@@ -0,0 +1,75 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/metrics"
+)
+
+func TestServer_Healthz(t *testing.T) {
+	server := metrics.NewServer()
+	server.AddCheck("failing", func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+
+	rr := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/healthz", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d", rr.Code)
+	}
+}
+
+func TestServer_Readyz(t *testing.T) {
+	var tests = []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			"ready",
+			nil,
+			http.StatusOK,
+		},
+		{
+			"not ready",
+			errors.New("boom"),
+			http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := metrics.NewServer()
+			server.AddCheck("postgres", func(ctx context.Context) error {
+				return nil
+			})
+
+			server.AddCheck("redis", func(ctx context.Context) error {
+				return tt.err
+			})
+
+			rr := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/readyz", nil))
+
+			if rr.Code != tt.expected {
+				t.Fatalf("expected %d actual %d", tt.expected, rr.Code)
+			}
+		})
+	}
+}
+
+func TestServer_Metrics(t *testing.T) {
+	metrics.EventsConsumed.WithLabelValues("1").Inc()
+
+	rr := httptest.NewRecorder()
+	metrics.NewServer().Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d", rr.Code)
+	}
+}
@@ -0,0 +1,403 @@
+// Package migrations applies versioned schema migrations to the database.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/db"
+)
+
+var (
+	// ErrSchemaOutdated is returned when the database is missing migrations a binary depends on.
+	ErrSchemaOutdated = errors.New("database schema is outdated")
+
+	// ErrUnknownVersion is returned when migrating to a version that does not exist.
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+var filename = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// lockID identifies the advisory lock held while migrating, so that instances starting at the same time apply
+// migrations one after the other.
+const lockID = 5143728
+
+// querier is implemented by both a database and a single connection.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Migration is a single versioned change to the schema.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes whether a migration was applied to the database.
+type Status struct {
+	Migration *Migration
+	AppliedAt *time.Time
+}
+
+// Load reads all migrations from dir, they are returned sorted by version.
+func Load(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		parts := filename.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := versions[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: parts[2]}
+			versions[version] = migration
+		}
+
+		if migration.Name != parts[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %s and %s", version, migration.Name, parts[2])
+		}
+
+		if parts[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(versions))
+	for _, migration := range versions {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d has no up migration", migration.Version)
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrator moves the database schema between versions.
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+// NewMigrator returns a migrator for the passed migrations, they must be sorted by version.
+func NewMigrator(db *sql.DB, migrations []*Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+// NewDefaultMigrator returns a migrator for the migrations embedded in the binary.
+func NewDefaultMigrator(conn *sql.DB) (*Migrator, error) {
+	migrations, err := Load(db.Migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMigrator(conn, migrations), nil
+}
+
+// Check returns ErrSchemaOutdated if the database is missing migrations that the binary contains.
+// A database that is ahead of the binary is accepted, as migrations are kept compatible with the previous release.
+func Check(ctx context.Context, conn *sql.DB) error {
+	m, err := NewDefaultMigrator(conn)
+	if err != nil {
+		return err
+	}
+
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	if version < m.Latest() {
+		return fmt.Errorf("%w: version is %d, required is %d", ErrSchemaOutdated, version, m.Latest())
+	}
+
+	return nil
+}
+
+// Latest returns the newest version known to the migrator.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the version the database is currently at, 0 if no migrations were applied.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	initialized, err := initialized(ctx, m.db)
+	if err != nil || !initialized {
+		return 0, err
+	}
+
+	return version(ctx, m.db)
+}
+
+// Status returns every known migration and when it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+
+		if at, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &at
+		}
+
+		result = append(result, status)
+	}
+
+	return result, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		current, err := version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if current == 0 {
+			return nil
+		}
+
+		previous := 0
+		for _, migration := range m.migrations {
+			if migration.Version >= current {
+				break
+			}
+
+			previous = migration.Version
+		}
+
+		return m.to(ctx, conn, previous)
+	})
+}
+
+// To applies or reverts migrations until the database is at version, 0 reverts all migrations.
+func (m *Migrator) To(ctx context.Context, version int) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		return m.to(ctx, conn, version)
+	})
+}
+
+func (m *Migrator) to(ctx context.Context, conn *sql.Conn, target int) error {
+	current, err := version(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	if target >= current {
+		for _, migration := range m.migrations {
+			if migration.Version <= current || migration.Version > target {
+				continue
+			}
+
+			err := apply(ctx, conn, migration)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version > current || migration.Version <= target {
+			continue
+		}
+
+		err := revert(ctx, conn, migration)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// locked runs f on a dedicated connection holding the migration lock, once the schema_migrations table exists.
+func (m *Migrator) locked(ctx context.Context, f func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1);", lockID)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1);", lockID)
+	}()
+
+	_, err = conn.ExecContext(
+		ctx,
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version INT PRIMARY KEY, applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW());",
+	)
+	if err != nil {
+		return err
+	}
+
+	return f(conn)
+}
+
+// applied returns when each applied migration was applied.
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	applied := make(map[int]time.Time)
+
+	initialized, err := initialized(ctx, m.db)
+	if err != nil || !initialized {
+		return applied, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations;")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			version int
+			at      time.Time
+		)
+
+		err := rows.Scan(&version, &at)
+		if err != nil {
+			return nil, err
+		}
+
+		applied[version] = at
+	}
+
+	return applied, rows.Err()
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+
+	return nil
+}
+
+func apply(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	return transaction(ctx, conn, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, migration.Up)
+		if err != nil {
+			return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1);", migration.Version)
+		return err
+	})
+}
+
+func revert(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("migration %d_%s can not be reverted", migration.Version, migration.Name)
+	}
+
+	return transaction(ctx, conn, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, migration.Down)
+		if err != nil {
+			return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1;", migration.Version)
+		return err
+	})
+}
+
+// transaction runs f in a transaction, postgres supports transactional DDL so a failed migration leaves no trace.
+func transaction(ctx context.Context, conn *sql.Conn, f func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = f(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// initialized returns whether the schema_migrations table exists, without creating it.
+func initialized(ctx context.Context, q querier) (bool, error) {
+	var exists bool
+	err := q.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL;").Scan(&exists)
+	return exists, err
+}
+
+// version returns the newest applied migration, the schema_migrations table must exist.
+func version(ctx context.Context, q querier) (int, error) {
+	var version int
+	err := q.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations;").Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
@@ -0,0 +1,247 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/soapboxsocial/soapbox/db"
+	"github.com/soapboxsocial/soapbox/pkg/migrations"
+)
+
+func testMigrations(t *testing.T) []*migrations.Migration {
+	fsys := fstest.MapFS{
+		"migrations/0001_initial.up.sql":      {Data: []byte("CREATE TABLE foo (id INT);")},
+		"migrations/0001_initial.down.sql":    {Data: []byte("DROP TABLE foo;")},
+		"migrations/0002_bar.up.sql":          {Data: []byte("CREATE TABLE bar (id INT);")},
+		"migrations/0002_bar.down.sql":        {Data: []byte("DROP TABLE bar;")},
+		"migrations/README.md":                {Data: []byte("ignored")},
+		"migrations/0003_irreversible.up.sql": {Data: []byte("DROP TABLE foo;")},
+	}
+
+	result, err := migrations.Load(fsys, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestLoad(t *testing.T) {
+	result := testMigrations(t)
+
+	if len(result) != 3 {
+		t.Fatalf("expected 3 migrations actual %d", len(result))
+	}
+
+	for i, name := range []string{"initial", "bar", "irreversible"} {
+		if result[i].Version != i+1 || result[i].Name != name {
+			t.Fatalf("unexpected migration %d_%s", result[i].Version, result[i].Name)
+		}
+	}
+
+	if result[0].Down != "DROP TABLE foo;" {
+		t.Fatalf("unexpected down migration %s", result[0].Down)
+	}
+
+	if result[2].Down != "" {
+		t.Fatal("unexpected down migration")
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	result, err := migrations.Load(db.Migrations, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+
+	for _, migration := range result {
+		if migration.Down == "" {
+			t.Fatalf("migration %d_%s has no down migration", migration.Version, migration.Name)
+		}
+	}
+}
+
+func expectVersion(mock sqlmock.Sqlmock, version int) {
+	mock.ExpectQuery("^SELECT to_regclass").WillReturnRows(mock.NewRows([]string{"exists"}).AddRow(true))
+	expectApplied(mock, version)
+}
+
+func expectApplied(mock sqlmock.Sqlmock, version int) {
+	mock.ExpectQuery("^SELECT COALESCE").WillReturnRows(mock.NewRows([]string{"version"}).AddRow(version))
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("^SELECT pg_advisory_lock").WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("^CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("^SELECT pg_advisory_unlock").WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestMigrator_Up(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer conn.Close()
+
+	m := migrations.NewMigrator(conn, testMigrations(t))
+
+	expectLock(mock)
+	expectApplied(mock, 1)
+
+	for _, version := range []int{2, 3} {
+		mock.ExpectBegin()
+		mock.ExpectExec("^(CREATE|DROP) TABLE").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("^INSERT INTO schema_migrations").WithArgs(version).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+	}
+
+	expectUnlock(mock)
+
+	err = m.Up(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrator_UpRollsBackOnFailure(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer conn.Close()
+
+	m := migrations.NewMigrator(conn, testMigrations(t))
+
+	expectLock(mock)
+	expectApplied(mock, 0)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("^CREATE TABLE foo").WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	err = m.Up(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer conn.Close()
+
+	m := migrations.NewMigrator(conn, testMigrations(t))
+
+	expectLock(mock)
+	expectApplied(mock, 2)
+	expectApplied(mock, 2)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("^DROP TABLE bar").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("^DELETE FROM schema_migrations").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	err = m.Down(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrator_ToIrreversible(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer conn.Close()
+
+	m := migrations.NewMigrator(conn, testMigrations(t))
+
+	expectLock(mock)
+	expectApplied(mock, 3)
+	expectUnlock(mock)
+
+	err = m.To(context.Background(), 2)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestMigrator_ToUnknownVersion(t *testing.T) {
+	conn, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer conn.Close()
+
+	m := migrations.NewMigrator(conn, testMigrations(t))
+
+	err = m.To(context.Background(), 10)
+	if !errors.Is(err, migrations.ErrUnknownVersion) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var tests = []struct {
+		name    string
+		version int
+		err     error
+	}{
+		{"uninitialized", -1, migrations.ErrSchemaOutdated},
+		{"outdated", 0, migrations.ErrSchemaOutdated},
+		{"current", 1000, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer conn.Close()
+
+			// the check never creates the schema_migrations table.
+			if tt.version < 0 {
+				mock.ExpectQuery("^SELECT to_regclass").WillReturnRows(mock.NewRows([]string{"exists"}).AddRow(false))
+			} else {
+				expectVersion(mock, tt.version)
+			}
+
+			err = migrations.Check(context.Background(), conn)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v actual %v", tt.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package minis
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+)
 
 type Backend struct {
 	db *sql.DB
@@ -10,15 +15,15 @@ func NewBackend(db *sql.DB) *Backend {
 	return &Backend{db: db}
 }
 
-func (b *Backend) ListMinis() ([]Mini, error) {
+func (b *Backend) ListMinis(ctx context.Context) ([]Mini, error) {
 	query := `SELECT id, name, slug, image, size, description FROM minis ORDER BY weight ASC;`
 
-	stmt, err := b.db.Prepare(query)
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query()
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -39,14 +44,14 @@ func (b *Backend) ListMinis() ([]Mini, error) {
 	return result, nil
 }
 
-func (b *Backend) GetMiniWithSlug(slug string) (*Mini, error) {
-	stmt, err := b.db.Prepare("SELECT id, name, image, size, description FROM minis WHERE slug = $1;")
+func (b *Backend) GetMiniWithSlug(ctx context.Context, slug string) (*Mini, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT id, name, image, size, description FROM minis WHERE slug = $1;")
 	if err != nil {
 		return nil, err
 	}
 
 	mini := &Mini{}
-	err = stmt.QueryRow(slug).Scan(&mini.ID, &mini.Name, &mini.Image, &mini.Size, &mini.Description)
+	err = stmt.QueryRowContext(ctx, slug).Scan(&mini.ID, &mini.Name, &mini.Image, &mini.Size, &mini.Description)
 	if err != nil {
 		return nil, err
 	}
@@ -56,14 +61,14 @@ func (b *Backend) GetMiniWithSlug(slug string) (*Mini, error) {
 	return mini, nil
 }
 
-func (b *Backend) GetMiniWithID(id int) (*Mini, error) {
-	stmt, err := b.db.Prepare("SELECT name, image, slug, size, description FROM minis WHERE id = $1;")
+func (b *Backend) GetMiniWithID(ctx context.Context, id int) (*Mini, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT name, image, slug, size, description FROM minis WHERE id = $1;")
 	if err != nil {
 		return nil, err
 	}
 
 	mini := &Mini{}
-	err = stmt.QueryRow(id).Scan(&mini.Name, &mini.Image, &mini.Slug, &mini.Size, &mini.Description)
+	err = stmt.QueryRowContext(ctx, id).Scan(&mini.Name, &mini.Image, &mini.Slug, &mini.Size, &mini.Description)
 	if err != nil {
 		return nil, err
 	}
@@ -73,31 +78,50 @@ func (b *Backend) GetMiniWithID(id int) (*Mini, error) {
 	return mini, nil
 }
 
-func (b *Backend) SaveScores(mini int, room string, scores Scores) error {
-	tx, err := b.db.Begin()
+// GetScoresForUser returns every score a user achieved, most recent first.
+func (b *Backend) GetScoresForUser(ctx context.Context, user int) ([]Score, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT mini_id, room, score, time FROM mini_scores WHERE user_id = $1 ORDER BY time DESC;")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	stmt, err := tx.Prepare("INSERT INTO mini_scores(mini_id, room, user_id, score) VALUES ($1, $2, $3, $4)")
+	rows, err := stmt.QueryContext(ctx, user)
 	if err != nil {
-		_ = tx.Rollback()
-		return err
+		return nil, err
 	}
 
-	for user, score := range scores {
-		_, err = stmt.Exec(mini, room, user, score)
+	defer rows.Close()
+
+	result := make([]Score, 0)
+
+	for rows.Next() {
+		score := Score{}
+
+		err := rows.Scan(&score.Mini, &score.Room, &score.Score, &score.Time)
 		if err != nil {
-			_ = tx.Rollback()
-			return err
+			return nil, err
 		}
-	}
 
-	err = tx.Commit()
-	if err != nil {
-		_ = tx.Rollback()
-		return err
+		result = append(result, score)
 	}
 
-	return nil
+	return result, rows.Err()
+}
+
+func (b *Backend) SaveScores(ctx context.Context, mini int, room string, scores Scores) error {
+	return sqlutil.Transaction(ctx, b.db, func(ctx context.Context) error {
+		stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO mini_scores(mini_id, room, user_id, score) VALUES ($1, $2, $3, $4)")
+		if err != nil {
+			return err
+		}
+
+		for user, score := range scores {
+			_, err = stmt.ExecContext(ctx, mini, room, user, score)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
@@ -1,7 +1,9 @@
 package minis_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 
@@ -23,7 +25,7 @@ func TestBackend_GetMiniWithID(t *testing.T) {
 		WithArgs(id).
 		WillReturnRows(mock.NewRows([]string{"name", "slug", "image", "size", "description"}).AddRow("name", "slug", "image", 0, ""))
 
-	result, err := backend.GetMiniWithID(id)
+	result, err := backend.GetMiniWithID(context.Background(), id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -48,7 +50,7 @@ func TestBackend_GetMiniWithSlug(t *testing.T) {
 		WithArgs(slug).
 		WillReturnRows(mock.NewRows([]string{"name", "slug", "image", "size", "description"}).AddRow(1, "name", "image", 0, ""))
 
-	result, err := backend.GetMiniWithSlug(slug)
+	result, err := backend.GetMiniWithSlug(context.Background(), slug)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -57,3 +59,28 @@ func TestBackend_GetMiniWithSlug(t *testing.T) {
 		t.Fatal("slug not matching")
 	}
 }
+
+func TestBackend_GetScoresForUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := minis.NewBackend(db)
+
+	user := 1
+	mock.ExpectPrepare("SELECT").
+		ExpectQuery().
+		WithArgs(user).
+		WillReturnRows(mock.NewRows([]string{"mini_id", "room", "score", "time"}).AddRow(12, "room", 100, time.Now()))
+
+	result, err := backend.GetScoresForUser(context.Background(), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 1 || result[0].Mini != 12 || result[0].Score != 100 {
+		t.Fatalf("unexpected scores %v", result)
+	}
+}
@@ -2,13 +2,13 @@ package minis
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
 	"github.com/soapboxsocial/soapbox/pkg/http/middlewares"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 )
 
 type Endpoint struct {
@@ -35,8 +35,8 @@ func (e *Endpoint) Router() *mux.Router {
 	return r
 }
 
-func (e *Endpoint) listMinis(w http.ResponseWriter, _ *http.Request) {
-	minis, err := e.backend.ListMinis()
+func (e *Endpoint) listMinis(w http.ResponseWriter, r *http.Request) {
+	minis, err := e.backend.ListMinis(r.Context())
 	if err != nil {
 		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "not found")
 		return
@@ -70,7 +70,7 @@ func (e *Endpoint) saveScores(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = e.backend.SaveScores(id, room, scores)
+	err = e.backend.SaveScores(r.Context(), id, room, scores)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "failed")
 		return
@@ -1,8 +1,18 @@
 package minis
 
+import "time"
+
 // Scores maps user id to score
 type Scores map[int]int
 
+// Score is a score a user achieved in a mini.
+type Score struct {
+	Mini  int       `json:"mini"`
+	Room  string    `json:"room"`
+	Score int       `json:"score"`
+	Time  time.Time `json:"time"`
+}
+
 // AuthKeys maps an access token to a game ID.
 type AuthKeys map[string]int
 
@@ -3,7 +3,9 @@ package grpc
 import (
 	"context"
 	"errors"
+	"time"
 
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
 	"github.com/soapboxsocial/soapbox/pkg/notifications/pb"
 	"github.com/soapboxsocial/soapbox/pkg/notifications/worker"
@@ -14,16 +16,20 @@ type Service struct {
 
 	dispatch *worker.Dispatcher
 	settings *notifications.Settings
+	limiter  *notifications.Limiter
+	stats    *notifications.Stats
 }
 
-func NewService(dispatch *worker.Dispatcher, settings *notifications.Settings) *Service {
+func NewService(dispatch *worker.Dispatcher, settings *notifications.Settings, limiter *notifications.Limiter, stats *notifications.Stats) *Service {
 	return &Service{
 		dispatch: dispatch,
 		settings: settings,
+		limiter:  limiter,
+		stats:    stats,
 	}
 }
 
-func (s *Service) SendNotification(_ context.Context, request *pb.SendNotificationRequest) (*pb.SendNotificationResponse, error) {
+func (s *Service) SendNotification(ctx context.Context, request *pb.SendNotificationRequest) (*pb.SendNotificationResponse, error) {
 	notification := request.Notification
 	if notification == nil {
 		return nil, errors.New("empty notification")
@@ -32,12 +38,74 @@ func (s *Service) SendNotification(_ context.Context, request *pb.SendNotificati
 	push := notification.ToPushNotification()
 	ids := request.Targets
 
-	targets, err := s.settings.GetSettingsForUsers(ids)
+	targets, err := s.settings.GetSettingsForUsers(ctx, ids)
 	if err != nil {
 		return nil, errors.New("failed to get targets")
 	}
 
-	s.dispatch.Dispatch(0, targets, push)
+	if request.DryRun {
+		suppressed := make([]int64, 0)
+		for _, target := range targets {
+			if !s.limiter.ShouldSendNotification(target, push) {
+				suppressed = append(suppressed, int64(target.ID))
+			}
+		}
+
+		return &pb.SendNotificationResponse{Success: true, Suppressed: suppressed}, nil
+	}
+
+	s.dispatch.Dispatch(httputil.DetachedContext(ctx), 0, targets, push)
 
 	return &pb.SendNotificationResponse{Success: true}, nil
 }
+
+func (s *Service) GetStats(_ context.Context, request *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	category := notifications.NotificationCategory(request.Category)
+	start := time.Unix(request.Start, 0)
+
+	end := time.Now()
+	if request.End != 0 {
+		end = time.Unix(request.End, 0)
+	}
+
+	if start.After(end) {
+		return nil, errors.New("start must be before end")
+	}
+
+	stats, err := s.stats.GetStats(category, start, end)
+	if err != nil {
+		return nil, errors.New("failed to get stats")
+	}
+
+	history, err := s.stats.GetHistory(category, start, end)
+	if err != nil {
+		return nil, errors.New("failed to get history")
+	}
+
+	resp := &pb.GetStatsResponse{
+		Stats:   make([]*pb.CategoryStats, 0, len(stats)),
+		History: make([]*pb.StatsEntry, 0, len(history)),
+	}
+
+	for _, stat := range stats {
+		resp.Stats = append(resp.Stats, &pb.CategoryStats{
+			Category:     string(stat.Category),
+			Sent:         stat.Sent,
+			Suppressed:   stat.Suppressed,
+			Failed:       stat.Failed,
+			Retried:      stat.Retried,
+			Unregistered: stat.Unregistered,
+		})
+	}
+
+	for _, entry := range history {
+		resp.History = append(resp.History, &pb.StatsEntry{
+			Timestamp: entry.Timestamp,
+			Category:  string(entry.Category),
+			Outcome:   string(entry.Outcome),
+			Count:     int64(entry.Count),
+		})
+	}
+
+	return resp, nil
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"context"
+
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/users"
@@ -31,13 +33,13 @@ func (f FollowerNotificationHandler) Origin(event *pubsub.Event) (int, error) {
 	return follower, nil
 }
 
-func (f FollowerNotificationHandler) Targets(event *pubsub.Event) ([]notifications.Target, error) {
+func (f FollowerNotificationHandler) Targets(ctx context.Context, event *pubsub.Event) ([]notifications.Target, error) {
 	targetID, err := event.GetInt("id")
 	if err != nil {
 		return nil, err
 	}
 
-	target, err := f.targets.GetSettingsFor(targetID)
+	target, err := f.targets.GetSettingsFor(ctx, targetID)
 	if err != nil {
 		return nil, err
 	}
@@ -45,13 +47,13 @@ func (f FollowerNotificationHandler) Targets(event *pubsub.Event) ([]notificatio
 	return []notifications.Target{*target}, nil
 }
 
-func (f FollowerNotificationHandler) Build(event *pubsub.Event) (*notifications.PushNotification, error) {
+func (f FollowerNotificationHandler) Build(ctx context.Context, event *pubsub.Event) (*notifications.PushNotification, error) {
 	creator, err := event.GetInt("follower")
 	if err != nil {
 		return nil, err
 	}
 
-	displayName, err := f.getDisplayName(creator)
+	displayName, err := f.getDisplayName(ctx, creator)
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +68,8 @@ func (f FollowerNotificationHandler) Build(event *pubsub.Event) (*notifications.
 	}, nil
 }
 
-func (f FollowerNotificationHandler) getDisplayName(id int) (string, error) {
-	user, err := f.users.FindByID(id)
+func (f FollowerNotificationHandler) getDisplayName(ctx context.Context, id int) (string, error) {
+	user, err := f.users.FindByID(ctx, id)
 	if err != nil {
 		return "", err
 	}
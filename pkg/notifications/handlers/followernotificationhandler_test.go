@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -35,7 +36,7 @@ func TestFollowerNotificationHandler_Targets(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(mock.NewRows([]string{"user_id", "room_frequency", "follows", "welcome_rooms"}).FromCSVString("1,2,false,false"))
 
-	target, err := handler.Targets(event)
+	target, err := handler.Targets(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -73,7 +74,7 @@ func TestFollowerNotificationHandler_Build(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(mock.NewRows([]string{"id", "display_name", "username", "image", "bio", "email"}).FromCSVString("1,foo,t,t,t,t"))
 
-	n, err := handler.Build(event)
+	n, err := handler.Build(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -30,13 +31,13 @@ func (f FollowRecommendationsNotificationHandler) Origin(*pubsub.Event) (int, er
 	return 0, errors.New("no origin for event")
 }
 
-func (f FollowRecommendationsNotificationHandler) Targets(event *pubsub.Event) ([]notifications.Target, error) {
+func (f FollowRecommendationsNotificationHandler) Targets(ctx context.Context, event *pubsub.Event) ([]notifications.Target, error) {
 	targetID, err := event.GetInt("id")
 	if err != nil {
 		return nil, err
 	}
 
-	target, err := f.targets.GetSettingsFor(targetID)
+	target, err := f.targets.GetSettingsFor(ctx, targetID)
 	if err != nil {
 		return nil, err
 	}
@@ -44,13 +45,13 @@ func (f FollowRecommendationsNotificationHandler) Targets(event *pubsub.Event) (
 	return []notifications.Target{*target}, nil
 }
 
-func (f FollowRecommendationsNotificationHandler) Build(event *pubsub.Event) (*notifications.PushNotification, error) {
+func (f FollowRecommendationsNotificationHandler) Build(ctx context.Context, event *pubsub.Event) (*notifications.PushNotification, error) {
 	targetID, err := event.GetInt("id")
 	if err != nil {
 		return nil, err
 	}
 
-	recommendations, err := f.backend.RecommendationsFor(targetID)
+	recommendations, err := f.backend.RecommendationsFor(ctx, targetID)
 	if err != nil {
 		return nil, err
 	}
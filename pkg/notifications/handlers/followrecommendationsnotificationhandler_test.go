@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"database/sql/driver"
 	"reflect"
 	"strconv"
@@ -37,7 +38,7 @@ func TestFollowRecommendationsNotificationHandler_Targets(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(mock.NewRows([]string{"user_id", "room_frequency", "follows", "welcome_rooms"}).FromCSVString("1,2,false,false"))
 
-	target, err := handler.Targets(event)
+	target, err := handler.Targets(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -152,7 +153,7 @@ func TestFollowRecommendationsNotificationHandler_Build(t *testing.T) {
 				WithArgs(id).
 				WillReturnRows(rows)
 
-			n, err := handler.Build(event)
+			n, err := handler.Build(context.Background(), event)
 			if err != nil {
 				t.Fatal(err)
 			}
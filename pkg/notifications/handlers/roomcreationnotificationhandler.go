@@ -39,7 +39,7 @@ func (r RoomCreationNotificationHandler) Origin(event *pubsub.Event) (int, error
 	return creator, nil
 }
 
-func (r RoomCreationNotificationHandler) Targets(event *pubsub.Event) ([]notifications.Target, error) {
+func (r RoomCreationNotificationHandler) Targets(ctx context.Context, event *pubsub.Event) ([]notifications.Target, error) {
 	if pubsub.RoomVisibility(event.Params["visibility"].(string)) == pubsub.Private {
 		return []notifications.Target{}, nil
 	}
@@ -49,7 +49,7 @@ func (r RoomCreationNotificationHandler) Targets(event *pubsub.Event) ([]notific
 		return nil, err
 	}
 
-	targets, err := r.targets.GetSettingsFollowingUser(creator)
+	targets, err := r.targets.GetSettingsFollowingUser(ctx, creator)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +57,7 @@ func (r RoomCreationNotificationHandler) Targets(event *pubsub.Event) ([]notific
 	return targets, nil
 }
 
-func (r RoomCreationNotificationHandler) Build(event *pubsub.Event) (*notifications.PushNotification, error) {
+func (r RoomCreationNotificationHandler) Build(ctx context.Context, event *pubsub.Event) (*notifications.PushNotification, error) {
 	if pubsub.RoomVisibility(event.Params["visibility"].(string)) == pubsub.Private {
 		return nil, errors.New("room is private")
 	}
@@ -72,7 +72,7 @@ func (r RoomCreationNotificationHandler) Build(event *pubsub.Event) (*notificati
 	}
 
 	room := event.Params["id"].(string)
-	response, err := r.metadata.GetRoom(context.Background(), &pb.GetRoomRequest{Id: room})
+	response, err := r.metadata.GetRoom(event.Context(), &pb.GetRoomRequest{Id: room})
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +85,7 @@ func (r RoomCreationNotificationHandler) Build(event *pubsub.Event) (*notificati
 		return nil, errors.New("room is private")
 	}
 
-	displayName, err := r.getDisplayName(creator)
+	displayName, err := r.getDisplayName(ctx, creator)
 	if err != nil {
 		return nil, err
 	}
@@ -94,11 +94,15 @@ func (r RoomCreationNotificationHandler) Build(event *pubsub.Event) (*notificati
 		return notifications.NewRoomNotificationWithName(room, displayName, response.State.Name), nil
 	}
 
+	if topics := response.State.GetMetadata().GetTopics(); len(topics) > 0 {
+		return notifications.NewRoomNotificationWithTopic(room, displayName, topics[0]), nil
+	}
+
 	return notifications.NewRoomNotification(room, displayName, creator), nil
 }
 
-func (r RoomCreationNotificationHandler) getDisplayName(id int) (string, error) {
-	user, err := r.users.FindByID(id)
+func (r RoomCreationNotificationHandler) getDisplayName(ctx context.Context, id int) (string, error) {
+	user, err := r.users.FindByID(ctx, id)
 	if err != nil {
 		return "", err
 	}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -44,7 +45,7 @@ func TestRoomCreationNotificationHandler_Targets(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(mock.NewRows([]string{"user_id", "room_frequency", "follows", "welcome_rooms"}).FromCSVString("1,2,false,false"))
 
-	target, err := handler.Targets(event)
+	target, err := handler.Targets(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -90,7 +91,7 @@ func TestRoomCreationNotificationHandler_Build(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(mock.NewRows([]string{"id", "display_name", "username", "image", "bio", "email"}).FromCSVString("1,foo,t,t,t,t"))
 
-	n, err := handler.Build(event)
+	n, err := handler.Build(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -109,3 +110,54 @@ func TestRoomCreationNotificationHandler_Build(t *testing.T) {
 		t.Fatalf("expected %v actual %v", notification, n)
 	}
 }
+
+func TestRoomCreationNotificationHandler_Build_WithTopic(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockRoomServiceClient(ctrl)
+
+	handler := handlers.NewRoomCreationNotificationHandler(notifications.NewSettings(nil), users.NewBackend(db), m)
+
+	room := "123"
+
+	raw := pubsub.NewRoomCreationEvent(room, 12, pubsub.Public)
+
+	event, err := getRawEvent(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &pb.RoomState{Id: room, Metadata: &pb.RoomMetadata{Topics: []string{"music", "art"}}}
+	m.EXPECT().GetRoom(gomock.Any(), gomock.Any(), gomock.Any()).Return(&pb.GetRoomResponse{State: state}, nil)
+
+	mock.
+		ExpectPrepare("SELECT").
+		ExpectQuery().
+		WillReturnRows(mock.NewRows([]string{"id", "display_name", "username", "image", "bio", "email"}).FromCSVString("1,foo,t,t,t,t"))
+
+	n, err := handler.Build(context.Background(), event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notification := &notifications.PushNotification{
+		Category: notifications.NEW_ROOM,
+		Alert: notifications.Alert{
+			Key:       "new_room_with_topic_notification",
+			Arguments: []string{"foo", "music"},
+		},
+		Arguments:  map[string]interface{}{"id": room, "topic": "music"},
+		CollapseID: room,
+	}
+
+	if !reflect.DeepEqual(n, notification) {
+		t.Fatalf("expected %v actual %v", notification, n)
+	}
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"context"
+
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/users"
@@ -31,13 +33,13 @@ func (r RoomInviteNotificationHandler) Origin(event *pubsub.Event) (int, error)
 	return creator, nil
 }
 
-func (r RoomInviteNotificationHandler) Targets(event *pubsub.Event) ([]notifications.Target, error) {
+func (r RoomInviteNotificationHandler) Targets(ctx context.Context, event *pubsub.Event) ([]notifications.Target, error) {
 	targetID, err := event.GetInt("id")
 	if err != nil {
 		return nil, err
 	}
 
-	target, err := r.targets.GetSettingsFor(targetID)
+	target, err := r.targets.GetSettingsFor(ctx, targetID)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +47,7 @@ func (r RoomInviteNotificationHandler) Targets(event *pubsub.Event) ([]notificat
 	return []notifications.Target{*target}, nil
 }
 
-func (r RoomInviteNotificationHandler) Build(event *pubsub.Event) (*notifications.PushNotification, error) {
+func (r RoomInviteNotificationHandler) Build(ctx context.Context, event *pubsub.Event) (*notifications.PushNotification, error) {
 	creator, err := event.GetInt("from")
 	if err != nil {
 		return nil, err
@@ -54,7 +56,7 @@ func (r RoomInviteNotificationHandler) Build(event *pubsub.Event) (*notification
 	name := event.Params["name"].(string)
 	room := event.Params["room"].(string)
 
-	displayName, err := r.getDisplayName(creator)
+	displayName, err := r.getDisplayName(ctx, creator)
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +68,8 @@ func (r RoomInviteNotificationHandler) Build(event *pubsub.Event) (*notification
 	return notifications.NewRoomInviteNotificationWithName(room, displayName, name), nil
 }
 
-func (r RoomInviteNotificationHandler) getDisplayName(id int) (string, error) {
-	user, err := r.users.FindByID(id)
+func (r RoomInviteNotificationHandler) getDisplayName(ctx context.Context, id int) (string, error) {
+	user, err := r.users.FindByID(ctx, id)
 	if err != nil {
 		return "", err
 	}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"reflect"
 	"strconv"
 	"testing"
@@ -37,7 +38,7 @@ func TestRoomInviteNotificationHandler_Targets(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(mock.NewRows([]string{"user_id", "room_frequency", "follows", "welcome_rooms"}).FromCSVString("1,2,false,false"))
 
-	target, err := handler.Targets(event)
+	target, err := handler.Targets(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -108,7 +109,7 @@ func TestRoomInviteNotificationHandler_Build(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			n, err := handler.Build(event)
+			n, err := handler.Build(context.Background(), event)
 			if err != nil {
 				t.Fatal(err)
 			}
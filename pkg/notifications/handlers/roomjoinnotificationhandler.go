@@ -34,13 +34,13 @@ func (r RoomJoinNotificationHandler) Origin(event *pubsub.Event) (int, error) {
 	return creator, nil
 }
 
-func (r RoomJoinNotificationHandler) Targets(event *pubsub.Event) ([]notifications.Target, error) {
+func (r RoomJoinNotificationHandler) Targets(ctx context.Context, event *pubsub.Event) ([]notifications.Target, error) {
 	creator, err := event.GetInt("creator")
 	if err != nil {
 		return nil, err
 	}
 
-	targets, err := r.targets.GetSettingsFollowingUser(creator)
+	targets, err := r.targets.GetSettingsFollowingUser(ctx, creator)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,7 @@ func (r RoomJoinNotificationHandler) Targets(event *pubsub.Event) ([]notificatio
 	room := event.Params["id"].(string)
 
 	resp, err := r.metadata.FilterUsersThatCanJoin(
-		context.TODO(),
+		event.Context(),
 		&pb.FilterUsersThatCanJoinRequest{Room: room, Ids: ids},
 	)
 
@@ -76,7 +76,7 @@ func (r RoomJoinNotificationHandler) Targets(event *pubsub.Event) ([]notificatio
 	return res, nil
 }
 
-func (r RoomJoinNotificationHandler) Build(event *pubsub.Event) (*notifications.PushNotification, error) {
+func (r RoomJoinNotificationHandler) Build(ctx context.Context, event *pubsub.Event) (*notifications.PushNotification, error) {
 	if pubsub.RoomVisibility(event.Params["visibility"].(string)) == pubsub.Private {
 		return nil, errRoomPrivate
 	}
@@ -87,7 +87,7 @@ func (r RoomJoinNotificationHandler) Build(event *pubsub.Event) (*notifications.
 	}
 
 	room := event.Params["id"].(string)
-	response, err := r.metadata.GetRoom(context.Background(), &pb.GetRoomRequest{Id: room})
+	response, err := r.metadata.GetRoom(event.Context(), &pb.GetRoomRequest{Id: room})
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"reflect"
 	"strconv"
 	"testing"
@@ -49,7 +50,7 @@ func TestRoomJoinNotificationHandler_Targets(t *testing.T) {
 
 	m.EXPECT().FilterUsersThatCanJoin(gomock.Any(), gomock.Any()).Return(&pb.FilterUsersThatCanJoinResponse{Ids: []int64{1}}, nil)
 
-	target, err := handler.Targets(event)
+	target, err := handler.Targets(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -165,7 +166,7 @@ func TestRoomJoinNotificationBuilder_Build(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			n, err := handler.Build(event)
+			n, err := handler.Build(context.Background(), event)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/soapboxsocial/soapbox/pkg/notifications"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+type StoryReplyNotificationHandler struct {
+	targets *notifications.Settings
+	users   *users.Backend
+}
+
+func NewStoryReplyNotificationHandler(targets *notifications.Settings, u *users.Backend) *StoryReplyNotificationHandler {
+	return &StoryReplyNotificationHandler{
+		targets: targets,
+		users:   u,
+	}
+}
+
+func (s StoryReplyNotificationHandler) Type() pubsub.EventType {
+	return pubsub.EventTypeStoryReply
+}
+
+func (s StoryReplyNotificationHandler) Origin(event *pubsub.Event) (int, error) {
+	from, err := event.GetInt("from")
+	if err != nil {
+		return 0, err
+	}
+
+	return from, nil
+}
+
+func (s StoryReplyNotificationHandler) Targets(ctx context.Context, event *pubsub.Event) ([]notifications.Target, error) {
+	targetID, err := event.GetInt("id")
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.targets.GetSettingsFor(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []notifications.Target{*target}, nil
+}
+
+func (s StoryReplyNotificationHandler) Build(ctx context.Context, event *pubsub.Event) (*notifications.PushNotification, error) {
+	from, err := event.GetInt("from")
+	if err != nil {
+		return nil, err
+	}
+
+	story := event.Params["story"].(string)
+
+	user, err := s.users.FindByID(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notifications.PushNotification{
+		Category: notifications.STORY_REPLY,
+		Alert: notifications.Alert{
+			Key:       "story_reply_notification",
+			Arguments: []string{user.DisplayName},
+		},
+		Arguments: map[string]interface{}{"id": story, "from": from},
+	}, nil
+}
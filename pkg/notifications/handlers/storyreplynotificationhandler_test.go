@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/soapboxsocial/soapbox/pkg/notifications"
+	"github.com/soapboxsocial/soapbox/pkg/notifications/handlers"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+func TestStoryReplyNotificationHandler_Targets(t *testing.T) {
+	raw := pubsub.NewStoryReplyEvent("123", 12, 1)
+	event, err := getRawEvent(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	handler := handlers.NewStoryReplyNotificationHandler(
+		notifications.NewSettings(db),
+		nil,
+	)
+
+	mock.
+		ExpectPrepare("SELECT").
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(mock.NewRows([]string{"user_id", "room_frequency", "follows", "welcome_rooms"}).FromCSVString("1,2,false,false"))
+
+	target, err := handler.Targets(context.Background(), event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []notifications.Target{
+		{ID: 1, RoomFrequency: 2, Follows: false, WelcomeRooms: false},
+	}
+
+	if !reflect.DeepEqual(target, expected) {
+		t.Fatalf("expected %v actual %v", expected, target)
+	}
+}
+
+func TestStoryReplyNotificationHandler_Build(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	handler := handlers.NewStoryReplyNotificationHandler(notifications.NewSettings(nil), users.NewBackend(db))
+
+	displayName := "foo"
+	story := "123"
+	from := 12
+
+	raw := pubsub.NewStoryReplyEvent(story, from, 13)
+
+	event, err := getRawEvent(&raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.
+		ExpectPrepare("SELECT").
+		ExpectQuery().
+		WithArgs(from).
+		WillReturnRows(mock.NewRows([]string{"id", "display_name", "username", "image", "bio", "email"}).FromCSVString("12,foo,t,t,t,t"))
+
+	n, err := handler.Build(context.Background(), event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notification := &notifications.PushNotification{
+		Category: notifications.STORY_REPLY,
+		Alert: notifications.Alert{
+			Key:       "story_reply_notification",
+			Arguments: []string{displayName},
+		},
+		Arguments: map[string]interface{}{"id": story, "from": from},
+	}
+
+	if !reflect.DeepEqual(n, notification) {
+		t.Fatalf("expected %v actual %v", notification, n)
+	}
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"context"
+
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 )
@@ -15,8 +17,8 @@ type Handler interface {
 	Origin(event *pubsub.Event) (int, error)
 
 	// Targets returns the notification receivers
-	Targets(event *pubsub.Event) ([]notifications.Target, error)
+	Targets(ctx context.Context, event *pubsub.Event) ([]notifications.Target, error)
 
 	// Build builds the notification
-	Build(event *pubsub.Event) (*notifications.PushNotification, error)
+	Build(ctx context.Context, event *pubsub.Event) (*notifications.PushNotification, error)
 }
@@ -1,8 +1,9 @@
 package handlers
 
 import (
-	"log"
+	"context"
 
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/users"
@@ -34,8 +35,8 @@ func (w WelcomeRoomNotificationHandler) Origin(*pubsub.Event) (int, error) {
 	return 0, ErrNoCreator
 }
 
-func (w WelcomeRoomNotificationHandler) Targets(*pubsub.Event) ([]notifications.Target, error) {
-	targets, err := w.settings.GetSettingsForRecentlyActiveUsers()
+func (w WelcomeRoomNotificationHandler) Targets(ctx context.Context, _ *pubsub.Event) ([]notifications.Target, error) {
+	targets, err := w.settings.GetSettingsForRecentlyActiveUsers(ctx)
 	if err != nil {
 		log.Printf("settings.GetSettingsForRecentlyActiveUsers err: %s", err)
 	}
@@ -47,7 +48,7 @@ func (w WelcomeRoomNotificationHandler) Targets(*pubsub.Event) ([]notifications.
 	return append(targets, staticTargets...), nil
 }
 
-func (w WelcomeRoomNotificationHandler) Build(event *pubsub.Event) (*notifications.PushNotification, error) {
+func (w WelcomeRoomNotificationHandler) Build(ctx context.Context, event *pubsub.Event) (*notifications.PushNotification, error) {
 	creator, err := event.GetInt("id")
 	if err != nil {
 		return nil, err
@@ -55,7 +56,7 @@ func (w WelcomeRoomNotificationHandler) Build(event *pubsub.Event) (*notificatio
 
 	room := event.Params["room"].(string)
 
-	displayName, err := w.getDisplayName(creator)
+	displayName, err := w.getDisplayName(ctx, creator)
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +72,8 @@ func (w WelcomeRoomNotificationHandler) Build(event *pubsub.Event) (*notificatio
 	}, nil
 }
 
-func (w WelcomeRoomNotificationHandler) getDisplayName(id int) (string, error) {
-	user, err := w.users.FindByID(id)
+func (w WelcomeRoomNotificationHandler) getDisplayName(ctx context.Context, id int) (string, error) {
+	user, err := w.users.FindByID(ctx, id)
 	if err != nil {
 		return "", err
 	}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -35,7 +36,7 @@ func TestWelcomeRoomNotificationHandler_Targets(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(mock.NewRows([]string{"user_id", "room_frequency", "follows", "welcome_rooms"}).FromCSVString("12,2,false,false"))
 
-	target, err := handler.Targets(event)
+	target, err := handler.Targets(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -77,7 +78,7 @@ func TestWelcomeRoomNotificationHandler_Build(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(mock.NewRows([]string{"id", "display_name", "username", "image", "bio", "email"}).FromCSVString("1,foo,t,t,t,t"))
 
-	n, err := handler.Build(event)
+	n, err := handler.Build(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1,6 +1,7 @@
 package notifications
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -18,6 +19,7 @@ var (
 	roomCooldown         = 10 * time.Minute
 	welcomeRoomCooldown  = 30 * time.Minute
 	reEngagementCooldown = (24 * time.Hour) * 7
+	storyReplyCooldown   = 5 * time.Minute
 )
 
 type Limiter struct {
@@ -78,6 +80,8 @@ func (l *Limiter) ShouldSendNotification(target Target, notification *PushNotifi
 		return true
 	case FOLLOW_RECOMMENDATIONS:
 		return true
+	case STORY_REPLY:
+		return !l.isLimited(limiterKeyForStoryReply(target.ID, notification))
 	default:
 		return false
 	}
@@ -102,6 +106,8 @@ func (l *Limiter) SentNotification(target Target, notification *PushNotification
 		}
 
 		l.limit(limiterKeyForWelcomeRoom(target.ID), welcomeRoomCooldown)
+	case STORY_REPLY:
+		l.limit(limiterKeyForStoryReply(target.ID, notification), storyReplyCooldown)
 	}
 }
 
@@ -117,7 +123,7 @@ func (l *Limiter) limit(key string, duration time.Duration) {
 }
 
 func (l *Limiter) isUserInRoom(user int, notification *PushNotification) bool {
-	room, _ := l.currentRoom.GetCurrentRoomForUser(user)
+	room, _ := l.currentRoom.GetCurrentRoomForUser(context.Background(), user)
 	if room == "" {
 		return false
 	}
@@ -151,6 +157,10 @@ func limiterKeyForWelcomeRoom(target int) string {
 	return fmt.Sprintf("notifications_limit_%d_welcome_room", target)
 }
 
+func limiterKeyForStoryReply(target int, notification *PushNotification) string {
+	return fmt.Sprintf("notifications_limit_%d_story_reply_%v", target, notification.Arguments["from"])
+}
+
 func getLimitForRoomFrequency(frequency Frequency, base time.Duration) time.Duration {
 
 	// @TODO think about this frequency
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.26.0
-// 	protoc        v3.15.2
+// 	protoc        v3.17.1
 // source: soapbox/notifications/v1/notifications.proto
 
 package pb
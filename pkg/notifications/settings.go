@@ -1,9 +1,12 @@
 package notifications
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strconv"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 )
 
 type Settings struct {
@@ -14,13 +17,13 @@ func NewSettings(db *sql.DB) *Settings {
 	return &Settings{db: db}
 }
 
-func (s *Settings) GetSettingsFor(user int) (*Target, error) {
-	stmt, err := s.db.Prepare("SELECT user_id, room_frequency, follows, welcome_rooms FROM notification_settings WHERE user_id = $1;")
+func (s *Settings) GetSettingsFor(ctx context.Context, user int) (*Target, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, s.db).PrepareContext(ctx, "SELECT user_id, room_frequency, follows, welcome_rooms FROM notification_settings WHERE user_id = $1;")
 	if err != nil {
 		return nil, err
 	}
 
-	row := stmt.QueryRow(user)
+	row := stmt.QueryRowContext(ctx, user)
 
 	target := &Target{}
 	err = row.Scan(&target.ID, &target.RoomFrequency, &target.Follows, &target.WelcomeRooms)
@@ -31,16 +34,18 @@ func (s *Settings) GetSettingsFor(user int) (*Target, error) {
 	return target, nil
 }
 
-func (s *Settings) GetSettingsFollowingUser(user int) ([]Target, error) {
+func (s *Settings) GetSettingsFollowingUser(ctx context.Context, user int) ([]Target, error) {
 	return s.getSettings(
+		ctx,
 		"SELECT notification_settings.user_id, notification_settings.room_frequency, notification_settings.follows, notification_settings.welcome_rooms FROM notification_settings INNER JOIN followers ON (notification_settings.user_id = followers.follower) WHERE followers.user_id = $1",
 		user,
 	)
 }
 
 // @TODO THIS NEEDS FIXING
-func (s *Settings) GetSettingsForRecentlyActiveUsers() ([]Target, error) {
+func (s *Settings) GetSettingsForRecentlyActiveUsers(ctx context.Context) ([]Target, error) {
 	return s.getSettings(
+		ctx,
 		`SELECT notification_settings.user_id, notification_settings.room_frequency, notification_settings.follows, notification_settings.welcome_rooms FROM notification_settings
 		INNER JOIN (
 			SELECT user_id
@@ -54,22 +59,22 @@ func (s *Settings) GetSettingsForRecentlyActiveUsers() ([]Target, error) {
 	)
 }
 
-func (s *Settings) GetSettingsForUsers(users []int64) ([]Target, error) {
+func (s *Settings) GetSettingsForUsers(ctx context.Context, users []int64) ([]Target, error) {
 	query := fmt.Sprintf(
 		"SELECT notification_settings.user_id, notification_settings.room_frequency, notification_settings.follows, notification_settings.welcome_rooms FROM notification_settings WHERE user_id IN (%s)",
 		join(users, ","),
 	)
 
-	return s.getSettings(query)
+	return s.getSettings(ctx, query)
 }
 
-func (s *Settings) UpdateSettingsFor(user int, frequency Frequency, follows, welcomeRooms bool) error {
-	stmt, err := s.db.Prepare("UPDATE notification_settings SET room_frequency = $1, follows = $2, welcome_rooms = $3 WHERE user_id = $4;")
+func (s *Settings) UpdateSettingsFor(ctx context.Context, user int, frequency Frequency, follows, welcomeRooms bool) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, s.db).PrepareContext(ctx, "UPDATE notification_settings SET room_frequency = $1, follows = $2, welcome_rooms = $3 WHERE user_id = $4;")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(frequency, follows, welcomeRooms, user)
+	_, err = stmt.ExecContext(ctx, frequency, follows, welcomeRooms, user)
 	return err
 }
 
@@ -90,13 +95,13 @@ func join(elems []int64, sep string) string {
 	return res
 }
 
-func (s *Settings) getSettings(query string, args ...interface{}) ([]Target, error) {
-	stmt, err := s.db.Prepare(query)
+func (s *Settings) getSettings(ctx context.Context, query string, args ...interface{}) ([]Target, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, s.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(args...)
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,214 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/log"
+	"github.com/soapboxsocial/soapbox/pkg/metrics"
+)
+
+// Outcome describes what happened to a notification for a specific target or device.
+type Outcome string
+
+const (
+	OutcomeSent         Outcome = "sent"
+	OutcomeSuppressed   Outcome = "suppressed"
+	OutcomeFailed       Outcome = "failed"
+	OutcomeRetried      Outcome = "retried"
+	OutcomeUnregistered Outcome = "unregistered"
+)
+
+const (
+	statsBucket    = time.Hour
+	statsRetention = 7 * 24 * time.Hour
+
+	statsHistoryKey    = "notifications_stats_history"
+	statsHistoryLength = 500
+
+	statsCategoriesKey = "notifications_stats_categories"
+)
+
+// CategoryStats contains the delivery counters for a category over a time range.
+type CategoryStats struct {
+	Category     NotificationCategory `json:"category"`
+	Sent         int64                `json:"sent"`
+	Suppressed   int64                `json:"suppressed"`
+	Failed       int64                `json:"failed"`
+	Retried      int64                `json:"retried"`
+	Unregistered int64                `json:"unregistered"`
+}
+
+// StatsEntry is a single recorded outcome kept in the recent history.
+type StatsEntry struct {
+	Timestamp int64                `json:"timestamp"`
+	Category  NotificationCategory `json:"category"`
+	Outcome   Outcome              `json:"outcome"`
+	Count     int                  `json:"count"`
+}
+
+// Stats keeps delivery counters bucketed per hour, as well as a short history of recent outcomes.
+type Stats struct {
+	rdb *redis.Client
+}
+
+func NewStats(rdb *redis.Client) *Stats {
+	return &Stats{
+		rdb: rdb,
+	}
+}
+
+// Record increments the counter for the outcome of a category by count.
+func (s *Stats) Record(category NotificationCategory, outcome Outcome, count int) {
+	if count <= 0 {
+		return
+	}
+
+	metrics.NotificationsDispatched.WithLabelValues(string(category), string(outcome)).Add(float64(count))
+
+	now := time.Now()
+	key := statsKey(category, now)
+
+	entry, err := json.Marshal(&StatsEntry{Timestamp: now.Unix(), Category: category, Outcome: outcome, Count: count})
+	if err != nil {
+		log.Printf("failed to marshal stats entry err: %v", err)
+		return
+	}
+
+	ctx := s.rdb.Context()
+
+	pipe := s.rdb.TxPipeline()
+	pipe.HIncrBy(ctx, key, string(outcome), int64(count))
+	pipe.Expire(ctx, key, statsRetention)
+	pipe.SAdd(ctx, statsCategoriesKey, string(category))
+	pipe.LPush(ctx, statsHistoryKey, string(entry))
+	pipe.LTrim(ctx, statsHistoryKey, 0, statsHistoryLength-1)
+
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		log.Printf("failed to record stats err: %v", err)
+	}
+}
+
+// GetStats returns the counters for every category between start and end.
+// If category is empty, all categories that have recorded stats are returned.
+func (s *Stats) GetStats(category NotificationCategory, start, end time.Time) ([]*CategoryStats, error) {
+	categories, err := s.categories(category)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end = clampStatsRange(start, end)
+
+	result := make([]*CategoryStats, 0)
+	for _, c := range categories {
+		stats := &CategoryStats{Category: c}
+
+		for t := start.Truncate(statsBucket); !t.After(end); t = t.Add(statsBucket) {
+			values, err := s.rdb.HGetAll(s.rdb.Context(), statsKey(c, t)).Result()
+			if err != nil {
+				return nil, err
+			}
+
+			stats.add(values)
+		}
+
+		result = append(result, stats)
+	}
+
+	return result, nil
+}
+
+// GetHistory returns the recent outcomes recorded between start and end, newest first.
+// If category is empty, outcomes for all categories are returned.
+func (s *Stats) GetHistory(category NotificationCategory, start, end time.Time) ([]*StatsEntry, error) {
+	data, err := s.rdb.LRange(s.rdb.Context(), statsHistoryKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*StatsEntry, 0)
+	for _, item := range data {
+		entry := &StatsEntry{}
+		err := json.Unmarshal([]byte(item), entry)
+		if err != nil {
+			log.Printf("failed to unmarshal stats entry err: %v", err)
+			continue
+		}
+
+		if category != "" && entry.Category != category {
+			continue
+		}
+
+		if entry.Timestamp < start.Unix() || entry.Timestamp > end.Unix() {
+			continue
+		}
+
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+func (s *Stats) categories(category NotificationCategory) ([]NotificationCategory, error) {
+	if category != "" {
+		return []NotificationCategory{category}, nil
+	}
+
+	members, err := s.rdb.SMembers(s.rdb.Context(), statsCategoriesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]NotificationCategory, 0, len(members))
+	for _, member := range members {
+		result = append(result, NotificationCategory(member))
+	}
+
+	return result, nil
+}
+
+func (c *CategoryStats) add(values map[string]string) {
+	for field, value := range values {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch Outcome(field) {
+		case OutcomeSent:
+			c.Sent += count
+		case OutcomeSuppressed:
+			c.Suppressed += count
+		case OutcomeFailed:
+			c.Failed += count
+		case OutcomeRetried:
+			c.Retried += count
+		case OutcomeUnregistered:
+			c.Unregistered += count
+		}
+	}
+}
+
+// clampStatsRange ensures we never scan more buckets than are retained.
+func clampStatsRange(start, end time.Time) (time.Time, time.Time) {
+	now := time.Now()
+	if end.IsZero() || end.After(now) {
+		end = now
+	}
+
+	oldest := end.Add(-statsRetention)
+	if start.Before(oldest) {
+		start = oldest
+	}
+
+	return start, end
+}
+
+func statsKey(category NotificationCategory, t time.Time) string {
+	return fmt.Sprintf("notifications_stats_%s_%d", category, t.Truncate(statsBucket).Unix())
+}
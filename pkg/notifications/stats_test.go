@@ -0,0 +1,96 @@
+package notifications_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/notifications"
+)
+
+func TestStats_GetStats(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	stats := notifications.NewStats(rdb)
+
+	stats.Record(notifications.NEW_ROOM, notifications.OutcomeSent, 3)
+	stats.Record(notifications.NEW_ROOM, notifications.OutcomeSent, 2)
+	stats.Record(notifications.NEW_ROOM, notifications.OutcomeSuppressed, 4)
+	stats.Record(notifications.NEW_FOLLOWER, notifications.OutcomeUnregistered, 1)
+	stats.Record(notifications.NEW_FOLLOWER, notifications.OutcomeFailed, 0)
+
+	now := time.Now()
+
+	result, err := stats.GetStats(notifications.NEW_ROOM, now.Add(-time.Hour), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*notifications.CategoryStats{
+		{Category: notifications.NEW_ROOM, Sent: 5, Suppressed: 4},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v actual %v", expected, result)
+	}
+
+	result, err = stats.GetStats("", now.Add(-time.Hour), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("expected 2 categories actual %d", len(result))
+	}
+}
+
+func TestStats_GetHistory(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	stats := notifications.NewStats(rdb)
+
+	stats.Record(notifications.NEW_ROOM, notifications.OutcomeSent, 3)
+	stats.Record(notifications.NEW_FOLLOWER, notifications.OutcomeRetried, 1)
+
+	now := time.Now()
+
+	result, err := stats.GetHistory(notifications.NEW_FOLLOWER, now.Add(-time.Hour), now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 entry actual %d", len(result))
+	}
+
+	entry := result[0]
+	if entry.Category != notifications.NEW_FOLLOWER || entry.Outcome != notifications.OutcomeRetried || entry.Count != 1 {
+		t.Fatalf("unexpected entry %v", entry)
+	}
+
+	result, err = stats.GetHistory("", now.Add(time.Hour), now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 0 {
+		t.Fatalf("expected no entries actual %d", len(result))
+	}
+}
@@ -3,9 +3,10 @@ package notifications
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 
 	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/log"
 )
 
 const placeholder = "val"
@@ -48,7 +49,7 @@ func (s *Storage) GetNotifications(user int) ([]*Notification, error) {
 		n := &Notification{}
 		err := json.Unmarshal([]byte(item), n)
 		if err != nil {
-			log.Printf("failed to unmarshal notification err: %v\n", err)
+			log.Printf("failed to unmarshal notification err: %v", err)
 			continue
 		}
 
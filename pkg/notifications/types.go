@@ -14,6 +14,7 @@ const (
 	TEST                   NotificationCategory = "TEST"
 	INFO                   NotificationCategory = "INFO"
 	FOLLOW_RECOMMENDATIONS NotificationCategory = "FOLLOW_RECOMMENDATIONS"
+	STORY_REPLY            NotificationCategory = "STORY_REPLY"
 )
 
 type Frequency int
@@ -80,6 +81,18 @@ func NewRoomNotificationWithName(id, creator, name string) *PushNotification {
 	}
 }
 
+func NewRoomNotificationWithTopic(id, creator, topic string) *PushNotification {
+	return &PushNotification{
+		Category: NEW_ROOM,
+		Alert: Alert{
+			Key:       "new_room_with_topic_notification",
+			Arguments: []string{creator, topic},
+		},
+		Arguments:  map[string]interface{}{"id": id, "topic": topic},
+		CollapseID: id,
+	}
+}
+
 func NewRoomInviteNotification(id, from string) *PushNotification {
 	return &PushNotification{
 		Category: ROOM_INVITE,
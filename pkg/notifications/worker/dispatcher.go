@@ -1,22 +1,36 @@
 package worker
 
-import "github.com/soapboxsocial/soapbox/pkg/notifications"
+import (
+	"context"
+	"sync"
+
+	"github.com/soapboxsocial/soapbox/pkg/log"
+	"github.com/soapboxsocial/soapbox/pkg/notifications"
+)
 
 type Dispatcher struct {
 	jobs chan Job
 	pool chan chan Job
+	quit chan bool
 
 	maxWorkers int
+	workers    []*Worker
 
 	config *Config
+
+	mux     sync.RWMutex
+	stopped bool
+	wg      *sync.WaitGroup
 }
 
 func NewDispatcher(maxWorkers int, config *Config) *Dispatcher {
 	return &Dispatcher{
 		jobs:       make(chan Job),
 		pool:       make(chan chan Job),
+		quit:       make(chan bool),
 		maxWorkers: maxWorkers,
 		config:     config,
+		wg:         &sync.WaitGroup{},
 	}
 }
 
@@ -25,11 +39,45 @@ func (d *Dispatcher) Run() {
 	for i := 0; i < d.maxWorkers; i++ {
 		worker := NewWorker(d.pool, d.config)
 		worker.Start()
+
+		d.workers = append(d.workers, worker)
 	}
 
 	go d.dispatch()
 }
 
+// Shutdown stops accepting new jobs and waits for the queued ones to be handled before stopping the workers.
+// If ctx is canceled first, the jobs that are still queued are dropped.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mux.Lock()
+	d.stopped = true
+	d.mux.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	for _, worker := range d.workers {
+		worker.Stop()
+	}
+
+	go func() {
+		d.quit <- true
+	}()
+
+	return err
+}
+
 func (d *Dispatcher) dispatch() {
 	for {
 		select {
@@ -43,12 +91,25 @@ func (d *Dispatcher) dispatch() {
 				// dispatch the job to the worker job channel
 				jobChannel <- job
 			}(job)
+		case <-d.quit:
+			// We have been asked to stop.
+			return
 		}
 	}
 }
 
-func (d *Dispatcher) Dispatch(origin int, targets []notifications.Target, notification *notifications.PushNotification) {
+func (d *Dispatcher) Dispatch(ctx context.Context, origin int, targets []notifications.Target, notification *notifications.PushNotification) {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+
+	if d.stopped {
+		log.Ctx(ctx).Printf("dropping %s notification, dispatcher is shutting down", notification.Category)
+		return
+	}
+
+	d.wg.Add(1)
+
 	go func() {
-		d.jobs <- Job{Origin: origin, Targets: targets, Notification: notification}
+		d.jobs <- Job{Context: ctx, Origin: origin, Targets: targets, Notification: notification, WaitGroup: d.wg}
 	}()
 }
@@ -0,0 +1,32 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/notifications"
+	"github.com/soapboxsocial/soapbox/pkg/notifications/worker"
+)
+
+func TestDispatcher_Shutdown(t *testing.T) {
+	dispatch := worker.NewDispatcher(2, &worker.Config{})
+	dispatch.Run()
+
+	notification := &notifications.PushNotification{Category: notifications.ROOM_JOINED}
+
+	for i := 0; i < 10; i++ {
+		dispatch.Dispatch(context.Background(), 0, []notifications.Target{}, notification)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := dispatch.Shutdown(ctx)
+	if err != nil {
+		t.Fatalf("queued jobs were not handled: %v", err)
+	}
+
+	// jobs dispatched after shutting down are dropped.
+	dispatch.Dispatch(context.Background(), 0, []notifications.Target{}, notification)
+}
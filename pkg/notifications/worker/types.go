@@ -1,9 +1,19 @@
 package worker
 
-import "github.com/soapboxsocial/soapbox/pkg/notifications"
+import (
+	"context"
+	"sync"
+
+	"github.com/soapboxsocial/soapbox/pkg/notifications"
+)
 
 type Job struct {
+	// Context contains the request ID of the request that caused the job, if any.
+	Context      context.Context
 	Origin       int
 	Targets      []notifications.Target
 	Notification *notifications.PushNotification
+
+	// WaitGroup is marked done once the job was handled, if set.
+	WaitGroup *sync.WaitGroup
 }
@@ -1,14 +1,16 @@
 package worker
 
 import (
-	"log"
+	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/soapboxsocial/soapbox/pkg/analytics"
 	"github.com/soapboxsocial/soapbox/pkg/devices"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/notifications"
 )
 
@@ -18,6 +20,7 @@ type Config struct {
 	Devices   *devices.Backend
 	Store     *notifications.Storage
 	Analytics *analytics.Backend
+	Stats     *notifications.Stats
 }
 
 type Worker struct {
@@ -74,6 +77,15 @@ func (w *Worker) Stop() {
 }
 
 func (w *Worker) handle(job Job) {
+	if job.WaitGroup != nil {
+		defer job.WaitGroup.Done()
+	}
+
+	ctx := job.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	ids := make([]int, 0)
 	targets := make([]notifications.Target, 0)
 
@@ -86,37 +98,46 @@ func (w *Worker) handle(job Job) {
 		targets = append(targets, target)
 	}
 
+	w.config.Stats.Record(job.Notification.Category, notifications.OutcomeSuppressed, len(job.Targets)-len(targets))
+
 	if len(ids) == 0 {
 		return
 	}
 
-	d, err := w.config.Devices.GetDevicesForUsers(ids)
+	d, err := w.config.Devices.GetDevicesForUsers(ctx, ids)
 	if err != nil {
-		log.Printf("devicesBackend.GetDevicesForUsers err: %v\n", err)
+		log.Ctx(ctx).Printf("devicesBackend.GetDevicesForUsers err: %v", err)
 		return
 	}
 
-	log.Printf("pushing %s to %d targets", job.Notification.Category, len(targets))
+	log.Ctx(ctx).Printf("pushing %s to %d targets", job.Notification.Category, len(targets))
 
 	notification := *job.Notification
 	notification.UUID = uuid.NewString()
 
 	for i := 0; i < w.maxRetries; i++ {
-		d = w.sendNotifications(d, notification)
+		if i > 0 {
+			w.config.Stats.Record(notification.Category, notifications.OutcomeRetried, len(d))
+		}
+
+		d = w.sendNotifications(ctx, d, notification)
 		if len(d) == 0 {
 			break
 		}
 	}
 
+	// anything left over could not be delivered even after retrying.
+	w.config.Stats.Record(notification.Category, notifications.OutcomeFailed, len(d))
+
 	for _, target := range targets {
 		an := notification.AnalyticsNotification()
 		if job.Origin != 0 {
 			an.Origin = &job.Origin
 		}
 
-		err := w.config.Analytics.AddSentNotification(target.ID, an)
+		err := w.config.Analytics.AddSentNotification(ctx, target.ID, an)
 		if err != nil {
-			log.Printf("analytics.AddSentNotification err: %s\n", err)
+			log.Ctx(ctx).Printf("analytics.AddSentNotification err: %s", err)
 		}
 
 		w.config.Limiter.SentNotification(target, job.Notification)
@@ -128,17 +149,20 @@ func (w *Worker) handle(job Job) {
 
 		err = w.config.Store.Store(target.ID, store)
 		if err != nil {
-			log.Printf("notificationStorage.Store err: %v\n", err)
+			log.Ctx(ctx).Printf("notificationStorage.Store err: %v", err)
 		}
 	}
 }
 
 // @TODO THIS SHOULD PROBABLY BE MOVED INTO APNS, especially once we add iOS
-func (w *Worker) sendNotifications(devices []string, notification notifications.PushNotification) []string {
+func (w *Worker) sendNotifications(ctx context.Context, devices []string, notification notifications.PushNotification) []string {
 	var wg sync.WaitGroup
+	var mu sync.Mutex
 
 	retry := make([]string, 0)
 
+	var sent, failed, unregistered int32
+
 	for _, device := range devices {
 		wg.Add(1)
 		go func(device string) {
@@ -146,12 +170,19 @@ func (w *Worker) sendNotifications(devices []string, notification notifications.
 			if err != nil {
 				switch err {
 				case notifications.ErrDeviceUnregistered:
+					atomic.AddInt32(&unregistered, 1)
 					w.unregistered <- device
 				case notifications.ErrRetryRequired:
+					mu.Lock()
 					retry = append(retry, device)
+					mu.Unlock()
+				default:
+					atomic.AddInt32(&failed, 1)
 				}
 
-				log.Printf("failed to send to target \"%s\" with error: %s\n", device, err)
+				log.Ctx(ctx).Printf("failed to send to target \"%s\" with error: %s", device, err)
+			} else {
+				atomic.AddInt32(&sent, 1)
 			}
 
 			wg.Done()
@@ -160,6 +191,10 @@ func (w *Worker) sendNotifications(devices []string, notification notifications.
 
 	wg.Wait()
 
+	w.config.Stats.Record(notification.Category, notifications.OutcomeSent, int(sent))
+	w.config.Stats.Record(notification.Category, notifications.OutcomeFailed, int(failed))
+	w.config.Stats.Record(notification.Category, notifications.OutcomeUnregistered, int(unregistered))
+
 	return retry
 }
 
@@ -167,7 +202,7 @@ func (w *Worker) wipeDevices() {
 	for device := range w.unregistered {
 		log.Printf("removing device: %s", device)
 
-		err := w.config.Devices.RemoveDevice(device)
+		err := w.config.Devices.RemoveDevice(context.Background(), device)
 		if err != nil {
 			log.Printf("failed to remove device err: %s", err)
 		}
@@ -195,6 +230,13 @@ func getNotificationForStore(notification *notifications.PushNotification) *noti
 			From:      0,
 			Category:  notification.Category,
 		}
+	case notifications.STORY_REPLY:
+		return &notifications.Notification{
+			Timestamp: time.Now().Unix(),
+			From:      notification.Arguments["from"].(int),
+			Category:  notification.Category,
+			Arguments: map[string]interface{}{"story": notification.Arguments["id"]},
+		}
 	default:
 		return nil
 	}
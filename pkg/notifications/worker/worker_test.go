@@ -46,6 +46,7 @@ func TestWorker(t *testing.T) {
 			Devices:   devices.NewBackend(db),
 			Store:     notifications.NewStorage(rdb),
 			Analytics: analytics.NewBackend(db),
+			Stats:     notifications.NewStats(rdb),
 		},
 	)
 
@@ -116,6 +117,7 @@ func TestWorker_WithUnregistered(t *testing.T) {
 			Devices:   devices.NewBackend(db),
 			Store:     notifications.NewStorage(rdb),
 			Analytics: analytics.NewBackend(db),
+			Stats:     notifications.NewStats(rdb),
 		},
 	)
 
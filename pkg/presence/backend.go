@@ -0,0 +1,426 @@
+// Package presence keeps track of which users are online and pushes changes to their followers.
+package presence
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/lib/pq"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+)
+
+// Timeout is how long users stay online after they were last active, users in a room stay online until they leave.
+const Timeout = 15 * time.Minute
+
+const (
+	lastSeenKey = "presence_last_seen"
+	onlineKey   = "presence_online"
+	roomsKey    = "presence_rooms"
+)
+
+// roomKey is the set of users in a room.
+func roomKey(room string) string {
+	return "presence_room_" + room
+}
+
+// leaveScript removes a user from the members of a room, and removes their room only if it is still the room they are
+// leaving. They may already have joined another one by the time the event is handled.
+var leaveScript = redis.NewScript(`
+redis.call("SREM", KEYS[2], ARGV[1])
+local current = redis.call("HGET", KEYS[1], ARGV[1])
+if current and string.sub(current, 1, #ARGV[2]) == ARGV[2] then
+	return redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// visibilityScript updates the visibility of a room for all of its members, it returns the members that were updated.
+// Members that have moved on to another room are removed from the room.
+var visibilityScript = redis.NewScript(`
+local updated = {}
+local members = redis.call("SMEMBERS", KEYS[2])
+for _, member in ipairs(members) do
+	local current = redis.call("HGET", KEYS[1], member)
+	if not current or string.sub(current, 1, #ARGV[1]) ~= ARGV[1] then
+		redis.call("SREM", KEYS[2], member)
+	elseif current ~= ARGV[1] .. ARGV[2] then
+		redis.call("HSET", KEYS[1], member, ARGV[1] .. ARGV[2])
+		table.insert(updated, member)
+	end
+end
+return updated
+`)
+
+type Backend struct {
+	rdb   *redis.Client
+	db    *sql.DB
+	queue *pubsub.Queue
+}
+
+func NewBackend(rdb *redis.Client, db *sql.DB, queue *pubsub.Queue) *Backend {
+	return &Backend{rdb: rdb, db: db, queue: queue}
+}
+
+// Heartbeat marks a user as active, followers are only notified when the user comes online.
+func (b *Backend) Heartbeat(ctx context.Context, user int) error {
+	pipe := b.rdb.TxPipeline()
+	pipe.ZAdd(ctx, lastSeenKey, &redis.Z{Score: float64(time.Now().Unix()), Member: user})
+	added := pipe.SAdd(ctx, onlineKey, user)
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if added.Val() == 0 {
+		return nil
+	}
+
+	return b.publish(ctx, user)
+}
+
+// JoinRoom marks a user as online in a room.
+func (b *Backend) JoinRoom(ctx context.Context, user int, room string, visibility pubsub.RoomVisibility) error {
+	pipe := b.rdb.TxPipeline()
+	pipe.ZAdd(ctx, lastSeenKey, &redis.Z{Score: float64(time.Now().Unix()), Member: user})
+	pipe.SAdd(ctx, onlineKey, user)
+	pipe.HSet(ctx, roomsKey, user, room+":"+string(visibility))
+	pipe.SAdd(ctx, roomKey(room), user)
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return b.publish(ctx, user)
+}
+
+// LeaveRoom removes a user from a room, they stay online until they time out.
+func (b *Backend) LeaveRoom(ctx context.Context, user int, room string) error {
+	err := b.rdb.ZAdd(ctx, lastSeenKey, &redis.Z{Score: float64(time.Now().Unix()), Member: user}).Err()
+	if err != nil {
+		return err
+	}
+
+	left, err := leaveScript.Run(ctx, b.rdb, []string{roomsKey, roomKey(room)}, user, room+":").Int()
+	if err != nil {
+		return err
+	}
+
+	if left == 0 {
+		return nil
+	}
+
+	return b.publish(ctx, user)
+}
+
+// UpdateRoomVisibility changes the visibility of a room for the users in it,
+// followers are notified so that they learn about public rooms and stop seeing private ones.
+func (b *Backend) UpdateRoomVisibility(ctx context.Context, room string, visibility pubsub.RoomVisibility) error {
+	result, err := visibilityScript.Run(ctx, b.rdb, []string{roomsKey, roomKey(room)}, room+":", string(visibility)).Result()
+	if err != nil {
+		return err
+	}
+
+	values, _ := result.([]interface{})
+
+	members := make([]string, 0, len(values))
+	for _, value := range values {
+		if member, ok := value.(string); ok {
+			members = append(members, member)
+		}
+	}
+
+	return b.publish(ctx, toInts(members)...)
+}
+
+// Expire takes users offline that were last active before before and are not in a room.
+func (b *Backend) Expire(ctx context.Context, before time.Time) error {
+	members, err := b.rdb.SMembers(ctx, onlineKey).Result()
+	if err != nil {
+		return err
+	}
+
+	users := toInts(members)
+	if len(users) == 0 {
+		return nil
+	}
+
+	states, err := b.state(ctx, users)
+	if err != nil {
+		return err
+	}
+
+	pipe := b.rdb.Pipeline()
+
+	expired := make(map[int]*redis.IntCmd)
+	for _, s := range states {
+		if s.room != "" || !time.Unix(s.lastSeen, 0).Before(before) {
+			continue
+		}
+
+		expired[s.id] = pipe.SRem(ctx, onlineKey, s.id)
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	offline := make([]int, 0, len(expired))
+	for _, user := range users {
+		// another instance may have expired the user already.
+		if removed, ok := expired[user]; ok && removed.Val() > 0 {
+			offline = append(offline, user)
+		}
+	}
+
+	return b.publish(ctx, offline...)
+}
+
+// Remove forgets everything about the presence of a user.
+func (b *Backend) Remove(ctx context.Context, user int) error {
+	states, err := b.state(ctx, []int{user})
+	if err != nil {
+		return err
+	}
+
+	pipe := b.rdb.TxPipeline()
+	pipe.ZRem(ctx, lastSeenKey, user)
+	pipe.SRem(ctx, onlineKey, user)
+	pipe.HDel(ctx, roomsKey, strconv.Itoa(user))
+
+	if room := states[0].room; room != "" {
+		pipe.SRem(ctx, roomKey(room), user)
+	}
+
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Get returns the presence of users as their followers see it, in the same order.
+func (b *Backend) Get(ctx context.Context, users []int) ([]Presence, error) {
+	if len(users) == 0 {
+		return []Presence{}, nil
+	}
+
+	state, err := b.state(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := b.getSettingsForUsers(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Presence, len(users))
+	for i, s := range state {
+		result[i] = s.visible(settings[s.id])
+	}
+
+	return result, nil
+}
+
+// GetOnline returns the presence of the users that are online,
+// users in a room come first followed by the most recently active.
+func (b *Backend) GetOnline(ctx context.Context, users []int) ([]Presence, error) {
+	all, err := b.Get(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Presence, 0)
+	for _, p := range all {
+		if p.Online {
+			result = append(result, p)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if (result[i].Room != nil) != (result[j].Room != nil) {
+			return result[i].Room != nil
+		}
+
+		if result[i].LastSeen != result[j].LastSeen {
+			return result[i].LastSeen > result[j].LastSeen
+		}
+
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+// GetSettings returns the presence settings of a user.
+func (b *Backend) GetSettings(ctx context.Context, user int) (*Settings, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT appear_offline, hide_room FROM presence_settings WHERE user_id = $1;")
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &Settings{}
+	err = stmt.QueryRowContext(ctx, user).Scan(&settings.AppearOffline, &settings.HideRoom)
+	if err == sql.ErrNoRows {
+		return settings, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings stores the presence settings of a user, followers are notified of the change straight away.
+func (b *Backend) UpdateSettings(ctx context.Context, user int, settings Settings) error {
+	query := `INSERT INTO presence_settings (user_id, appear_offline, hide_room) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET appear_offline = $2, hide_room = $3;`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, user, settings.AppearOffline, settings.HideRoom)
+	if err != nil {
+		return err
+	}
+
+	return b.publish(ctx, user)
+}
+
+func (b *Backend) getSettingsForUsers(ctx context.Context, users []int) (map[int]Settings, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT user_id, appear_offline, hide_room FROM presence_settings WHERE user_id = ANY($1);")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(users))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make(map[int]Settings)
+	for rows.Next() {
+		var id int
+		settings := Settings{}
+
+		err := rows.Scan(&id, &settings.AppearOffline, &settings.HideRoom)
+		if err != nil {
+			return nil, err
+		}
+
+		result[id] = settings
+	}
+
+	return result, rows.Err()
+}
+
+// publish notifies followers of the current presence of users.
+func (b *Backend) publish(ctx context.Context, users ...int) error {
+	presence, err := b.Get(ctx, users)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range presence {
+		var room string
+		if p.Room != nil {
+			room = *p.Room
+		}
+
+		err := b.queue.Publish(ctx, pubsub.PresenceTopic, pubsub.NewPresenceUpdateEvent(p.ID, p.Online, room, p.LastSeen))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// state is the presence of a user before their settings are applied.
+type state struct {
+	id         int
+	online     bool
+	lastSeen   int64
+	room       string
+	visibility pubsub.RoomVisibility
+}
+
+func (s state) visible(settings Settings) Presence {
+	if settings.AppearOffline {
+		return Presence{ID: s.id}
+	}
+
+	p := Presence{ID: s.id, Online: s.online, LastSeen: s.lastSeen}
+	if s.room != "" && s.visibility == pubsub.Public && !settings.HideRoom {
+		room := s.room
+		p.Room = &room
+	}
+
+	return p
+}
+
+func (b *Backend) state(ctx context.Context, users []int) ([]state, error) {
+	pipe := b.rdb.Pipeline()
+
+	lastSeen := make([]*redis.FloatCmd, len(users))
+	online := make([]*redis.BoolCmd, len(users))
+	rooms := make([]*redis.StringCmd, len(users))
+
+	for i, user := range users {
+		member := strconv.Itoa(user)
+		lastSeen[i] = pipe.ZScore(ctx, lastSeenKey, member)
+		online[i] = pipe.SIsMember(ctx, onlineKey, member)
+		rooms[i] = pipe.HGet(ctx, roomsKey, member)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	result := make([]state, len(users))
+	for i, user := range users {
+		s := state{id: user, online: online[i].Val(), lastSeen: int64(lastSeen[i].Val())}
+
+		room := rooms[i].Val()
+		if idx := strings.LastIndex(room, ":"); idx != -1 {
+			s.room = room[:idx]
+			s.visibility = pubsub.RoomVisibility(room[idx+1:])
+		}
+
+		result[i] = s
+	}
+
+	return result, nil
+}
+
+// toInts converts the members of a Redis set or hash to user IDs, skipping anything that is not one.
+func toInts(members []string) []int {
+	result := make([]int, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.Atoi(member)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, id)
+	}
+
+	return result
+}
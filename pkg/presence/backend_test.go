@@ -0,0 +1,220 @@
+package presence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/presence"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+func TestBackend(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	backend := presence.NewBackend(rdb, db, pubsub.NewQueue(rdb))
+	ctx := context.Background()
+
+	// every change is published, which reads the settings of the user.
+	expectSettings(mock, 7)
+
+	err = backend.Heartbeat(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = backend.JoinRoom(ctx, 1, "foo", pubsub.Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertPresence(t, backend, presence.Presence{ID: 1, Online: true, Room: strPtr("foo")})
+
+	// leaving a room the user is no longer in does not change their room.
+	err = backend.LeaveRoom(ctx, 1, "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertPresence(t, backend, presence.Presence{ID: 1, Online: true, Room: strPtr("foo")})
+
+	// users in a room do not expire.
+	err = backend.Expire(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = backend.LeaveRoom(ctx, 1, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = backend.Expire(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertPresence(t, backend, presence.Presence{ID: 1, Online: false})
+}
+
+func TestBackend_Get_Settings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	backend := presence.NewBackend(rdb, db, pubsub.NewQueue(rdb))
+	ctx := context.Background()
+
+	expectSettings(mock, 2)
+
+	err = backend.JoinRoom(ctx, 1, "foo", pubsub.Private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = backend.JoinRoom(ctx, 2, "foo", pubsub.Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectPrepare("^SELECT user_id, appear_offline, hide_room FROM presence_settings").ExpectQuery().
+		WillReturnRows(mock.NewRows([]string{"user_id", "appear_offline", "hide_room"}).AddRow(2, true, false))
+
+	result, err := backend.Get(ctx, []int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result[0].Online || result[0].Room != nil {
+		t.Fatalf("private rooms should be hidden %v", result[0])
+	}
+
+	if result[1].Online || result[1].LastSeen != 0 {
+		t.Fatalf("user should appear offline %v", result[1])
+	}
+
+	if result[2].Online {
+		t.Fatalf("unknown user should be offline %v", result[2])
+	}
+}
+
+func TestBackend_UpdateRoomVisibility(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	backend := presence.NewBackend(rdb, db, pubsub.NewQueue(rdb))
+	ctx := context.Background()
+
+	expectSettings(mock, 5)
+
+	err = backend.JoinRoom(ctx, 1, "foo", pubsub.Private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = backend.JoinRoom(ctx, 2, "foo", pubsub.Private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the user moved on without their leave being handled yet.
+	err = backend.JoinRoom(ctx, 2, "bar", pubsub.Private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = backend.UpdateRoomVisibility(ctx, "foo", pubsub.Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertPresence(t, backend, presence.Presence{ID: 1, Online: true, Room: strPtr("foo")})
+
+	members, err := mr.Members("presence_room_foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != 1 || members[0] != "1" {
+		t.Fatalf("unexpected members %v", members)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertPresence(t *testing.T, backend *presence.Backend, expected presence.Presence) {
+	t.Helper()
+
+	result, err := backend.Get(context.Background(), []int{expected.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := result[0]
+	if p.Online != expected.Online {
+		t.Fatalf("expected online %v actual %v", expected.Online, p.Online)
+	}
+
+	if (p.Room == nil) != (expected.Room == nil) || (p.Room != nil && *p.Room != *expected.Room) {
+		t.Fatalf("expected room %v actual %v", expected.Room, p.Room)
+	}
+
+	if p.LastSeen == 0 {
+		t.Fatal("expected last seen to be set")
+	}
+}
+
+func expectSettings(mock sqlmock.Sqlmock, times int) {
+	mock.MatchExpectationsInOrder(false)
+
+	for i := 0; i < times; i++ {
+		mock.ExpectPrepare("^SELECT user_id, appear_offline, hide_room FROM presence_settings").ExpectQuery().
+			WillReturnRows(mock.NewRows([]string{"user_id", "appear_offline", "hide_room"}))
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,158 @@
+package presence
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/soapboxsocial/soapbox/pkg/followers"
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
+)
+
+type Endpoint struct {
+	backend   *Backend
+	hub       *Hub
+	followers *followers.FollowersBackend
+}
+
+func NewEndpoint(backend *Backend, hub *Hub, followers *followers.FollowersBackend) *Endpoint {
+	return &Endpoint{backend: backend, hub: hub, followers: followers}
+}
+
+func (e *Endpoint) Router() *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/stream", e.stream).Methods("GET")
+	r.HandleFunc("/settings", e.settings).Methods("GET")
+	r.HandleFunc("/settings", e.updateSettings).Methods("POST")
+
+	return r
+}
+
+// stream sends the presence of the users followed by the caller as server-sent events.
+// It starts with every followed user that is online, followed by changes as they happen.
+// Users followed after the stream started are only included once the client reconnects.
+func (e *Endpoint) stream(w http.ResponseWriter, r *http.Request) {
+	id, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "unauthorized")
+		return
+	}
+
+	following, err := e.followers.GetAllFollowingIDsFor(r.Context(), id)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("followers.GetAllFollowingIDsFor err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	// subscribing before reading the current presence ensures no change in between is missed.
+	subscription := e.hub.Subscribe(following)
+	defer e.hub.Unsubscribe(subscription)
+
+	online, err := e.backend.GetOnline(r.Context(), following)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.GetOnline err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	stream, err := httputil.NewEventStream(w)
+	if err != nil {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "streaming unsupported")
+		return
+	}
+
+	for _, p := range online {
+		err := stream.Send("", "presence", p)
+		if err != nil {
+			return
+		}
+	}
+
+	stream.Flush()
+
+	ticker := time.NewTicker(httputil.KeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case p, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+
+			err := stream.Send("", "presence", p)
+			if err != nil {
+				return
+			}
+
+			stream.Flush()
+		case <-ticker.C:
+			err := stream.KeepAlive()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (e *Endpoint) settings(w http.ResponseWriter, r *http.Request) {
+	id, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "unauthorized")
+		return
+	}
+
+	settings, err := e.backend.GetSettings(r.Context(), id)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.GetSettings err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = httputil.JsonEncode(w, settings)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("httputil.JsonEncode err: %s", err)
+	}
+}
+
+func (e *Endpoint) updateSettings(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	id, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "unauthorized")
+		return
+	}
+
+	appearOffline, err := strconv.ParseBool(r.Form.Get("appear_offline"))
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	hideRoom, err := strconv.ParseBool(r.Form.Get("hide_room"))
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = e.backend.UpdateSettings(r.Context(), id, Settings{AppearOffline: appearOffline, HideRoom: hideRoom})
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.UpdateSettings err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	httputil.JsonSuccess(w)
+}
@@ -0,0 +1,139 @@
+package presence
+
+import (
+	"sync"
+
+	"github.com/soapboxsocial/soapbox/pkg/log"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+// subscriptionBuffer is how many updates a subscriber may fall behind before updates to it are dropped.
+const subscriptionBuffer = 64
+
+// Subscription receives presence updates for a fixed set of users.
+type Subscription struct {
+	C <-chan Presence
+
+	c     chan Presence
+	users []int
+}
+
+// Hub fans presence updates out to the subscriptions watching the users they are about.
+type Hub struct {
+	mux           sync.RWMutex
+	subscriptions map[int]map[*Subscription]bool
+	closed        bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subscriptions: make(map[int]map[*Subscription]bool)}
+}
+
+// Subscribe returns a subscription to the presence updates of users.
+// The subscription is closed once the hub stops.
+func (h *Hub) Subscribe(users []int) *Subscription {
+	c := make(chan Presence, subscriptionBuffer)
+	s := &Subscription{C: c, c: c, users: users}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if h.closed {
+		close(c)
+		return s
+	}
+
+	for _, user := range users {
+		if h.subscriptions[user] == nil {
+			h.subscriptions[user] = make(map[*Subscription]bool)
+		}
+
+		h.subscriptions[user][s] = true
+	}
+
+	return s
+}
+
+// Unsubscribe stops updates to a subscription.
+func (h *Hub) Unsubscribe(s *Subscription) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	for _, user := range s.users {
+		delete(h.subscriptions[user], s)
+
+		if len(h.subscriptions[user]) == 0 {
+			delete(h.subscriptions, user)
+		}
+	}
+}
+
+// Run dispatches presence update events until events is closed, all subscriptions are closed afterwards.
+func (h *Hub) Run(events <-chan *pubsub.Event) {
+	for event := range events {
+		if event.Type != pubsub.EventTypePresenceUpdate {
+			continue
+		}
+
+		p, err := presenceFromEvent(event)
+		if err != nil {
+			log.Printf("failed to decode presence err: %v", err)
+			continue
+		}
+
+		h.dispatch(p)
+	}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.closed = true
+	for _, subscriptions := range h.subscriptions {
+		for s := range subscriptions {
+			h.closeSubscription(s)
+		}
+	}
+}
+
+func (h *Hub) dispatch(p Presence) {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	for s := range h.subscriptions[p.ID] {
+		select {
+		case s.c <- p:
+		default:
+			log.Printf("dropping presence of %d for slow subscriber", p.ID)
+		}
+	}
+}
+
+// closeSubscription closes a subscription and removes it, it must be called with the lock held.
+func (h *Hub) closeSubscription(s *Subscription) {
+	for _, user := range s.users {
+		delete(h.subscriptions[user], s)
+	}
+
+	close(s.c)
+}
+
+func presenceFromEvent(event *pubsub.Event) (Presence, error) {
+	id, err := event.GetInt("id")
+	if err != nil {
+		return Presence{}, err
+	}
+
+	lastSeen, err := event.GetInt("last_seen")
+	if err != nil {
+		return Presence{}, err
+	}
+
+	online, _ := event.Params["online"].(bool)
+
+	p := Presence{ID: id, Online: online, LastSeen: int64(lastSeen)}
+	if room, _ := event.Params["room"].(string); room != "" {
+		p.Room = &room
+	}
+
+	return p, nil
+}
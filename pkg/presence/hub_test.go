@@ -0,0 +1,62 @@
+package presence_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/presence"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+func TestHub(t *testing.T) {
+	hub := presence.NewHub()
+
+	events := make(chan *pubsub.Event)
+	done := make(chan struct{})
+
+	go func() {
+		hub.Run(events)
+		close(done)
+	}()
+
+	subscription := hub.Subscribe([]int{1, 2})
+	other := hub.Subscribe([]int{3})
+
+	events <- getRawEvent(t, pubsub.NewPresenceUpdateEvent(1, true, "foo", 10))
+	events <- getRawEvent(t, pubsub.NewPresenceUpdateEvent(4, true, "", 10))
+
+	p := <-subscription.C
+	if p.ID != 1 || !p.Online || p.Room == nil || *p.Room != "foo" || p.LastSeen != 10 {
+		t.Fatalf("unexpected presence %v", p)
+	}
+
+	hub.Unsubscribe(other)
+
+	close(events)
+	<-done
+
+	if _, ok := <-subscription.C; ok {
+		t.Fatal("expected subscription to be closed")
+	}
+
+	if _, ok := <-hub.Subscribe([]int{1}).C; ok {
+		t.Fatal("expected subscriptions after the hub stopped to be closed")
+	}
+}
+
+func getRawEvent(t *testing.T, event pubsub.Event) *pubsub.Event {
+	t.Helper()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evt := &pubsub.Event{}
+	err = json.Unmarshal(data, evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return evt
+}
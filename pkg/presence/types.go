@@ -0,0 +1,17 @@
+package presence
+
+// Presence is whether a user is online as their followers see it.
+type Presence struct {
+	ID     int     `json:"id"`
+	Online bool    `json:"online"`
+	Room   *string `json:"room,omitempty"`
+
+	// LastSeen is the last time the user was active, it is not set for users that appear offline.
+	LastSeen int64 `json:"last_seen,omitempty"`
+}
+
+// Settings controls what followers of a user learn about their presence.
+type Settings struct {
+	AppearOffline bool `json:"appear_offline"`
+	HideRoom      bool `json:"hide_room"`
+}
@@ -1,8 +1,16 @@
 package pubsub
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
 )
 
 type EventType int
@@ -23,8 +31,72 @@ const (
 	EventTypeRoomOpenMini
 	EventTypeDeleteUser
 	EventTypeFollowRecommendations
+	EventTypeUserExportRequested
+	EventTypeUserDeletionScheduled
+	EventTypeUserDeletionCancelled
+	EventTypeStoryReply
+	EventTypePresenceUpdate
+	EventTypeInboxUpdate
+	EventTypeRoomMetadataUpdate
+	EventTypeRoomRename
+	EventTypeRoomVisibilityUpdate
+	EventTypeRoomClosed
+	EventTypeUnfollow
+	EventTypeUserBlocked
 )
 
+// eventTypeNames are the stable names of the event types, used where events leave the system.
+var eventTypeNames = map[EventType]string{
+	EventTypeNewRoom:               "new_room",
+	EventTypeRoomJoin:              "room_join",
+	EventTypeRoomInvite:            "room_invite",
+	EventTypeNewFollower:           "new_follower",
+	EventTypeUserUpdate:            "user_update",
+	EventTypeRoomLeft:              "room_left",
+	EventTypeNewUser:               "new_user",
+	EventTypeNewStory:              "new_story",
+	EventTypeStoryReaction:         "story_reaction",
+	EventTypeUserHeartbeat:         "user_heartbeat",
+	EventTypeWelcomeRoom:           "welcome_room",
+	EventTypeRoomLinkShare:         "room_link_share",
+	EventTypeRoomOpenMini:          "room_open_mini",
+	EventTypeDeleteUser:            "delete_user",
+	EventTypeFollowRecommendations: "follow_recommendations",
+	EventTypeUserExportRequested:   "user_export_requested",
+	EventTypeUserDeletionScheduled: "user_deletion_scheduled",
+	EventTypeUserDeletionCancelled: "user_deletion_cancelled",
+	EventTypeStoryReply:            "story_reply",
+	EventTypePresenceUpdate:        "presence_update",
+	EventTypeInboxUpdate:           "inbox_update",
+	EventTypeRoomMetadataUpdate:    "room_metadata_update",
+	EventTypeRoomRename:            "room_rename",
+	EventTypeRoomVisibilityUpdate:  "room_visibility_update",
+	EventTypeRoomClosed:            "room_closed",
+	EventTypeUnfollow:              "unfollow",
+	EventTypeUserBlocked:           "user_blocked",
+}
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	name, ok := eventTypeNames[t]
+	if !ok {
+		return fmt.Sprintf("unknown_%d", int(t))
+	}
+
+	return name
+}
+
+// ParseEventType returns the event type with name.
+func ParseEventType(name string) (EventType, error) {
+	for t, n := range eventTypeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown event type %s", name)
+}
+
 type RoomVisibility string
 
 const (
@@ -33,8 +105,58 @@ const (
 )
 
 type Event struct {
-	Type   EventType              `json:"type"`
-	Params map[string]interface{} `json:"params"`
+	Type      EventType              `json:"type"`
+	Params    map[string]interface{} `json:"params"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Trace     TraceCarrier           `json:"trace,omitempty"`
+}
+
+// Context returns a context containing the request ID and trace of the request that published the event.
+func (e Event) Context() context.Context {
+	ctx := context.Background()
+	if e.RequestID != "" {
+		ctx = httputil.WithRequestID(ctx, e.RequestID)
+	}
+
+	if len(e.Trace) == 0 {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, e.Trace)
+}
+
+// StartSpan starts a consumer span for handling the event, continuing the trace of the publisher.
+func (e Event) StartSpan(name string) (context.Context, trace.Span) {
+	return tracing.Start(
+		e.Context(),
+		name,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String(messagingSystem),
+			semconv.MessagingOperationKey.String("process"),
+			EventTypeKey.Int(int(e.Type)),
+		),
+	)
+}
+
+// TraceCarrier holds the trace context propagated with an event.
+type TraceCarrier map[string]string
+
+func (c TraceCarrier) Get(key string) string {
+	return c[key]
+}
+
+func (c TraceCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c TraceCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
 }
 
 func (e Event) GetInt(field string) (int, error) {
@@ -67,6 +189,20 @@ func NewFollowerEvent(follower, id int) Event {
 	}
 }
 
+func NewUnfollowEvent(follower, id int) Event {
+	return Event{
+		Type:   EventTypeUnfollow,
+		Params: map[string]interface{}{"follower": follower, "id": id},
+	}
+}
+
+func NewUserBlockedEvent(user, blocked int) Event {
+	return Event{
+		Type:   EventTypeUserBlocked,
+		Params: map[string]interface{}{"id": user, "blocked": blocked},
+	}
+}
+
 func NewRoomCreationEvent(id string, creator int, visibility RoomVisibility) Event {
 	return Event{
 		Type:   EventTypeNewRoom,
@@ -88,9 +224,33 @@ func NewStoryCreationEvent(creator int) Event {
 	}
 }
 
-func NewStoryReactionEvent(user int) Event {
+func NewStoryReactionEvent(user int, story string, owner int) Event {
 	return Event{
 		Type:   EventTypeStoryReaction,
+		Params: map[string]interface{}{"id": user, "story": story, "owner": owner},
+	}
+}
+
+func NewStoryReplyEvent(story string, from, to int) Event {
+	return Event{
+		Type:   EventTypeStoryReply,
+		Params: map[string]interface{}{"story": story, "from": from, "id": to},
+	}
+}
+
+// NewPresenceUpdateEvent describes the presence of a user as their followers may see it,
+// room is empty unless the user is in a room their followers are allowed to know about.
+func NewPresenceUpdateEvent(user int, online bool, room string, lastSeen int64) Event {
+	return Event{
+		Type:   EventTypePresenceUpdate,
+		Params: map[string]interface{}{"id": user, "online": online, "room": room, "last_seen": lastSeen},
+	}
+}
+
+// NewInboxUpdateEvent signals that new items were added to the inbox of a user.
+func NewInboxUpdateEvent(user int) Event {
+	return Event{
+		Type:   EventTypeInboxUpdate,
 		Params: map[string]interface{}{"id": user},
 	}
 }
@@ -109,6 +269,42 @@ func NewRoomLeftEvent(room string, user int, visibility RoomVisibility, joined t
 	}
 }
 
+func NewRoomMetadataUpdateEvent(room string, user int) Event {
+	return Event{
+		Type:   EventTypeRoomMetadataUpdate,
+		Params: map[string]interface{}{"id": room, "creator": user},
+	}
+}
+
+func NewRoomRenameEvent(room string, user int) Event {
+	return Event{
+		Type:   EventTypeRoomRename,
+		Params: map[string]interface{}{"id": room, "creator": user},
+	}
+}
+
+func NewRoomVisibilityUpdateEvent(room string, user int, visibility RoomVisibility) Event {
+	return Event{
+		Type:   EventTypeRoomVisibilityUpdate,
+		Params: map[string]interface{}{"id": room, "creator": user, "visibility": visibility},
+	}
+}
+
+// NewRoomClosedEvent is published when the last member left a room, it contains the totals of the room.
+func NewRoomClosedEvent(room, name string, owner int, visibility RoomVisibility, started time.Time, reactions int) Event {
+	return Event{
+		Type: EventTypeRoomClosed,
+		Params: map[string]interface{}{
+			"id":         room,
+			"name":       name,
+			"creator":    owner,
+			"visibility": visibility,
+			"started":    started.Unix(),
+			"reactions":  reactions,
+		},
+	}
+}
+
 func NewUserHeartbeatEvent(user int) Event {
 	return Event{
 		Type:   EventTypeUserHeartbeat,
@@ -150,3 +346,24 @@ func NewFollowRecommendationsEvent(user int) Event {
 		Params: map[string]interface{}{"id": user},
 	}
 }
+
+func NewUserExportRequestedEvent(user int) Event {
+	return Event{
+		Type:   EventTypeUserExportRequested,
+		Params: map[string]interface{}{"id": user},
+	}
+}
+
+func NewUserDeletionScheduledEvent(user int) Event {
+	return Event{
+		Type:   EventTypeUserDeletionScheduled,
+		Params: map[string]interface{}{"id": user},
+	}
+}
+
+func NewUserDeletionCancelledEvent(user int) Event {
+	return Event{
+		Type:   EventTypeUserDeletionCancelled,
+		Params: map[string]interface{}{"id": user},
+	}
+}
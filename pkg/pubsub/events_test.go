@@ -0,0 +1,98 @@
+package pubsub_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
+)
+
+func TestEvent_Context(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctx, span := tracing.Start(httputil.WithRequestID(context.Background(), "1234"), "request")
+	defer span.End()
+
+	event := pubsub.NewUserUpdateEvent(1)
+	event.RequestID = "1234"
+	event.Trace = make(pubsub.TraceCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, event.Trace)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received := &pubsub.Event{}
+	err = json.Unmarshal(data, received)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, ok := httputil.GetRequestIDFromContext(received.Context())
+	if !ok || id != "1234" {
+		t.Fatalf("unexpected request id %s", id)
+	}
+
+	handleCtx, handle := received.StartSpan("test.handle")
+	handle.End()
+
+	sc := trace.SpanContextFromContext(handleCtx)
+	if sc.TraceID() != span.SpanContext().TraceID() {
+		t.Fatal("handler is not part of the publishing trace")
+	}
+
+	err = provider.ForceFlush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].SpanKind != trace.SpanKindConsumer || spans[0].Parent.SpanID() != span.SpanContext().SpanID() {
+		t.Fatalf("unexpected spans %v", spans)
+	}
+}
+
+func TestEvent_ContextWithoutTrace(t *testing.T) {
+	event := pubsub.NewUserUpdateEvent(1)
+
+	if trace.SpanContextFromContext(event.Context()).IsValid() {
+		t.Fatal("unexpected span context")
+	}
+
+	if _, ok := httputil.GetRequestIDFromContext(event.Context()); ok {
+		t.Fatal("unexpected request id")
+	}
+}
+
+func TestEventType_String(t *testing.T) {
+	for i := pubsub.EventTypeNewRoom; i <= pubsub.EventTypeUserBlocked; i++ {
+		name := i.String()
+		if strings.HasPrefix(name, "unknown") {
+			t.Fatalf("event type %d has no name", int(i))
+		}
+
+		parsed, err := pubsub.ParseEventType(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if parsed != i {
+			t.Fatalf("parsed %s as %d, expected %d", name, int(parsed), int(i))
+		}
+	}
+}
@@ -1,24 +1,51 @@
 package pubsub
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"strconv"
+	"sync"
 
 	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
+	"github.com/soapboxsocial/soapbox/pkg/metrics"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
 )
 
 type Topic string
 
+const messagingSystem = "redis"
+
+// EventTypeKey is the span attribute containing the type of event.
+const EventTypeKey = attribute.Key("pubsub.event_type")
+
 const (
 	RoomTopic  Topic = "room"
 	UserTopic  Topic = "user"
 	StoryTopic Topic = "story"
+
+	// PresenceTopic carries presence changes that were already filtered by the privacy settings of the user.
+	PresenceTopic Topic = "presence"
+
+	// InboxTopic wakes up the API servers streaming the inbox of a user.
+	InboxTopic Topic = "inbox"
 )
 
 type Queue struct {
 	buffer chan *Event
 
 	rdb *redis.Client
+
+	mux           sync.Mutex
+	subscriptions []*redis.PubSub
+	readers       sync.WaitGroup
+	closed        sync.Once
 }
 
 // NewQueue creates a new redis pubsub Queue.
@@ -30,13 +57,34 @@ func NewQueue(rdb *redis.Client) *Queue {
 }
 
 // Publish an Event on a specific topic.
-func (q *Queue) Publish(topic Topic, event Event) error {
+// The request ID and trace stored in the context are stamped on the event.
+func (q *Queue) Publish(ctx context.Context, topic Topic, event Event) error {
+	ctx, span := tracing.Start(
+		ctx,
+		string(topic)+" send",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String(messagingSystem),
+			semconv.MessagingDestinationKey.String(string(topic)),
+			EventTypeKey.Int(int(event.Type)),
+		),
+	)
+	defer span.End()
+
+	if id, ok := httputil.GetRequestIDFromContext(ctx); ok {
+		event.RequestID = id
+	}
+
+	event.Trace = make(TraceCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, event.Trace)
+
 	data, err := json.Marshal(event)
 	if err != nil {
+		span.RecordError(err)
 		return err
 	}
 
-	q.rdb.Publish(q.rdb.Context(), string(topic), data)
+	q.rdb.Publish(ctx, string(topic), data)
 	return nil
 }
 
@@ -48,12 +96,51 @@ func (q *Queue) Subscribe(topics ...Topic) <-chan *Event {
 	}
 
 	pubsub := q.rdb.Subscribe(q.rdb.Context(), t...)
+
+	q.mux.Lock()
+	q.subscriptions = append(q.subscriptions, pubsub)
+	q.mux.Unlock()
+
+	q.readers.Add(1)
 	go q.read(pubsub)
 
 	return q.buffer
 }
 
+// Close unsubscribes from all topics.
+// The channel returned by Subscribe is closed once the events that were already received are consumed.
+func (q *Queue) Close() error {
+	q.mux.Lock()
+	subscriptions := q.subscriptions
+	q.subscriptions = nil
+	q.mux.Unlock()
+
+	var result error
+	for _, pubsub := range subscriptions {
+		err := pubsub.Unsubscribe(q.rdb.Context())
+		if err != nil {
+			result = err
+		}
+
+		err = pubsub.Close()
+		if err != nil {
+			result = err
+		}
+	}
+
+	q.closed.Do(func() {
+		go func() {
+			q.readers.Wait()
+			close(q.buffer)
+		}()
+	})
+
+	return result
+}
+
 func (q *Queue) read(pubsub *redis.PubSub) {
+	defer q.readers.Done()
+
 	c := pubsub.Channel()
 	for msg := range c {
 		event := &Event{}
@@ -63,6 +150,8 @@ func (q *Queue) read(pubsub *redis.PubSub) {
 			continue
 		}
 
+		metrics.EventsConsumed.WithLabelValues(strconv.Itoa(int(event.Type))).Inc()
+
 		q.buffer <- event
 	}
 }
@@ -0,0 +1,38 @@
+package pubsub_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+func TestQueue_Close(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	queue := pubsub.NewQueue(rdb)
+	events := queue.Subscribe(pubsub.RoomTopic, pubsub.UserTopic)
+
+	_ = queue.Close()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("unexpected event")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("events were not closed")
+	}
+}
@@ -1,9 +1,12 @@
 package follows
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 	"github.com/soapboxsocial/soapbox/pkg/users/types"
 )
 
@@ -15,13 +18,13 @@ func NewBackend(db *sql.DB) *Backend {
 	return &Backend{db: db}
 }
 
-func (b *Backend) RecommendationsFor(user int) ([]types.User, error) {
-	stmt, err := b.db.Prepare("SELECT id, display_name, username, image FROM users WHERE id IN (SELECT recommendation FROM follow_recommendations WHERE user_id = $1);")
+func (b *Backend) RecommendationsFor(ctx context.Context, user int) ([]types.User, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT id, display_name, username, image FROM users WHERE id IN (SELECT recommendation FROM follow_recommendations WHERE user_id = $1);")
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(user)
+	rows, err := stmt.QueryContext(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -36,49 +39,39 @@ func (b *Backend) RecommendationsFor(user int) ([]types.User, error) {
 			return nil, err // @todo
 		}
 
+		user.Images = images.Variants(user.Image)
 		result = append(result, user)
 	}
 
 	return result, nil
 }
 
-func (b *Backend) AddRecommendationsFor(user int, recommendations []int) error {
-	tx, err := b.db.Begin()
-	if err != nil {
-		return err
-	}
-
-	stmt, err := tx.Prepare("INSERT INTO follow_recommendations (user_id, recommendation) VALUES ($1, $2)")
-	if err != nil {
-		_ = tx.Rollback()
-		return err
-	}
-
-	for _, id := range recommendations {
-		_, err = stmt.Exec(user, id)
+func (b *Backend) AddRecommendationsFor(ctx context.Context, user int, recommendations []int) error {
+	return sqlutil.Transaction(ctx, b.db, func(ctx context.Context) error {
+		stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO follow_recommendations (user_id, recommendation) VALUES ($1, $2)")
 		if err != nil {
-			_ = tx.Rollback()
 			return err
 		}
-	}
 
-	err = tx.Commit()
-	if err != nil {
-		_ = tx.Rollback()
-		return err
-	}
+		for _, id := range recommendations {
+			_, err = stmt.ExecContext(ctx, user, id)
+			if err != nil {
+				return err
+			}
+		}
 
-	return nil
+		return nil
+	})
 }
 
-func (b *Backend) LastUpdatedFor(user int) (*time.Time, error) {
-	stmt, err := b.db.Prepare("SELECT last_recommended FROM last_follow_recommended WHERE user_id = $1;")
+func (b *Backend) LastUpdatedFor(ctx context.Context, user int) (*time.Time, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT last_recommended FROM last_follow_recommended WHERE user_id = $1;")
 	if err != nil {
 		return nil, err
 	}
 
 	timestamp := &time.Time{}
-	err = stmt.QueryRow(user).Scan(timestamp)
+	err = stmt.QueryRowContext(ctx, user).Scan(timestamp)
 	if err != nil {
 		return nil, err
 	}
@@ -86,13 +79,13 @@ func (b *Backend) LastUpdatedFor(user int) (*time.Time, error) {
 	return timestamp, nil
 }
 
-func (b *Backend) SetLastUpdatedFor(user int) error {
-	stmt, err := b.db.Prepare("INSERT INTO last_follow_recommended (user_id, last_recommended) VALUES ($1, $2);")
+func (b *Backend) SetLastUpdatedFor(ctx context.Context, user int) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO last_follow_recommended (user_id, last_recommended) VALUES ($1, $2);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(user, time.Now())
+	_, err = stmt.ExecContext(ctx, user, time.Now())
 	if err != nil {
 		return err
 	}
@@ -3,7 +3,6 @@ package providers
 import (
 	"context"
 	"errors"
-	"log"
 	"net/http"
 	"sync"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/dghubble/oauth1"
 
 	"github.com/soapboxsocial/soapbox/pkg/linkedaccounts"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 )
 
 type Twitter struct {
@@ -29,13 +29,13 @@ func NewTwitter(oauth *oauth1.Config, backend *linkedaccounts.Backend, transport
 	}
 }
 
-func (t *Twitter) FindUsersToFollowFor(user int) ([]int, error) {
-	client, err := t.getClientForUser(user)
+func (t *Twitter) FindUsersToFollowFor(ctx context.Context, user int) ([]int, error) {
+	client, err := t.getClientForUser(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
-	accounts, err := t.backend.GetAllTwitterProfilesForUsersNotRecommendedToAndNotFollowedBy(user)
+	accounts, err := t.backend.GetAllTwitterProfilesForUsersNotRecommendedToAndNotFollowedBy(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,7 @@ func (t *Twitter) FindUsersToFollowFor(user int) ([]int, error) {
 
 			resp, err := request(client, accounts)
 			if err != nil {
-				log.Printf("request err: %s\n", err)
+				log.Printf("request err: %s", err)
 				return
 			}
 
@@ -73,20 +73,20 @@ func (t *Twitter) FindUsersToFollowFor(user int) ([]int, error) {
 	return ids, nil
 }
 
-func (t *Twitter) getClientForUser(id int) (*twitter.Client, error) {
-	account, err := t.backend.GetTwitterProfileFor(id)
+func (t *Twitter) getClientForUser(ctx context.Context, id int) (*twitter.Client, error) {
+	account, err := t.backend.GetTwitterProfileFor(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	access := oauth1.NewToken(account.Token, account.Secret)
 
-	ctx := oauth1.NoContext
+	clientCtx := oauth1.NoContext
 	if t.transport != nil {
-		ctx = context.WithValue(ctx, oauth1.HTTPClient, t.transport)
+		clientCtx = context.WithValue(clientCtx, oauth1.HTTPClient, t.transport)
 	}
 
-	httpClient := t.oauth.Client(ctx, access)
+	httpClient := t.oauth.Client(clientCtx, access)
 	return twitter.NewClient(httpClient), nil
 }
 
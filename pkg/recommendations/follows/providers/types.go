@@ -1,7 +1,9 @@
 package providers
 
+import "context"
+
 // FollowRecommendationsProvider is a generic interface for returning a set of recommended users to follow
 // for any specific user based on the algorithm.
 type FollowRecommendationsProvider interface {
-	FindUsersToFollowFor(user int) ([]int, error)
+	FindUsersToFollowFor(ctx context.Context, user int) ([]int, error)
 }
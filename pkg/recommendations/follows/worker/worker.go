@@ -1,9 +1,10 @@
 package worker
 
 import (
-	"log"
+	"context"
 	"time"
 
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/recommendations/follows"
 	"github.com/soapboxsocial/soapbox/pkg/recommendations/follows/providers"
@@ -60,9 +61,10 @@ func (w *Worker) Stop() {
 func (w *Worker) handle(job *Job) {
 	defer job.WaitGroup.Done()
 
+	ctx := context.Background()
 	id := job.UserID
 
-	last, err := w.config.Recommendations.LastUpdatedFor(id)
+	last, err := w.config.Recommendations.LastUpdatedFor(ctx, id)
 	if err != nil {
 		log.Printf("backend.LastUpdatedFor err: %s", err)
 		return
@@ -73,7 +75,7 @@ func (w *Worker) handle(job *Job) {
 		return
 	}
 
-	users, err := w.config.Twitter.FindUsersToFollowFor(id)
+	users, err := w.config.Twitter.FindUsersToFollowFor(ctx, id)
 	if err != nil {
 		log.Printf("twitter.FindUsersToFollowFor err: %s", err)
 		return
@@ -83,13 +85,13 @@ func (w *Worker) handle(job *Job) {
 		return
 	}
 
-	err = w.config.Recommendations.AddRecommendationsFor(id, users)
+	err = w.config.Recommendations.AddRecommendationsFor(ctx, id, users)
 	if err != nil {
 		log.Printf("backend.AddRecommendationsFor err: %s", err)
 		return
 	}
 
-	err = w.config.Queue.Publish(pubsub.UserTopic, pubsub.NewFollowRecommendationsEvent(id))
+	err = w.config.Queue.Publish(ctx, pubsub.UserTopic, pubsub.NewFollowRecommendationsEvent(id))
 	if err != nil {
 		log.Printf("w.queue.Publish err: %s", err)
 	}
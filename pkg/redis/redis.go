@@ -23,5 +23,8 @@ func NewRedis(config conf.RedisConf) *redis.Client {
 		opts.TLSConfig = &tls.Config{}
 	}
 
-	return redis.NewClient(opts)
+	rdb := redis.NewClient(opts)
+	rdb.AddHook(TracingHook{})
+
+	return rdb
 }
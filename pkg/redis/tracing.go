@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
+)
+
+type spanKey struct{}
+
+// TracingHook records commands that are made with a context that is part of a trace.
+type TracingHook struct{}
+
+func (TracingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return startSpan(ctx, cmd.FullName(), cmd.Name()), nil
+}
+
+func (TracingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endSpan(ctx, cmd.Err())
+	return nil
+}
+
+func (TracingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	names := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		names = append(names, cmd.Name())
+	}
+
+	return startSpan(ctx, "pipeline", strings.Join(names, " ")), nil
+}
+
+func (TracingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmd.Err() != nil && cmd.Err() != redis.Nil {
+			err = cmd.Err()
+			break
+		}
+	}
+
+	endSpan(ctx, err)
+	return nil
+}
+
+func startSpan(ctx context.Context, name, operation string) context.Context {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx
+	}
+
+	ctx, span := tracing.Start(
+		ctx,
+		"redis "+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String("redis"),
+			semconv.DBOperationKey.String(operation),
+		),
+	)
+
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+func endSpan(ctx context.Context, err error) {
+	span, ok := ctx.Value(spanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+
+	if err != nil && err != redis.Nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
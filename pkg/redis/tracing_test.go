@@ -0,0 +1,71 @@
+package redis_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/redis"
+)
+
+func TestTracingHook(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := strconv.Atoi(mr.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewRedis(conf.RedisConf{
+		Port:       port,
+		Host:       mr.Host(),
+		DisableTLS: true,
+	})
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+
+	// commands outside of a trace are not recorded.
+	rdb.Set(context.Background(), "foo", "bar", 0)
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "parent")
+	rdb.Get(ctx, "foo")
+	span.End()
+
+	err = provider.ForceFlush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var command, parent *tracetest.SpanStub
+	spans := exporter.GetSpans()
+	for i := range spans {
+		s := &spans[i]
+		switch s.Name {
+		case "redis get":
+			command = s
+		case "redis set":
+			t.Fatal("command outside of trace was recorded")
+		case "parent":
+			parent = s
+		}
+	}
+
+	if command == nil || parent == nil {
+		t.Fatal("spans were not recorded")
+	}
+
+	if command.Parent.SpanID() != parent.SpanContext.SpanID() {
+		t.Fatal("command span is not a child of the parent")
+	}
+}
@@ -1,9 +1,10 @@
 package rooms
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
 )
 
@@ -20,7 +21,7 @@ func NewAuth(rooms *Repository, blocked *blocks.Backend) *Auth {
 }
 
 // CanJoin returns whether a user can join a specific room.
-func (a *Auth) CanJoin(room string, user int) bool {
+func (a *Auth) CanJoin(ctx context.Context, room string, user int) bool {
 	r, err := a.rooms.Get(room)
 	if err != nil {
 		return false
@@ -30,11 +31,11 @@ func (a *Auth) CanJoin(room string, user int) bool {
 		return false
 	}
 
-	return !a.containsBlockers(r, user)
+	return !a.containsBlockers(ctx, r, user)
 }
 
 // FilterWhoCanJoin checks for a set of users who can join a room.
-func (a *Auth) FilterWhoCanJoin(room string, users []int64) []int64 {
+func (a *Auth) FilterWhoCanJoin(ctx context.Context, room string, users []int64) []int64 {
 	r, err := a.rooms.Get(room)
 	if err != nil {
 		return []int64{}
@@ -47,7 +48,7 @@ func (a *Auth) FilterWhoCanJoin(room string, users []int64) []int64 {
 			continue
 		}
 
-		if a.containsBlockers(r, int(user)) {
+		if a.containsBlockers(ctx, r, int(user)) {
 			continue
 		}
 
@@ -69,10 +70,10 @@ func (a *Auth) canJoin(room *Room, user int) bool {
 	return true
 }
 
-func (a *Auth) containsBlockers(room *Room, user int) bool {
-	blockingUsers, err := a.blocked.GetUsersWhoBlocked(user)
+func (a *Auth) containsBlockers(ctx context.Context, room *Room, user int) bool {
+	blockingUsers, err := a.blocked.GetUsersWhoBlocked(ctx, user)
 	if err != nil {
-		fmt.Printf("failed to get blocked users who blocked: %+v", err)
+		log.Ctx(ctx).Printf("failed to get blocked users who blocked: %+v", err)
 	}
 
 	return room.ContainsUsers(blockingUsers)
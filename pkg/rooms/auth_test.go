@@ -1,6 +1,7 @@
 package rooms
 
 import (
+	"context"
 	"reflect"
 	"strconv"
 	"testing"
@@ -72,7 +73,7 @@ func TestAuth_CanJoin(t *testing.T) {
 				WithArgs(user).
 				WillReturnRows(rows)
 
-			res := auth.CanJoin(id, user)
+			res := auth.CanJoin(context.Background(), id, user)
 			if res != tt.Expected {
 				t.Fatalf("CanJoin actual: %v expected: %v", res, tt.Expected)
 			}
@@ -133,7 +134,7 @@ func TestAuth_FilterWhoCanJoin(t *testing.T) {
 				WithArgs(user).
 				WillReturnRows(rows)
 
-			res := auth.FilterWhoCanJoin(id, []int64{user})
+			res := auth.FilterWhoCanJoin(context.Background(), id, []int64{user})
 			if !reflect.DeepEqual(res, tt.Expected) {
 				t.Fatalf("CanJoin actual: %v expected: %v", res, tt.Expected)
 			}
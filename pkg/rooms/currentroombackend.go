@@ -1,7 +1,10 @@
 package rooms
 
 import (
+	"context"
 	"database/sql"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 )
 
 type CurrentRoomBackend struct {
@@ -14,13 +17,13 @@ func NewCurrentRoomBackend(db *sql.DB) *CurrentRoomBackend {
 	}
 }
 
-func (b *CurrentRoomBackend) GetCurrentRoomForUser(id int) (string, error) {
-	stmt, err := b.db.Prepare("SELECT room FROM current_rooms WHERE user_id = $1;")
+func (b *CurrentRoomBackend) GetCurrentRoomForUser(ctx context.Context, id int) (string, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT room FROM current_rooms WHERE user_id = $1;")
 	if err != nil {
 		return "", err
 	}
 
-	row := stmt.QueryRow(id)
+	row := stmt.QueryRowContext(ctx, id)
 
 	var room string
 	err = row.Scan(&room)
@@ -31,22 +34,22 @@ func (b *CurrentRoomBackend) GetCurrentRoomForUser(id int) (string, error) {
 	return room, nil
 }
 
-func (b *CurrentRoomBackend) SetCurrentRoomForUser(user int, room string) error {
-	stmt, err := b.db.Prepare("SELECT update_current_rooms($1, $2);")
+func (b *CurrentRoomBackend) SetCurrentRoomForUser(ctx context.Context, user int, room string) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT update_current_rooms($1, $2);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(user, room)
+	_, err = stmt.ExecContext(ctx, user, room)
 	return err
 }
 
-func (b *CurrentRoomBackend) RemoveCurrentRoomForUser(user int) error {
-	stmt, err := b.db.Prepare("DELETE FROM current_rooms WHERE user_id = $1")
+func (b *CurrentRoomBackend) RemoveCurrentRoomForUser(ctx context.Context, user int) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "DELETE FROM current_rooms WHERE user_id = $1")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(user)
+	_, err = stmt.ExecContext(ctx, user)
 	return err
 }
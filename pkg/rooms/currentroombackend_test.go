@@ -1,6 +1,7 @@
 package rooms_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -25,7 +26,7 @@ func TestCurrentRoomBackend_GetCurrentRoomForUser(t *testing.T) {
 		ExpectQuery().
 		WillReturnRows(sqlmock.NewRows([]string{"room"}).AddRow(room))
 
-	val, err := backend.GetCurrentRoomForUser(user)
+	val, err := backend.GetCurrentRoomForUser(context.Background(), user)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -53,7 +54,7 @@ func TestCurrentRoomBackend_SetCurrentRoomForUser(t *testing.T) {
 		WithArgs(user, room).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = backend.SetCurrentRoomForUser(user, room)
+	err = backend.SetCurrentRoomForUser(context.Background(), user, room)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -76,7 +77,7 @@ func TestCurrentRoomBackend_RemoveCurrentRoomForUser(t *testing.T) {
 		WithArgs(user).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = backend.RemoveCurrentRoomForUser(user)
+	err = backend.RemoveCurrentRoomForUser(context.Background(), user)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1,20 +1,32 @@
 package rooms
 
 import (
-	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
 )
 
 type RoomState struct {
-	ID         string       `json:"id"`
-	Name       string       `json:"name"`
-	Visibility string       `json:"visibility"`
-	Members    []RoomMember `json:"members"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Topics      []string     `json:"topics"`
+	Language    string       `json:"language,omitempty"`
+	Visibility  string       `json:"visibility"`
+	Members     []RoomMember `json:"members"`
+}
+
+// RankedRoomState is a RoomState with the reasons it was recommended to the user.
+type RankedRoomState struct {
+	RoomState
+
+	Reasons []string `json:"reasons"`
 }
 
 type RoomMember struct {
@@ -27,13 +39,15 @@ type Endpoint struct {
 	repository *Repository
 	server     *Server
 	auth       *Auth
+	ranker     *Ranker
 }
 
-func NewEndpoint(repository *Repository, server *Server, auth *Auth) *Endpoint {
+func NewEndpoint(repository *Repository, server *Server, auth *Auth, ranker *Ranker) *Endpoint {
 	return &Endpoint{
 		repository: repository,
 		server:     server,
 		auth:       auth,
+		ranker:     ranker,
 	}
 }
 
@@ -48,29 +62,63 @@ func (e *Endpoint) Router() *mux.Router {
 }
 
 func (e *Endpoint) rooms(w http.ResponseWriter, r *http.Request) {
-	rooms := make([]RoomState, 0)
-
 	userID, ok := httputil.GetUserIDFromContext(r.Context())
 	if !ok {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
 		return
 	}
 
-	e.repository.Map(func(room *Room) {
-		if room.ConnectionState() == closed {
-			return
-		}
+	cursor := rankCursor{}
+	paginated, err := httputil.DecodeCursor(r.URL.Query(), &cursor)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
+		return
+	}
+
+	limit := httputil.GetLimit(r.URL.Query())
+
+	// later pages are ranked at the time of the first, so the freshness of rooms does not move them between pages.
+	now := time.Now()
+	if paginated {
+		now = time.Unix(0, cursor.Time)
+	}
 
-		if !e.auth.CanJoin(room.id, userID) {
-			return
+	ranked, err := e.ranker.Rank(r.Context(), userID, parseLanguages(r.Header.Get("Accept-Language")), now)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("ranker.Rank err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	start := 0
+	if paginated {
+		for start < len(ranked) && !cursor.before(ranked[start]) {
+			start++
 		}
+	}
 
-		rooms = append(rooms, roomToRoomState(room))
-	})
+	ranked = ranked[start:]
+
+	var next interface{}
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+		next = newRankCursor(ranked[limit-1], now)
+	}
+
+	rooms := make([]RankedRoomState, 0)
+	for _, room := range ranked {
+		rooms = append(rooms, RankedRoomState{RoomState: roomToRoomState(room.Room), Reasons: room.Reasons})
+	}
 
-	err := httputil.JsonEncode(w, rooms)
+	page, err := httputil.NewPage(rooms, next)
 	if err != nil {
-		log.Printf("rooms error: %v\n", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = httputil.JsonEncode(w, page)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("rooms error: %v", err)
 	}
 }
 
@@ -89,14 +137,14 @@ func (e *Endpoint) room(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !e.auth.CanJoin(room.id, userID) {
+	if !e.auth.CanJoin(r.Context(), room.id, userID) {
 		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "not found")
 		return
 	}
 
 	err = httputil.JsonEncode(w, roomToRoomState(room))
 	if err != nil {
-		log.Printf("room error: %v\n", err)
+		log.Ctx(r.Context()).Printf("room error: %v", err)
 	}
 }
 
@@ -116,10 +164,54 @@ func roomToRoomState(room *Room) RoomState {
 		visibility = "private"
 	}
 
+	metadata := room.Metadata()
+
 	return RoomState{
-		ID:         room.id,
-		Name:       room.name,
-		Visibility: visibility,
-		Members:    members,
+		ID:          room.id,
+		Name:        room.Name(),
+		Description: metadata.Description,
+		Topics:      metadata.Topics,
+		Language:    metadata.Language,
+		Visibility:  visibility,
+		Members:     members,
+	}
+}
+
+// rankCursor is the position in the list of ranked rooms, rooms are ordered by descending score and then by ID.
+// Rooms open and close between requests, so paging by position would repeat or skip rooms.
+// Time is when the first page was ranked in nanoseconds, all pages are scored as of then.
+type rankCursor struct {
+	Time  int64   `json:"time"`
+	Score float64 `json:"score"`
+	ID    string  `json:"id"`
+}
+
+func newRankCursor(room *RankedRoom, ranked time.Time) rankCursor {
+	return rankCursor{Time: ranked.UnixNano(), Score: room.Score, ID: room.Room.ID()}
+}
+
+// before returns whether the cursor is positioned before room, meaning room belongs on the next page.
+func (c rankCursor) before(room *RankedRoom) bool {
+	if room.Score != c.Score {
+		return room.Score < c.Score
+	}
+
+	return room.Room.ID() > c.ID
+}
+
+// parseLanguages returns the primary language subtags of an Accept-Language header, ignoring their weights.
+func parseLanguages(header string) []string {
+	languages := make([]string, 0)
+
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		tag = strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		languages = append(languages, tag)
 	}
+
+	return languages
 }
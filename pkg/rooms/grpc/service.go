@@ -3,6 +3,10 @@ package grpc
 import (
 	"context"
 	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/soapboxsocial/soapbox/pkg/rooms"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/internal"
@@ -15,31 +19,39 @@ type Service struct {
 	repository *rooms.Repository
 	ws         *rooms.WelcomeStore
 	auth       *rooms.Auth
+	ranker     *rooms.Ranker
 }
 
-func NewService(repository *rooms.Repository, ws *rooms.WelcomeStore, auth *rooms.Auth) *Service {
+func NewService(repository *rooms.Repository, ws *rooms.WelcomeStore, auth *rooms.Auth, ranker *rooms.Ranker) *Service {
 	return &Service{
 		repository: repository,
 		ws:         ws,
 		auth:       auth,
+		ranker:     ranker,
 	}
 }
 
 func (s *Service) GetRoom(_ context.Context, request *pb.GetRoomRequest) (*pb.GetRoomResponse, error) {
 	r, err := s.repository.Get(request.Id)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
 	return &pb.GetRoomResponse{State: r.ToProto()}, nil
 }
 
-func (s *Service) ListRooms(context.Context, *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
-	result := make([]*pb.RoomState, 0)
+// ListRooms returns the rooms in the order they are ranked for the requested user.
+// Without a user all rooms are returned.
+func (s *Service) ListRooms(ctx context.Context, request *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	ranked, err := s.ranker.Rank(ctx, int(request.GetUserId()), request.GetLanguages(), time.Now())
+	if err != nil {
+		return nil, err
+	}
 
-	s.repository.Map(func(room *rooms.Room) {
-		result = append(result, room.ToProto())
-	})
+	result := make([]*pb.RoomState, 0)
+	for _, room := range ranked {
+		result = append(result, room.Room.ToProto())
+	}
 
 	return &pb.ListRoomsResponse{Rooms: result}, nil
 }
@@ -74,7 +86,7 @@ func (s *Service) RegisterWelcomeRoom(_ context.Context, request *pb.RegisterWel
 	return &pb.RegisterWelcomeRoomResponse{Id: id}, nil
 }
 
-func (s *Service) FilterUsersThatCanJoin(_ context.Context, request *pb.FilterUsersThatCanJoinRequest) (*pb.FilterUsersThatCanJoinResponse, error) {
+func (s *Service) FilterUsersThatCanJoin(ctx context.Context, request *pb.FilterUsersThatCanJoinRequest) (*pb.FilterUsersThatCanJoinResponse, error) {
 	if request == nil || request.Room == "" {
 		return nil, errors.New("no message")
 	}
@@ -83,6 +95,6 @@ func (s *Service) FilterUsersThatCanJoin(_ context.Context, request *pb.FilterUs
 		return &pb.FilterUsersThatCanJoinResponse{Ids: []int64{}}, nil
 	}
 
-	users := s.auth.FilterWhoCanJoin(request.Room, request.Ids)
+	users := s.auth.FilterWhoCanJoin(ctx, request.Room, request.Ids)
 	return &pb.FilterUsersThatCanJoinResponse{Ids: users}, nil
 }
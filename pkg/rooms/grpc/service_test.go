@@ -6,6 +6,8 @@ import (
 
 	"github.com/alicebob/miniredis"
 	"github.com/go-redis/redis/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/soapboxsocial/soapbox/pkg/rooms"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/grpc"
@@ -25,7 +27,7 @@ func TestService_RegisterWelcomeRoom(t *testing.T) {
 	repository := rooms.NewRepository()
 	ws := rooms.NewWelcomeStore(rdb)
 
-	service := grpc.NewService(repository, ws, nil)
+	service := grpc.NewService(repository, ws, nil, nil)
 
 	userID := int64(1)
 	resp, err := service.RegisterWelcomeRoom(context.Background(), &pb.RegisterWelcomeRoomRequest{UserId: userID})
@@ -42,3 +44,12 @@ func TestService_RegisterWelcomeRoom(t *testing.T) {
 		t.Errorf("%d does not equal %d", id, userID)
 	}
 }
+
+func TestService_GetRoom_NotFound(t *testing.T) {
+	service := grpc.NewService(rooms.NewRepository(), nil, nil, nil)
+
+	_, err := service.GetRoom(context.Background(), &pb.GetRoomRequest{Id: "123"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected not found actual %v", err)
+	}
+}
@@ -1,8 +1,8 @@
 package rooms
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 	"time"
@@ -10,6 +10,7 @@ import (
 	"github.com/pion/ion-sfu/pkg/sfu"
 	"github.com/pion/webrtc/v3"
 
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/signal"
 )
@@ -17,6 +18,8 @@ import (
 type Member struct {
 	mux sync.RWMutex
 
+	ctx context.Context
+
 	id        int
 	name      string
 	username  string
@@ -34,8 +37,9 @@ type Member struct {
 	dataChannel *BufferedDataChannel
 }
 
-func NewMember(id int, name, username, image string, peer *sfu.PeerLocal, signal signal.Transport) *Member {
+func NewMember(ctx context.Context, id int, name, username, image string, peer *sfu.PeerLocal, signal signal.Transport) *Member {
 	m := &Member{
+		ctx:         ctx,
 		id:          id,
 		name:        name,
 		username:    username,
@@ -53,6 +57,11 @@ func NewMember(id int, name, username, image string, peer *sfu.PeerLocal, signal
 	return m
 }
 
+// Context returns the context of the request the member connected with.
+func (m *Member) Context() context.Context {
+	return m.ctx
+}
+
 func (m *Member) IsConnected() bool {
 	m.mux.RLock()
 	defer m.mux.RUnlock()
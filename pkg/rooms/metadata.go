@@ -0,0 +1,91 @@
+package rooms
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+)
+
+const (
+	// MaxTopics is the amount of topics a room can be tagged with.
+	MaxTopics = 3
+
+	// MaxDescriptionLength is the amount of characters a room description can contain.
+	MaxDescriptionLength = 140
+)
+
+// Topics is the curated taxonomy rooms can be tagged with.
+var Topics = []string{
+	"art",
+	"books",
+	"business",
+	"comedy",
+	"education",
+	"fashion",
+	"film",
+	"food",
+	"gaming",
+	"health",
+	"music",
+	"news",
+	"politics",
+	"relationships",
+	"science",
+	"sports",
+	"technology",
+	"travel",
+}
+
+var (
+	topics = make(map[string]bool)
+
+	// languageCode matches ISO 639-1 and 639-2 language codes.
+	languageCode = regexp.MustCompile("^[a-z]{2,3}$")
+)
+
+func init() {
+	for _, topic := range Topics {
+		topics[topic] = true
+	}
+}
+
+// IsTopic returns whether a topic is part of the taxonomy.
+func IsTopic(topic string) bool {
+	return topics[topic]
+}
+
+// sanitizeMetadata trims the description, drops topics not in the taxonomy and unknown language codes.
+func sanitizeMetadata(metadata *pb.RoomMetadata) *pb.RoomMetadata {
+	result := &pb.RoomMetadata{Topics: make([]string, 0)}
+	if metadata == nil {
+		return result
+	}
+
+	result.Description = strings.TrimSpace(metadata.Description)
+	if len([]rune(result.Description)) > MaxDescriptionLength {
+		result.Description = string([]rune(result.Description)[:MaxDescriptionLength])
+	}
+
+	seen := make(map[string]bool)
+	for _, topic := range metadata.Topics {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if !IsTopic(topic) || seen[topic] {
+			continue
+		}
+
+		seen[topic] = true
+		result.Topics = append(result.Topics, topic)
+
+		if len(result.Topics) == MaxTopics {
+			break
+		}
+	}
+
+	language := strings.ToLower(strings.TrimSpace(metadata.Language))
+	if languageCode.MatchString(language) {
+		result.Language = language
+	}
+
+	return result
+}
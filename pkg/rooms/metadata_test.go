@@ -0,0 +1,55 @@
+package rooms
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+)
+
+func TestSanitizeMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       *pb.RoomMetadata
+		expected *pb.RoomMetadata
+	}{
+		{
+			name:     "nil",
+			in:       nil,
+			expected: &pb.RoomMetadata{Topics: []string{}},
+		},
+		{
+			name:     "topics",
+			in:       &pb.RoomMetadata{Topics: []string{"Music", "foo", "music", " art", "food", "film"}},
+			expected: &pb.RoomMetadata{Topics: []string{"music", "art", "food"}},
+		},
+		{
+			name:     "description",
+			in:       &pb.RoomMetadata{Description: " " + strings.Repeat("a", MaxDescriptionLength+1) + " "},
+			expected: &pb.RoomMetadata{Description: strings.Repeat("a", MaxDescriptionLength), Topics: []string{}},
+		},
+		{
+			name:     "language",
+			in:       &pb.RoomMetadata{Language: "EN"},
+			expected: &pb.RoomMetadata{Language: "en", Topics: []string{}},
+		},
+		{
+			name:     "invalid language",
+			in:       &pb.RoomMetadata{Language: "en-US"},
+			expected: &pb.RoomMetadata{Topics: []string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := sanitizeMetadata(tt.in)
+
+			if actual.Description != tt.expected.Description ||
+				actual.Language != tt.expected.Language ||
+				!reflect.DeepEqual(actual.Topics, tt.expected.Topics) {
+				t.Fatalf("expected %v actual %v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.26.0
-// 	protoc        v3.15.2
+// 	protoc        v3.17.1
 // source: soapbox/v1/room.proto
 
 package pb
@@ -184,6 +184,7 @@ type Command struct {
 	//	*Command_OpenMini_
 	//	*Command_CloseMini_
 	//	*Command_RequestMini_
+	//	*Command_UpdateMetadata_
 	Payload isCommand_Payload `protobuf_oneof:"payload"`
 }
 
@@ -345,6 +346,13 @@ func (x *Command) GetRequestMini() *Command_RequestMini {
 	return nil
 }
 
+func (x *Command) GetUpdateMetadata() *Command_UpdateMetadata {
+	if x, ok := x.GetPayload().(*Command_UpdateMetadata_); ok {
+		return x.UpdateMetadata
+	}
+	return nil
+}
+
 type isCommand_Payload interface {
 	isCommand_Payload()
 }
@@ -417,6 +425,10 @@ type Command_RequestMini_ struct {
 	RequestMini *Command_RequestMini `protobuf:"bytes,17,opt,name=request_mini,json=requestMini,proto3,oneof"`
 }
 
+type Command_UpdateMetadata_ struct {
+	UpdateMetadata *Command_UpdateMetadata `protobuf:"bytes,18,opt,name=update_metadata,json=updateMetadata,proto3,oneof"`
+}
+
 func (*Command_MuteUpdate_) isCommand_Payload() {}
 
 func (*Command_Reaction_) isCommand_Payload() {}
@@ -451,6 +463,8 @@ func (*Command_CloseMini_) isCommand_Payload() {}
 
 func (*Command_RequestMini_) isCommand_Payload() {}
 
+func (*Command_UpdateMetadata_) isCommand_Payload() {}
+
 type Event struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -475,6 +489,8 @@ type Event struct {
 	//	*Event_OpenedMini_
 	//	*Event_ClosedMini_
 	//	*Event_RequestedMini_
+	//	*Event_ServerRestarting_
+	//	*Event_UpdatedMetadata_
 	Payload isEvent_Payload `protobuf_oneof:"payload"`
 }
 
@@ -643,6 +659,20 @@ func (x *Event) GetRequestedMini() *Event_RequestedMini {
 	return nil
 }
 
+func (x *Event) GetServerRestarting() *Event_ServerRestarting {
+	if x, ok := x.GetPayload().(*Event_ServerRestarting_); ok {
+		return x.ServerRestarting
+	}
+	return nil
+}
+
+func (x *Event) GetUpdatedMetadata() *Event_UpdatedMetadata {
+	if x, ok := x.GetPayload().(*Event_UpdatedMetadata_); ok {
+		return x.UpdatedMetadata
+	}
+	return nil
+}
+
 type isEvent_Payload interface {
 	isEvent_Payload()
 }
@@ -715,6 +745,14 @@ type Event_RequestedMini_ struct {
 	RequestedMini *Event_RequestedMini `protobuf:"bytes,18,opt,name=requested_mini,json=requestedMini,proto3,oneof"`
 }
 
+type Event_ServerRestarting_ struct {
+	ServerRestarting *Event_ServerRestarting `protobuf:"bytes,19,opt,name=server_restarting,json=serverRestarting,proto3,oneof"`
+}
+
+type Event_UpdatedMetadata_ struct {
+	UpdatedMetadata *Event_UpdatedMetadata `protobuf:"bytes,20,opt,name=updated_metadata,json=updatedMetadata,proto3,oneof"`
+}
+
 func (*Event_Joined_) isEvent_Payload() {}
 
 func (*Event_Left_) isEvent_Payload() {}
@@ -749,6 +787,10 @@ func (*Event_ClosedMini_) isEvent_Payload() {}
 
 func (*Event_RequestedMini_) isEvent_Payload() {}
 
+func (*Event_ServerRestarting_) isEvent_Payload() {}
+
+func (*Event_UpdatedMetadata_) isEvent_Payload() {}
+
 type RoomState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -761,8 +803,9 @@ type RoomState struct {
 	Visibility Visibility              `protobuf:"varint,5,opt,name=visibility,proto3,enum=soapbox.v1.Visibility" json:"visibility,omitempty"`
 	Link       string                  `protobuf:"bytes,7,opt,name=link,proto3" json:"link,omitempty"`
 	// Deprecated: Do not use.
-	MiniOld string          `protobuf:"bytes,8,opt,name=mini_old,json=miniOld,proto3" json:"mini_old,omitempty"`
-	Mini    *RoomState_Mini `protobuf:"bytes,9,opt,name=mini,proto3" json:"mini,omitempty"`
+	MiniOld  string          `protobuf:"bytes,8,opt,name=mini_old,json=miniOld,proto3" json:"mini_old,omitempty"`
+	Mini     *RoomState_Mini `protobuf:"bytes,9,opt,name=mini,proto3" json:"mini,omitempty"`
+	Metadata *RoomMetadata   `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (x *RoomState) Reset() {
@@ -854,6 +897,76 @@ func (x *RoomState) GetMini() *RoomState_Mini {
 	return nil
 }
 
+func (x *RoomState) GetMetadata() *RoomMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type RoomMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Description string   `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Topics      []string `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Language    string   `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *RoomMetadata) Reset() {
+	*x = RoomMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_soapbox_v1_room_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomMetadata) ProtoMessage() {}
+
+func (x *RoomMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_soapbox_v1_room_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomMetadata.ProtoReflect.Descriptor instead.
+func (*RoomMetadata) Descriptor() ([]byte, []int) {
+	return file_soapbox_v1_room_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RoomMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RoomMetadata) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *RoomMetadata) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 type Command_MuteUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -865,7 +978,7 @@ type Command_MuteUpdate struct {
 func (x *Command_MuteUpdate) Reset() {
 	*x = Command_MuteUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[3]
+		mi := &file_soapbox_v1_room_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -878,7 +991,7 @@ func (x *Command_MuteUpdate) String() string {
 func (*Command_MuteUpdate) ProtoMessage() {}
 
 func (x *Command_MuteUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[3]
+	mi := &file_soapbox_v1_room_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -912,7 +1025,7 @@ type Command_Reaction struct {
 func (x *Command_Reaction) Reset() {
 	*x = Command_Reaction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[4]
+		mi := &file_soapbox_v1_room_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -925,7 +1038,7 @@ func (x *Command_Reaction) String() string {
 func (*Command_Reaction) ProtoMessage() {}
 
 func (x *Command_Reaction) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[4]
+	mi := &file_soapbox_v1_room_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +1072,7 @@ type Command_LinkShare struct {
 func (x *Command_LinkShare) Reset() {
 	*x = Command_LinkShare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[5]
+		mi := &file_soapbox_v1_room_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -972,7 +1085,7 @@ func (x *Command_LinkShare) String() string {
 func (*Command_LinkShare) ProtoMessage() {}
 
 func (x *Command_LinkShare) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[5]
+	mi := &file_soapbox_v1_room_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1006,7 +1119,7 @@ type Command_InviteAdmin struct {
 func (x *Command_InviteAdmin) Reset() {
 	*x = Command_InviteAdmin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[6]
+		mi := &file_soapbox_v1_room_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1019,7 +1132,7 @@ func (x *Command_InviteAdmin) String() string {
 func (*Command_InviteAdmin) ProtoMessage() {}
 
 func (x *Command_InviteAdmin) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[6]
+	mi := &file_soapbox_v1_room_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,7 +1164,7 @@ type Command_AcceptAdmin struct {
 func (x *Command_AcceptAdmin) Reset() {
 	*x = Command_AcceptAdmin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[7]
+		mi := &file_soapbox_v1_room_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1064,7 +1177,7 @@ func (x *Command_AcceptAdmin) String() string {
 func (*Command_AcceptAdmin) ProtoMessage() {}
 
 func (x *Command_AcceptAdmin) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[7]
+	mi := &file_soapbox_v1_room_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1091,7 +1204,7 @@ type Command_RemoveAdmin struct {
 func (x *Command_RemoveAdmin) Reset() {
 	*x = Command_RemoveAdmin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[8]
+		mi := &file_soapbox_v1_room_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1104,7 +1217,7 @@ func (x *Command_RemoveAdmin) String() string {
 func (*Command_RemoveAdmin) ProtoMessage() {}
 
 func (x *Command_RemoveAdmin) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[8]
+	mi := &file_soapbox_v1_room_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1138,7 +1251,7 @@ type Command_RenameRoom struct {
 func (x *Command_RenameRoom) Reset() {
 	*x = Command_RenameRoom{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[9]
+		mi := &file_soapbox_v1_room_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1151,7 +1264,7 @@ func (x *Command_RenameRoom) String() string {
 func (*Command_RenameRoom) ProtoMessage() {}
 
 func (x *Command_RenameRoom) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[9]
+	mi := &file_soapbox_v1_room_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1185,7 +1298,7 @@ type Command_InviteUser struct {
 func (x *Command_InviteUser) Reset() {
 	*x = Command_InviteUser{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[10]
+		mi := &file_soapbox_v1_room_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1198,7 +1311,7 @@ func (x *Command_InviteUser) String() string {
 func (*Command_InviteUser) ProtoMessage() {}
 
 func (x *Command_InviteUser) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[10]
+	mi := &file_soapbox_v1_room_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1232,7 +1345,7 @@ type Command_KickUser struct {
 func (x *Command_KickUser) Reset() {
 	*x = Command_KickUser{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[11]
+		mi := &file_soapbox_v1_room_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1245,7 +1358,7 @@ func (x *Command_KickUser) String() string {
 func (*Command_KickUser) ProtoMessage() {}
 
 func (x *Command_KickUser) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[11]
+	mi := &file_soapbox_v1_room_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1279,7 +1392,7 @@ type Command_MuteUser struct {
 func (x *Command_MuteUser) Reset() {
 	*x = Command_MuteUser{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[12]
+		mi := &file_soapbox_v1_room_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1292,7 +1405,7 @@ func (x *Command_MuteUser) String() string {
 func (*Command_MuteUser) ProtoMessage() {}
 
 func (x *Command_MuteUser) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[12]
+	mi := &file_soapbox_v1_room_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1324,7 +1437,7 @@ type Command_RecordScreen struct {
 func (x *Command_RecordScreen) Reset() {
 	*x = Command_RecordScreen{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[13]
+		mi := &file_soapbox_v1_room_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1337,7 +1450,7 @@ func (x *Command_RecordScreen) String() string {
 func (*Command_RecordScreen) ProtoMessage() {}
 
 func (x *Command_RecordScreen) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[13]
+	mi := &file_soapbox_v1_room_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1364,7 +1477,7 @@ type Command_VisibilityUpdate struct {
 func (x *Command_VisibilityUpdate) Reset() {
 	*x = Command_VisibilityUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[14]
+		mi := &file_soapbox_v1_room_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1377,7 +1490,7 @@ func (x *Command_VisibilityUpdate) String() string {
 func (*Command_VisibilityUpdate) ProtoMessage() {}
 
 func (x *Command_VisibilityUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[14]
+	mi := &file_soapbox_v1_room_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1411,7 +1524,7 @@ type Command_PinLink struct {
 func (x *Command_PinLink) Reset() {
 	*x = Command_PinLink{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[15]
+		mi := &file_soapbox_v1_room_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1424,7 +1537,7 @@ func (x *Command_PinLink) String() string {
 func (*Command_PinLink) ProtoMessage() {}
 
 func (x *Command_PinLink) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[15]
+	mi := &file_soapbox_v1_room_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1456,7 +1569,7 @@ type Command_UnpinLink struct {
 func (x *Command_UnpinLink) Reset() {
 	*x = Command_UnpinLink{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[16]
+		mi := &file_soapbox_v1_room_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1469,7 +1582,7 @@ func (x *Command_UnpinLink) String() string {
 func (*Command_UnpinLink) ProtoMessage() {}
 
 func (x *Command_UnpinLink) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[16]
+	mi := &file_soapbox_v1_room_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1497,7 +1610,7 @@ type Command_OpenMini struct {
 func (x *Command_OpenMini) Reset() {
 	*x = Command_OpenMini{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[17]
+		mi := &file_soapbox_v1_room_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1510,7 +1623,7 @@ func (x *Command_OpenMini) String() string {
 func (*Command_OpenMini) ProtoMessage() {}
 
 func (x *Command_OpenMini) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[17]
+	mi := &file_soapbox_v1_room_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1549,7 +1662,7 @@ type Command_CloseMini struct {
 func (x *Command_CloseMini) Reset() {
 	*x = Command_CloseMini{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[18]
+		mi := &file_soapbox_v1_room_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1562,7 +1675,7 @@ func (x *Command_CloseMini) String() string {
 func (*Command_CloseMini) ProtoMessage() {}
 
 func (x *Command_CloseMini) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[18]
+	mi := &file_soapbox_v1_room_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1589,7 +1702,7 @@ type Command_RequestMini struct {
 func (x *Command_RequestMini) Reset() {
 	*x = Command_RequestMini{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[19]
+		mi := &file_soapbox_v1_room_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1602,7 +1715,7 @@ func (x *Command_RequestMini) String() string {
 func (*Command_RequestMini) ProtoMessage() {}
 
 func (x *Command_RequestMini) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[19]
+	mi := &file_soapbox_v1_room_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1625,6 +1738,53 @@ func (x *Command_RequestMini) GetId() int64 {
 	return 0
 }
 
+type Command_UpdateMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *RoomMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *Command_UpdateMetadata) Reset() {
+	*x = Command_UpdateMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_soapbox_v1_room_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Command_UpdateMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command_UpdateMetadata) ProtoMessage() {}
+
+func (x *Command_UpdateMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_soapbox_v1_room_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command_UpdateMetadata.ProtoReflect.Descriptor instead.
+func (*Command_UpdateMetadata) Descriptor() ([]byte, []int) {
+	return file_soapbox_v1_room_proto_rawDescGZIP(), []int{0, 17}
+}
+
+func (x *Command_UpdateMetadata) GetMetadata() *RoomMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 type Event_Joined struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1636,7 +1796,7 @@ type Event_Joined struct {
 func (x *Event_Joined) Reset() {
 	*x = Event_Joined{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[20]
+		mi := &file_soapbox_v1_room_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1649,7 +1809,7 @@ func (x *Event_Joined) String() string {
 func (*Event_Joined) ProtoMessage() {}
 
 func (x *Event_Joined) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[20]
+	mi := &file_soapbox_v1_room_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1683,7 +1843,7 @@ type Event_Left struct {
 func (x *Event_Left) Reset() {
 	*x = Event_Left{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[21]
+		mi := &file_soapbox_v1_room_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1696,7 +1856,7 @@ func (x *Event_Left) String() string {
 func (*Event_Left) ProtoMessage() {}
 
 func (x *Event_Left) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[21]
+	mi := &file_soapbox_v1_room_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1730,7 +1890,7 @@ type Event_MuteUpdated struct {
 func (x *Event_MuteUpdated) Reset() {
 	*x = Event_MuteUpdated{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[22]
+		mi := &file_soapbox_v1_room_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1743,7 +1903,7 @@ func (x *Event_MuteUpdated) String() string {
 func (*Event_MuteUpdated) ProtoMessage() {}
 
 func (x *Event_MuteUpdated) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[22]
+	mi := &file_soapbox_v1_room_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1777,7 +1937,7 @@ type Event_Reacted struct {
 func (x *Event_Reacted) Reset() {
 	*x = Event_Reacted{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[23]
+		mi := &file_soapbox_v1_room_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1790,7 +1950,7 @@ func (x *Event_Reacted) String() string {
 func (*Event_Reacted) ProtoMessage() {}
 
 func (x *Event_Reacted) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[23]
+	mi := &file_soapbox_v1_room_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1824,7 +1984,7 @@ type Event_LinkShared struct {
 func (x *Event_LinkShared) Reset() {
 	*x = Event_LinkShared{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[24]
+		mi := &file_soapbox_v1_room_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1837,7 +1997,7 @@ func (x *Event_LinkShared) String() string {
 func (*Event_LinkShared) ProtoMessage() {}
 
 func (x *Event_LinkShared) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[24]
+	mi := &file_soapbox_v1_room_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1871,7 +2031,7 @@ type Event_InvitedAdmin struct {
 func (x *Event_InvitedAdmin) Reset() {
 	*x = Event_InvitedAdmin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[25]
+		mi := &file_soapbox_v1_room_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1884,7 +2044,7 @@ func (x *Event_InvitedAdmin) String() string {
 func (*Event_InvitedAdmin) ProtoMessage() {}
 
 func (x *Event_InvitedAdmin) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[25]
+	mi := &file_soapbox_v1_room_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1918,7 +2078,7 @@ type Event_AddedAdmin struct {
 func (x *Event_AddedAdmin) Reset() {
 	*x = Event_AddedAdmin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[26]
+		mi := &file_soapbox_v1_room_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1931,7 +2091,7 @@ func (x *Event_AddedAdmin) String() string {
 func (*Event_AddedAdmin) ProtoMessage() {}
 
 func (x *Event_AddedAdmin) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[26]
+	mi := &file_soapbox_v1_room_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1965,7 +2125,7 @@ type Event_RemovedAdmin struct {
 func (x *Event_RemovedAdmin) Reset() {
 	*x = Event_RemovedAdmin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[27]
+		mi := &file_soapbox_v1_room_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1978,7 +2138,7 @@ func (x *Event_RemovedAdmin) String() string {
 func (*Event_RemovedAdmin) ProtoMessage() {}
 
 func (x *Event_RemovedAdmin) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[27]
+	mi := &file_soapbox_v1_room_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2012,7 +2172,7 @@ type Event_RenamedRoom struct {
 func (x *Event_RenamedRoom) Reset() {
 	*x = Event_RenamedRoom{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[28]
+		mi := &file_soapbox_v1_room_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2025,7 +2185,7 @@ func (x *Event_RenamedRoom) String() string {
 func (*Event_RenamedRoom) ProtoMessage() {}
 
 func (x *Event_RenamedRoom) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[28]
+	mi := &file_soapbox_v1_room_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2059,7 +2219,7 @@ type Event_RecordedScreen struct {
 func (x *Event_RecordedScreen) Reset() {
 	*x = Event_RecordedScreen{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[29]
+		mi := &file_soapbox_v1_room_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2072,7 +2232,7 @@ func (x *Event_RecordedScreen) String() string {
 func (*Event_RecordedScreen) ProtoMessage() {}
 
 func (x *Event_RecordedScreen) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[29]
+	mi := &file_soapbox_v1_room_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2106,7 +2266,7 @@ type Event_MutedByAdmin struct {
 func (x *Event_MutedByAdmin) Reset() {
 	*x = Event_MutedByAdmin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[30]
+		mi := &file_soapbox_v1_room_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2119,7 +2279,7 @@ func (x *Event_MutedByAdmin) String() string {
 func (*Event_MutedByAdmin) ProtoMessage() {}
 
 func (x *Event_MutedByAdmin) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[30]
+	mi := &file_soapbox_v1_room_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2153,7 +2313,7 @@ type Event_VisibilityUpdated struct {
 func (x *Event_VisibilityUpdated) Reset() {
 	*x = Event_VisibilityUpdated{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[31]
+		mi := &file_soapbox_v1_room_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2166,7 +2326,7 @@ func (x *Event_VisibilityUpdated) String() string {
 func (*Event_VisibilityUpdated) ProtoMessage() {}
 
 func (x *Event_VisibilityUpdated) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[31]
+	mi := &file_soapbox_v1_room_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2200,7 +2360,7 @@ type Event_PinnedLink struct {
 func (x *Event_PinnedLink) Reset() {
 	*x = Event_PinnedLink{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[32]
+		mi := &file_soapbox_v1_room_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2213,7 +2373,7 @@ func (x *Event_PinnedLink) String() string {
 func (*Event_PinnedLink) ProtoMessage() {}
 
 func (x *Event_PinnedLink) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[32]
+	mi := &file_soapbox_v1_room_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2245,7 +2405,7 @@ type Event_UnpinnedLink struct {
 func (x *Event_UnpinnedLink) Reset() {
 	*x = Event_UnpinnedLink{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[33]
+		mi := &file_soapbox_v1_room_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2258,7 +2418,7 @@ func (x *Event_UnpinnedLink) String() string {
 func (*Event_UnpinnedLink) ProtoMessage() {}
 
 func (x *Event_UnpinnedLink) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[33]
+	mi := &file_soapbox_v1_room_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2287,7 +2447,7 @@ type Event_OpenedMini struct {
 func (x *Event_OpenedMini) Reset() {
 	*x = Event_OpenedMini{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[34]
+		mi := &file_soapbox_v1_room_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2300,7 +2460,7 @@ func (x *Event_OpenedMini) String() string {
 func (*Event_OpenedMini) ProtoMessage() {}
 
 func (x *Event_OpenedMini) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[34]
+	mi := &file_soapbox_v1_room_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2340,7 +2500,7 @@ type Event_ClosedMini struct {
 func (x *Event_ClosedMini) Reset() {
 	*x = Event_ClosedMini{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[35]
+		mi := &file_soapbox_v1_room_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2353,7 +2513,7 @@ func (x *Event_ClosedMini) String() string {
 func (*Event_ClosedMini) ProtoMessage() {}
 
 func (x *Event_ClosedMini) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[35]
+	mi := &file_soapbox_v1_room_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2380,7 +2540,7 @@ type Event_RequestedMini struct {
 func (x *Event_RequestedMini) Reset() {
 	*x = Event_RequestedMini{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[36]
+		mi := &file_soapbox_v1_room_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2393,7 +2553,7 @@ func (x *Event_RequestedMini) String() string {
 func (*Event_RequestedMini) ProtoMessage() {}
 
 func (x *Event_RequestedMini) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[36]
+	mi := &file_soapbox_v1_room_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2416,6 +2576,91 @@ func (x *Event_RequestedMini) GetMini() *RoomState_Mini {
 	return nil
 }
 
+type Event_ServerRestarting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Event_ServerRestarting) Reset() {
+	*x = Event_ServerRestarting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_soapbox_v1_room_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event_ServerRestarting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_ServerRestarting) ProtoMessage() {}
+
+func (x *Event_ServerRestarting) ProtoReflect() protoreflect.Message {
+	mi := &file_soapbox_v1_room_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_ServerRestarting.ProtoReflect.Descriptor instead.
+func (*Event_ServerRestarting) Descriptor() ([]byte, []int) {
+	return file_soapbox_v1_room_proto_rawDescGZIP(), []int{1, 17}
+}
+
+type Event_UpdatedMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *RoomMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *Event_UpdatedMetadata) Reset() {
+	*x = Event_UpdatedMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_soapbox_v1_room_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event_UpdatedMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_UpdatedMetadata) ProtoMessage() {}
+
+func (x *Event_UpdatedMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_soapbox_v1_room_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_UpdatedMetadata.ProtoReflect.Descriptor instead.
+func (*Event_UpdatedMetadata) Descriptor() ([]byte, []int) {
+	return file_soapbox_v1_room_proto_rawDescGZIP(), []int{1, 18}
+}
+
+func (x *Event_UpdatedMetadata) GetMetadata() *RoomMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 type RoomState_RoomMember struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2433,7 +2678,7 @@ type RoomState_RoomMember struct {
 func (x *RoomState_RoomMember) Reset() {
 	*x = RoomState_RoomMember{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[37]
+		mi := &file_soapbox_v1_room_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2446,7 +2691,7 @@ func (x *RoomState_RoomMember) String() string {
 func (*RoomState_RoomMember) ProtoMessage() {}
 
 func (x *RoomState_RoomMember) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[37]
+	mi := &file_soapbox_v1_room_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2525,7 +2770,7 @@ type RoomState_Mini struct {
 func (x *RoomState_Mini) Reset() {
 	*x = RoomState_Mini{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_soapbox_v1_room_proto_msgTypes[38]
+		mi := &file_soapbox_v1_room_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2538,7 +2783,7 @@ func (x *RoomState_Mini) String() string {
 func (*RoomState_Mini) ProtoMessage() {}
 
 func (x *RoomState_Mini) ProtoReflect() protoreflect.Message {
-	mi := &file_soapbox_v1_room_proto_msgTypes[38]
+	mi := &file_soapbox_v1_room_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2587,7 +2832,7 @@ var File_soapbox_v1_room_proto protoreflect.FileDescriptor
 var file_soapbox_v1_room_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x6f,
 	0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78,
-	0x2e, 0x76, 0x31, 0x22, 0x90, 0x0d, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
+	0x2e, 0x76, 0x31, 0x22, 0xa7, 0x0e, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
 	0x41, 0x0a, 0x0b, 0x6d, 0x75, 0x74, 0x65, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76,
 	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x75, 0x74, 0x65, 0x55, 0x70,
@@ -2658,207 +2903,243 @@ var file_soapbox_v1_room_proto_rawDesc = []byte{
 	0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x6f,
 	0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
 	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x69, 0x6e, 0x69, 0x48, 0x00, 0x52, 0x0b,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x69, 0x6e, 0x69, 0x1a, 0x22, 0x0a, 0x0a, 0x4d,
-	0x75, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75, 0x74,
-	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x1a,
-	0x20, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x4d, 0x0a, 0x0f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0e, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x22, 0x0a, 0x0a, 0x4d, 0x75,
+	0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75, 0x74, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x1a, 0x20,
+	0x0a, 0x08, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d,
+	0x6f, 0x6a, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69,
+	0x1a, 0x1f, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e,
+	0x6b, 0x1a, 0x1d, 0x0a, 0x0b, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x1a, 0x0d, 0x0a, 0x0b, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x1a,
+	0x1d, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x20,
+	0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x1a, 0x1c, 0x0a, 0x0a, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x1a,
+	0x0a, 0x08, 0x4b, 0x69, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x1a, 0x0a, 0x08, 0x4d, 0x75,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x0e, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x1a, 0x4a, 0x0a, 0x10, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x0a, 0x76, 0x69,
+	0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
+	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x73, 0x69,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x1a, 0x1d, 0x0a, 0x07, 0x50, 0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x12, 0x0a,
+	0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e,
+	0x6b, 0x1a, 0x0b, 0x0a, 0x09, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x6b, 0x1a, 0x2e,
+	0x0a, 0x08, 0x4f, 0x70, 0x65, 0x6e, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x69,
+	0x6e, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x0b,
+	0x0a, 0x09, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x4d, 0x69, 0x6e, 0x69, 0x1a, 0x1d, 0x0a, 0x0b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x46, 0x0a, 0x0e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x34, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xc2, 0x10,
+	0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x32, 0x0a, 0x06, 0x6a,
+	0x6f, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x6f,
+	0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4a,
+	0x6f, 0x69, 0x6e, 0x65, 0x64, 0x48, 0x00, 0x52, 0x06, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x12,
+	0x2c, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x4c, 0x65, 0x66, 0x74, 0x48, 0x00, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x42, 0x0a,
+	0x0c, 0x6d, 0x75, 0x74, 0x65, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x75, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x75, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52,
+	0x07, 0x72, 0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x12, 0x3f, 0x0a, 0x0b, 0x6c, 0x69, 0x6e, 0x6b,
+	0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x6c,
+	0x69, 0x6e, 0x6b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x12, 0x45, 0x0a, 0x0d, 0x69, 0x6e, 0x76,
+	0x69, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x65, 0x64, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x12, 0x45, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62,
+	0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x42, 0x0a, 0x0c, 0x72, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x48, 0x00, 0x52,
+	0x0b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x4b, 0x0a, 0x0f,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65,
+	0x64, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x65, 0x64, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x12, 0x46, 0x0a, 0x0e, 0x6d, 0x75, 0x74,
+	0x65, 0x64, 0x5f, 0x62, 0x79, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x75, 0x74, 0x65, 0x64, 0x42, 0x79, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x42, 0x79, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x12, 0x54, 0x0a, 0x12, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x48, 0x00, 0x52, 0x11, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x3f, 0x0a, 0x0b, 0x70, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73,
+	0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e,
+	0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x69,
+	0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x45, 0x0a, 0x0d, 0x75, 0x6e, 0x70, 0x69,
+	0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x2e, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x48,
+	0x00, 0x52, 0x0c, 0x75, 0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x12,
+	0x3f, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x4d, 0x69,
+	0x6e, 0x69, 0x48, 0x00, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69,
+	0x12, 0x3f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4d,
+	0x69, 0x6e, 0x69, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4d, 0x69, 0x6e,
+	0x69, 0x12, 0x48, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x6d,
+	0x69, 0x6e, 0x69, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x6f, 0x61, 0x70,
+	0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x51, 0x0a, 0x11, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x10, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x4e,
+	0x0a, 0x10, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62,
+	0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x0f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3e,
+	0x0a, 0x06, 0x4a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x1a, 0x16,
+	0x0a, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x28, 0x0a, 0x0b, 0x4d, 0x75, 0x74, 0x65, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x6d, 0x75, 0x74, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x4d, 0x75, 0x74, 0x65, 0x64,
+	0x1a, 0x1f, 0x0a, 0x07, 0x52, 0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65,
 	0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a,
-	0x69, 0x1a, 0x1f, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69,
-	0x6e, 0x6b, 0x1a, 0x1d, 0x0a, 0x0b, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x41, 0x64, 0x6d, 0x69,
+	0x69, 0x1a, 0x20, 0x0a, 0x0a, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c,
+	0x69, 0x6e, 0x6b, 0x1a, 0x1e, 0x0a, 0x0c, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x64, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x1a, 0x1c, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x1a, 0x1e, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69,
 	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
-	0x64, 0x1a, 0x0d, 0x0a, 0x0b, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x41, 0x64, 0x6d, 0x69, 0x6e,
-	0x1a, 0x1d, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a,
-	0x20, 0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x1a, 0x1c, 0x0a, 0x0a, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a,
-	0x1a, 0x0a, 0x08, 0x4b, 0x69, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x1a, 0x0a, 0x08, 0x4d,
-	0x75, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x0e, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x1a, 0x4a, 0x0a, 0x10, 0x56, 0x69, 0x73, 0x69, 0x62,
-	0x69, 0x6c, 0x69, 0x74, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x0a, 0x76,
+	0x64, 0x1a, 0x21, 0x0a, 0x0b, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x20, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64,
+	0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x1e, 0x0a, 0x0c, 0x4d, 0x75, 0x74, 0x65, 0x64, 0x42,
+	0x79, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x4b, 0x0a, 0x11, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x0a, 0x76,
 	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
 	0x16, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x73,
 	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
-	0x69, 0x74, 0x79, 0x1a, 0x1d, 0x0a, 0x07, 0x50, 0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x12,
-	0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69,
-	0x6e, 0x6b, 0x1a, 0x0b, 0x0a, 0x09, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x6b, 0x1a,
-	0x2e, 0x0a, 0x08, 0x4f, 0x70, 0x65, 0x6e, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x6d,
-	0x69, 0x6e, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a,
-	0x0b, 0x0a, 0x09, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x4d, 0x69, 0x6e, 0x69, 0x1a, 0x1d, 0x0a, 0x0b,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x70,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xc2, 0x0e, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
-	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x32, 0x0a, 0x06, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76,
-	0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x48, 0x00,
-	0x52, 0x06, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78,
-	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x65, 0x66, 0x74, 0x48, 0x00,
-	0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x42, 0x0a, 0x0c, 0x6d, 0x75, 0x74, 0x65, 0x5f, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73,
-	0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e,
-	0x4d, 0x75, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x6d,
-	0x75, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65,
-	0x61, 0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x6f,
-	0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52,
-	0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65, 0x61, 0x63, 0x74, 0x65,
-	0x64, 0x12, 0x3f, 0x0a, 0x0b, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78,
-	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x6c, 0x69, 0x6e, 0x6b, 0x53, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x12, 0x45, 0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x64,
-	0x6d, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6f, 0x61, 0x70,
-	0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x49, 0x6e, 0x76,
-	0x69, 0x74, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e, 0x76,
-	0x69, 0x74, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x3f, 0x0a, 0x0b, 0x61, 0x64, 0x64,
-	0x65, 0x64, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
-	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x2e, 0x41, 0x64, 0x64, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x0a,
-	0x61, 0x64, 0x64, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x45, 0x0a, 0x0d, 0x72, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69,
-	0x6e, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69,
-	0x6e, 0x12, 0x42, 0x0a, 0x0c, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6f,
-	0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f,
-	0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65,
-	0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x4b, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65,
-	0x64, 0x5f, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
-	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e,
-	0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x53, 0x63, 0x72, 0x65,
-	0x65, 0x6e, 0x12, 0x46, 0x0a, 0x0e, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x5f, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6f, 0x61,
-	0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x75,
-	0x74, 0x65, 0x64, 0x42, 0x79, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x75,
-	0x74, 0x65, 0x64, 0x42, 0x79, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x54, 0x0a, 0x12, 0x76, 0x69,
-	0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
-	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78,
-	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69,
-	0x6c, 0x69, 0x74, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x11, 0x76,
-	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
-	0x12, 0x3f, 0x0a, 0x0b, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18,
-	0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e,
-	0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4c,
-	0x69, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e,
-	0x6b, 0x12, 0x45, 0x0a, 0x0d, 0x75, 0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x6c, 0x69,
-	0x6e, 0x6b, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62,
-	0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x6e, 0x70, 0x69,
-	0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x0c, 0x75, 0x6e, 0x70, 0x69,
-	0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x3f, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x6e,
-	0x65, 0x64, 0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
-	0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x48, 0x00, 0x52, 0x0a, 0x6f,
-	0x70, 0x65, 0x6e, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x3f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f,
-	0x73, 0x65, 0x64, 0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
-	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x48, 0x00, 0x52, 0x0a,
-	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x48, 0x0a, 0x0e, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x69, 0x6e, 0x69, 0x18, 0x12, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d,
-	0x69, 0x6e, 0x69, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64,
-	0x4d, 0x69, 0x6e, 0x69, 0x1a, 0x3e, 0x0a, 0x06, 0x4a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x34,
-	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73,
-	0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x04,
-	0x75, 0x73, 0x65, 0x72, 0x1a, 0x16, 0x0a, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x28, 0x0a, 0x0b,
-	0x4d, 0x75, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69,
-	0x73, 0x5f, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69,
-	0x73, 0x4d, 0x75, 0x74, 0x65, 0x64, 0x1a, 0x1f, 0x0a, 0x07, 0x52, 0x65, 0x61, 0x63, 0x74, 0x65,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x1a, 0x20, 0x0a, 0x0a, 0x4c, 0x69, 0x6e, 0x6b, 0x53,
-	0x68, 0x61, 0x72, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x1a, 0x1e, 0x0a, 0x0c, 0x49, 0x6e, 0x76,
-	0x69, 0x74, 0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x1c, 0x0a, 0x0a, 0x41, 0x64, 0x64,
-	0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x1e, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x21, 0x0a, 0x0b, 0x52, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x64, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x20, 0x0a, 0x0e, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x1e, 0x0a, 0x0c,
-	0x4d, 0x75, 0x74, 0x65, 0x64, 0x42, 0x79, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x1a, 0x4b, 0x0a, 0x11,
-	0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x64, 0x12, 0x36, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e,
-	0x76, 0x31, 0x2e, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x76,
-	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x20, 0x0a, 0x0a, 0x50, 0x69, 0x6e,
-	0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x1a, 0x0e, 0x0a, 0x0c, 0x55,
-	0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x1a, 0x54, 0x0a, 0x0a, 0x4f,
-	0x70, 0x65, 0x6e, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x16, 0x0a, 0x04, 0x73, 0x6c, 0x75,
-	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x04, 0x73, 0x6c, 0x75,
-	0x67, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f,
-	0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x52, 0x04, 0x6d, 0x69, 0x6e,
-	0x69, 0x1a, 0x0c, 0x0a, 0x0a, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x1a,
-	0x3f, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69,
-	0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x69,
-	0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xcd, 0x05, 0x0a, 0x09,
-	0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a,
-	0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
-	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x36, 0x0a,
-	0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x16, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x56,
-	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62,
-	0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x1d, 0x0a, 0x08, 0x6d, 0x69, 0x6e,
-	0x69, 0x5f, 0x6f, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52,
-	0x07, 0x6d, 0x69, 0x6e, 0x69, 0x4f, 0x6c, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x69, 0x6e, 0x69,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x69,
-	0x6e, 0x69, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x1a, 0x80, 0x02, 0x0a, 0x0a, 0x52, 0x6f, 0x6f,
-	0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c,
-	0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
-	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
-	0x12, 0x39, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25,
-	0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d,
-	0x75, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75, 0x74, 0x65,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x72, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x04, 0x73, 0x73, 0x72, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0x28, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x4f, 0x4c,
-	0x45, 0x5f, 0x52, 0x45, 0x47, 0x55, 0x4c, 0x41, 0x52, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x52,
-	0x4f, 0x4c, 0x45, 0x5f, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x10, 0x01, 0x1a, 0xad, 0x01, 0x0a, 0x04,
-	0x4d, 0x69, 0x6e, 0x69, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x33, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x69,
-	0x6e, 0x69, 0x2e, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0x38, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x49, 0x5a,
-	0x45, 0x5f, 0x53, 0x4d, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x49, 0x5a,
-	0x45, 0x5f, 0x52, 0x45, 0x47, 0x55, 0x4c, 0x41, 0x52, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x53,
-	0x49, 0x5a, 0x45, 0x5f, 0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x02, 0x2a, 0x3b, 0x0a, 0x0a, 0x56,
-	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x49, 0x53,
-	0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x10, 0x00,
-	0x12, 0x16, 0x0a, 0x12, 0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x50,
-	0x52, 0x49, 0x56, 0x41, 0x54, 0x45, 0x10, 0x01, 0x42, 0x0e, 0x5a, 0x0c, 0x70, 0x6b, 0x67, 0x2f,
-	0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x74, 0x79, 0x1a, 0x20, 0x0a, 0x0a, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4c, 0x69, 0x6e,
+	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x1a, 0x0e, 0x0a, 0x0c, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x1a, 0x54, 0x0a, 0x0a, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x4d,
+	0x69, 0x6e, 0x69, 0x12, 0x16, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x2e, 0x0a, 0x04, 0x6d,
+	0x69, 0x6e, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x6f, 0x61, 0x70,
+	0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x1a, 0x0c, 0x0a, 0x0a, 0x43,
+	0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x1a, 0x3f, 0x0a, 0x0d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x69, 0x6e, 0x69, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x69,
+	0x6e, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62,
+	0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e,
+	0x4d, 0x69, 0x6e, 0x69, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x1a, 0x12, 0x0a, 0x10, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x1a, 0x47,
+	0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x34, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x22, 0x83, 0x06, 0x0a, 0x09, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x6f, 0x6f,
+	0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x6f, 0x6c, 0x65, 0x12, 0x36, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62,
+	0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b,
+	0x12, 0x1d, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x69, 0x5f, 0x6f, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x69, 0x4f, 0x6c, 0x64, 0x12,
+	0x2e, 0x0a, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x69, 0x12,
+	0x34, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x6f, 0x6f, 0x6d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x80, 0x02, 0x0a, 0x0a, 0x52, 0x6f, 0x6f, 0x6d, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a,
+	0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x73, 0x6f,
+	0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x52, 0x6f,
+	0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75, 0x74, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x73, 0x72, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x73,
+	0x72, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x28,
+	0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x52,
+	0x45, 0x47, 0x55, 0x4c, 0x41, 0x52, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x4f, 0x4c, 0x45,
+	0x5f, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x10, 0x01, 0x1a, 0xad, 0x01, 0x0a, 0x04, 0x4d, 0x69, 0x6e,
+	0x69, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x33, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x73, 0x6f, 0x61, 0x70, 0x62, 0x6f, 0x78, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x2e,
+	0x53, 0x69, 0x7a, 0x65, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x38,
+	0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x53,
+	0x4d, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x52,
+	0x45, 0x47, 0x55, 0x4c, 0x41, 0x52, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x49, 0x5a, 0x45,
+	0x5f, 0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x02, 0x22, 0x64, 0x0a, 0x0c, 0x52, 0x6f, 0x6f, 0x6d,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x2a, 0x3b,
+	0x0a, 0x0a, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x15, 0x0a, 0x11,
+	0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49,
+	0x43, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x56, 0x49, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54,
+	0x59, 0x5f, 0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45, 0x10, 0x01, 0x42, 0x0e, 0x5a, 0x0c, 0x70,
+	0x6b, 0x67, 0x2f, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2874,7 +3155,7 @@ func file_soapbox_v1_room_proto_rawDescGZIP() []byte {
 }
 
 var file_soapbox_v1_room_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_soapbox_v1_room_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_soapbox_v1_room_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_soapbox_v1_room_proto_goTypes = []interface{}{
 	(Visibility)(0),                  // 0: soapbox.v1.Visibility
 	(RoomState_RoomMember_Role)(0),   // 1: soapbox.v1.RoomState.RoomMember.Role
@@ -2882,93 +3163,103 @@ var file_soapbox_v1_room_proto_goTypes = []interface{}{
 	(*Command)(nil),                  // 3: soapbox.v1.Command
 	(*Event)(nil),                    // 4: soapbox.v1.Event
 	(*RoomState)(nil),                // 5: soapbox.v1.RoomState
-	(*Command_MuteUpdate)(nil),       // 6: soapbox.v1.Command.MuteUpdate
-	(*Command_Reaction)(nil),         // 7: soapbox.v1.Command.Reaction
-	(*Command_LinkShare)(nil),        // 8: soapbox.v1.Command.LinkShare
-	(*Command_InviteAdmin)(nil),      // 9: soapbox.v1.Command.InviteAdmin
-	(*Command_AcceptAdmin)(nil),      // 10: soapbox.v1.Command.AcceptAdmin
-	(*Command_RemoveAdmin)(nil),      // 11: soapbox.v1.Command.RemoveAdmin
-	(*Command_RenameRoom)(nil),       // 12: soapbox.v1.Command.RenameRoom
-	(*Command_InviteUser)(nil),       // 13: soapbox.v1.Command.InviteUser
-	(*Command_KickUser)(nil),         // 14: soapbox.v1.Command.KickUser
-	(*Command_MuteUser)(nil),         // 15: soapbox.v1.Command.MuteUser
-	(*Command_RecordScreen)(nil),     // 16: soapbox.v1.Command.RecordScreen
-	(*Command_VisibilityUpdate)(nil), // 17: soapbox.v1.Command.VisibilityUpdate
-	(*Command_PinLink)(nil),          // 18: soapbox.v1.Command.PinLink
-	(*Command_UnpinLink)(nil),        // 19: soapbox.v1.Command.UnpinLink
-	(*Command_OpenMini)(nil),         // 20: soapbox.v1.Command.OpenMini
-	(*Command_CloseMini)(nil),        // 21: soapbox.v1.Command.CloseMini
-	(*Command_RequestMini)(nil),      // 22: soapbox.v1.Command.RequestMini
-	(*Event_Joined)(nil),             // 23: soapbox.v1.Event.Joined
-	(*Event_Left)(nil),               // 24: soapbox.v1.Event.Left
-	(*Event_MuteUpdated)(nil),        // 25: soapbox.v1.Event.MuteUpdated
-	(*Event_Reacted)(nil),            // 26: soapbox.v1.Event.Reacted
-	(*Event_LinkShared)(nil),         // 27: soapbox.v1.Event.LinkShared
-	(*Event_InvitedAdmin)(nil),       // 28: soapbox.v1.Event.InvitedAdmin
-	(*Event_AddedAdmin)(nil),         // 29: soapbox.v1.Event.AddedAdmin
-	(*Event_RemovedAdmin)(nil),       // 30: soapbox.v1.Event.RemovedAdmin
-	(*Event_RenamedRoom)(nil),        // 31: soapbox.v1.Event.RenamedRoom
-	(*Event_RecordedScreen)(nil),     // 32: soapbox.v1.Event.RecordedScreen
-	(*Event_MutedByAdmin)(nil),       // 33: soapbox.v1.Event.MutedByAdmin
-	(*Event_VisibilityUpdated)(nil),  // 34: soapbox.v1.Event.VisibilityUpdated
-	(*Event_PinnedLink)(nil),         // 35: soapbox.v1.Event.PinnedLink
-	(*Event_UnpinnedLink)(nil),       // 36: soapbox.v1.Event.UnpinnedLink
-	(*Event_OpenedMini)(nil),         // 37: soapbox.v1.Event.OpenedMini
-	(*Event_ClosedMini)(nil),         // 38: soapbox.v1.Event.ClosedMini
-	(*Event_RequestedMini)(nil),      // 39: soapbox.v1.Event.RequestedMini
-	(*RoomState_RoomMember)(nil),     // 40: soapbox.v1.RoomState.RoomMember
-	(*RoomState_Mini)(nil),           // 41: soapbox.v1.RoomState.Mini
+	(*RoomMetadata)(nil),             // 6: soapbox.v1.RoomMetadata
+	(*Command_MuteUpdate)(nil),       // 7: soapbox.v1.Command.MuteUpdate
+	(*Command_Reaction)(nil),         // 8: soapbox.v1.Command.Reaction
+	(*Command_LinkShare)(nil),        // 9: soapbox.v1.Command.LinkShare
+	(*Command_InviteAdmin)(nil),      // 10: soapbox.v1.Command.InviteAdmin
+	(*Command_AcceptAdmin)(nil),      // 11: soapbox.v1.Command.AcceptAdmin
+	(*Command_RemoveAdmin)(nil),      // 12: soapbox.v1.Command.RemoveAdmin
+	(*Command_RenameRoom)(nil),       // 13: soapbox.v1.Command.RenameRoom
+	(*Command_InviteUser)(nil),       // 14: soapbox.v1.Command.InviteUser
+	(*Command_KickUser)(nil),         // 15: soapbox.v1.Command.KickUser
+	(*Command_MuteUser)(nil),         // 16: soapbox.v1.Command.MuteUser
+	(*Command_RecordScreen)(nil),     // 17: soapbox.v1.Command.RecordScreen
+	(*Command_VisibilityUpdate)(nil), // 18: soapbox.v1.Command.VisibilityUpdate
+	(*Command_PinLink)(nil),          // 19: soapbox.v1.Command.PinLink
+	(*Command_UnpinLink)(nil),        // 20: soapbox.v1.Command.UnpinLink
+	(*Command_OpenMini)(nil),         // 21: soapbox.v1.Command.OpenMini
+	(*Command_CloseMini)(nil),        // 22: soapbox.v1.Command.CloseMini
+	(*Command_RequestMini)(nil),      // 23: soapbox.v1.Command.RequestMini
+	(*Command_UpdateMetadata)(nil),   // 24: soapbox.v1.Command.UpdateMetadata
+	(*Event_Joined)(nil),             // 25: soapbox.v1.Event.Joined
+	(*Event_Left)(nil),               // 26: soapbox.v1.Event.Left
+	(*Event_MuteUpdated)(nil),        // 27: soapbox.v1.Event.MuteUpdated
+	(*Event_Reacted)(nil),            // 28: soapbox.v1.Event.Reacted
+	(*Event_LinkShared)(nil),         // 29: soapbox.v1.Event.LinkShared
+	(*Event_InvitedAdmin)(nil),       // 30: soapbox.v1.Event.InvitedAdmin
+	(*Event_AddedAdmin)(nil),         // 31: soapbox.v1.Event.AddedAdmin
+	(*Event_RemovedAdmin)(nil),       // 32: soapbox.v1.Event.RemovedAdmin
+	(*Event_RenamedRoom)(nil),        // 33: soapbox.v1.Event.RenamedRoom
+	(*Event_RecordedScreen)(nil),     // 34: soapbox.v1.Event.RecordedScreen
+	(*Event_MutedByAdmin)(nil),       // 35: soapbox.v1.Event.MutedByAdmin
+	(*Event_VisibilityUpdated)(nil),  // 36: soapbox.v1.Event.VisibilityUpdated
+	(*Event_PinnedLink)(nil),         // 37: soapbox.v1.Event.PinnedLink
+	(*Event_UnpinnedLink)(nil),       // 38: soapbox.v1.Event.UnpinnedLink
+	(*Event_OpenedMini)(nil),         // 39: soapbox.v1.Event.OpenedMini
+	(*Event_ClosedMini)(nil),         // 40: soapbox.v1.Event.ClosedMini
+	(*Event_RequestedMini)(nil),      // 41: soapbox.v1.Event.RequestedMini
+	(*Event_ServerRestarting)(nil),   // 42: soapbox.v1.Event.ServerRestarting
+	(*Event_UpdatedMetadata)(nil),    // 43: soapbox.v1.Event.UpdatedMetadata
+	(*RoomState_RoomMember)(nil),     // 44: soapbox.v1.RoomState.RoomMember
+	(*RoomState_Mini)(nil),           // 45: soapbox.v1.RoomState.Mini
 }
 var file_soapbox_v1_room_proto_depIdxs = []int32{
-	6,  // 0: soapbox.v1.Command.mute_update:type_name -> soapbox.v1.Command.MuteUpdate
-	7,  // 1: soapbox.v1.Command.reaction:type_name -> soapbox.v1.Command.Reaction
-	8,  // 2: soapbox.v1.Command.link_share:type_name -> soapbox.v1.Command.LinkShare
-	9,  // 3: soapbox.v1.Command.invite_admin:type_name -> soapbox.v1.Command.InviteAdmin
-	10, // 4: soapbox.v1.Command.accept_admin:type_name -> soapbox.v1.Command.AcceptAdmin
-	11, // 5: soapbox.v1.Command.remove_admin:type_name -> soapbox.v1.Command.RemoveAdmin
-	12, // 6: soapbox.v1.Command.rename_room:type_name -> soapbox.v1.Command.RenameRoom
-	13, // 7: soapbox.v1.Command.invite_user:type_name -> soapbox.v1.Command.InviteUser
-	14, // 8: soapbox.v1.Command.kick_user:type_name -> soapbox.v1.Command.KickUser
-	15, // 9: soapbox.v1.Command.mute_user:type_name -> soapbox.v1.Command.MuteUser
-	16, // 10: soapbox.v1.Command.record_screen:type_name -> soapbox.v1.Command.RecordScreen
-	17, // 11: soapbox.v1.Command.visibility_update:type_name -> soapbox.v1.Command.VisibilityUpdate
-	18, // 12: soapbox.v1.Command.pin_link:type_name -> soapbox.v1.Command.PinLink
-	19, // 13: soapbox.v1.Command.unpin_link:type_name -> soapbox.v1.Command.UnpinLink
-	20, // 14: soapbox.v1.Command.open_mini:type_name -> soapbox.v1.Command.OpenMini
-	21, // 15: soapbox.v1.Command.close_mini:type_name -> soapbox.v1.Command.CloseMini
-	22, // 16: soapbox.v1.Command.request_mini:type_name -> soapbox.v1.Command.RequestMini
-	23, // 17: soapbox.v1.Event.joined:type_name -> soapbox.v1.Event.Joined
-	24, // 18: soapbox.v1.Event.left:type_name -> soapbox.v1.Event.Left
-	25, // 19: soapbox.v1.Event.mute_updated:type_name -> soapbox.v1.Event.MuteUpdated
-	26, // 20: soapbox.v1.Event.reacted:type_name -> soapbox.v1.Event.Reacted
-	27, // 21: soapbox.v1.Event.link_shared:type_name -> soapbox.v1.Event.LinkShared
-	28, // 22: soapbox.v1.Event.invited_admin:type_name -> soapbox.v1.Event.InvitedAdmin
-	29, // 23: soapbox.v1.Event.added_admin:type_name -> soapbox.v1.Event.AddedAdmin
-	30, // 24: soapbox.v1.Event.removed_admin:type_name -> soapbox.v1.Event.RemovedAdmin
-	31, // 25: soapbox.v1.Event.renamed_room:type_name -> soapbox.v1.Event.RenamedRoom
-	32, // 26: soapbox.v1.Event.recorded_screen:type_name -> soapbox.v1.Event.RecordedScreen
-	33, // 27: soapbox.v1.Event.muted_by_admin:type_name -> soapbox.v1.Event.MutedByAdmin
-	34, // 28: soapbox.v1.Event.visibility_updated:type_name -> soapbox.v1.Event.VisibilityUpdated
-	35, // 29: soapbox.v1.Event.pinned_link:type_name -> soapbox.v1.Event.PinnedLink
-	36, // 30: soapbox.v1.Event.unpinned_link:type_name -> soapbox.v1.Event.UnpinnedLink
-	37, // 31: soapbox.v1.Event.opened_mini:type_name -> soapbox.v1.Event.OpenedMini
-	38, // 32: soapbox.v1.Event.closed_mini:type_name -> soapbox.v1.Event.ClosedMini
-	39, // 33: soapbox.v1.Event.requested_mini:type_name -> soapbox.v1.Event.RequestedMini
-	40, // 34: soapbox.v1.RoomState.members:type_name -> soapbox.v1.RoomState.RoomMember
-	0,  // 35: soapbox.v1.RoomState.visibility:type_name -> soapbox.v1.Visibility
-	41, // 36: soapbox.v1.RoomState.mini:type_name -> soapbox.v1.RoomState.Mini
-	0,  // 37: soapbox.v1.Command.VisibilityUpdate.visibility:type_name -> soapbox.v1.Visibility
-	40, // 38: soapbox.v1.Event.Joined.user:type_name -> soapbox.v1.RoomState.RoomMember
-	0,  // 39: soapbox.v1.Event.VisibilityUpdated.visibility:type_name -> soapbox.v1.Visibility
-	41, // 40: soapbox.v1.Event.OpenedMini.mini:type_name -> soapbox.v1.RoomState.Mini
-	41, // 41: soapbox.v1.Event.RequestedMini.mini:type_name -> soapbox.v1.RoomState.Mini
-	1,  // 42: soapbox.v1.RoomState.RoomMember.role:type_name -> soapbox.v1.RoomState.RoomMember.Role
-	2,  // 43: soapbox.v1.RoomState.Mini.size:type_name -> soapbox.v1.RoomState.Mini.Size
-	44, // [44:44] is the sub-list for method output_type
-	44, // [44:44] is the sub-list for method input_type
-	44, // [44:44] is the sub-list for extension type_name
-	44, // [44:44] is the sub-list for extension extendee
-	0,  // [0:44] is the sub-list for field type_name
+	7,  // 0: soapbox.v1.Command.mute_update:type_name -> soapbox.v1.Command.MuteUpdate
+	8,  // 1: soapbox.v1.Command.reaction:type_name -> soapbox.v1.Command.Reaction
+	9,  // 2: soapbox.v1.Command.link_share:type_name -> soapbox.v1.Command.LinkShare
+	10, // 3: soapbox.v1.Command.invite_admin:type_name -> soapbox.v1.Command.InviteAdmin
+	11, // 4: soapbox.v1.Command.accept_admin:type_name -> soapbox.v1.Command.AcceptAdmin
+	12, // 5: soapbox.v1.Command.remove_admin:type_name -> soapbox.v1.Command.RemoveAdmin
+	13, // 6: soapbox.v1.Command.rename_room:type_name -> soapbox.v1.Command.RenameRoom
+	14, // 7: soapbox.v1.Command.invite_user:type_name -> soapbox.v1.Command.InviteUser
+	15, // 8: soapbox.v1.Command.kick_user:type_name -> soapbox.v1.Command.KickUser
+	16, // 9: soapbox.v1.Command.mute_user:type_name -> soapbox.v1.Command.MuteUser
+	17, // 10: soapbox.v1.Command.record_screen:type_name -> soapbox.v1.Command.RecordScreen
+	18, // 11: soapbox.v1.Command.visibility_update:type_name -> soapbox.v1.Command.VisibilityUpdate
+	19, // 12: soapbox.v1.Command.pin_link:type_name -> soapbox.v1.Command.PinLink
+	20, // 13: soapbox.v1.Command.unpin_link:type_name -> soapbox.v1.Command.UnpinLink
+	21, // 14: soapbox.v1.Command.open_mini:type_name -> soapbox.v1.Command.OpenMini
+	22, // 15: soapbox.v1.Command.close_mini:type_name -> soapbox.v1.Command.CloseMini
+	23, // 16: soapbox.v1.Command.request_mini:type_name -> soapbox.v1.Command.RequestMini
+	24, // 17: soapbox.v1.Command.update_metadata:type_name -> soapbox.v1.Command.UpdateMetadata
+	25, // 18: soapbox.v1.Event.joined:type_name -> soapbox.v1.Event.Joined
+	26, // 19: soapbox.v1.Event.left:type_name -> soapbox.v1.Event.Left
+	27, // 20: soapbox.v1.Event.mute_updated:type_name -> soapbox.v1.Event.MuteUpdated
+	28, // 21: soapbox.v1.Event.reacted:type_name -> soapbox.v1.Event.Reacted
+	29, // 22: soapbox.v1.Event.link_shared:type_name -> soapbox.v1.Event.LinkShared
+	30, // 23: soapbox.v1.Event.invited_admin:type_name -> soapbox.v1.Event.InvitedAdmin
+	31, // 24: soapbox.v1.Event.added_admin:type_name -> soapbox.v1.Event.AddedAdmin
+	32, // 25: soapbox.v1.Event.removed_admin:type_name -> soapbox.v1.Event.RemovedAdmin
+	33, // 26: soapbox.v1.Event.renamed_room:type_name -> soapbox.v1.Event.RenamedRoom
+	34, // 27: soapbox.v1.Event.recorded_screen:type_name -> soapbox.v1.Event.RecordedScreen
+	35, // 28: soapbox.v1.Event.muted_by_admin:type_name -> soapbox.v1.Event.MutedByAdmin
+	36, // 29: soapbox.v1.Event.visibility_updated:type_name -> soapbox.v1.Event.VisibilityUpdated
+	37, // 30: soapbox.v1.Event.pinned_link:type_name -> soapbox.v1.Event.PinnedLink
+	38, // 31: soapbox.v1.Event.unpinned_link:type_name -> soapbox.v1.Event.UnpinnedLink
+	39, // 32: soapbox.v1.Event.opened_mini:type_name -> soapbox.v1.Event.OpenedMini
+	40, // 33: soapbox.v1.Event.closed_mini:type_name -> soapbox.v1.Event.ClosedMini
+	41, // 34: soapbox.v1.Event.requested_mini:type_name -> soapbox.v1.Event.RequestedMini
+	42, // 35: soapbox.v1.Event.server_restarting:type_name -> soapbox.v1.Event.ServerRestarting
+	43, // 36: soapbox.v1.Event.updated_metadata:type_name -> soapbox.v1.Event.UpdatedMetadata
+	44, // 37: soapbox.v1.RoomState.members:type_name -> soapbox.v1.RoomState.RoomMember
+	0,  // 38: soapbox.v1.RoomState.visibility:type_name -> soapbox.v1.Visibility
+	45, // 39: soapbox.v1.RoomState.mini:type_name -> soapbox.v1.RoomState.Mini
+	6,  // 40: soapbox.v1.RoomState.metadata:type_name -> soapbox.v1.RoomMetadata
+	0,  // 41: soapbox.v1.Command.VisibilityUpdate.visibility:type_name -> soapbox.v1.Visibility
+	6,  // 42: soapbox.v1.Command.UpdateMetadata.metadata:type_name -> soapbox.v1.RoomMetadata
+	44, // 43: soapbox.v1.Event.Joined.user:type_name -> soapbox.v1.RoomState.RoomMember
+	0,  // 44: soapbox.v1.Event.VisibilityUpdated.visibility:type_name -> soapbox.v1.Visibility
+	45, // 45: soapbox.v1.Event.OpenedMini.mini:type_name -> soapbox.v1.RoomState.Mini
+	45, // 46: soapbox.v1.Event.RequestedMini.mini:type_name -> soapbox.v1.RoomState.Mini
+	6,  // 47: soapbox.v1.Event.UpdatedMetadata.metadata:type_name -> soapbox.v1.RoomMetadata
+	1,  // 48: soapbox.v1.RoomState.RoomMember.role:type_name -> soapbox.v1.RoomState.RoomMember.Role
+	2,  // 49: soapbox.v1.RoomState.Mini.size:type_name -> soapbox.v1.RoomState.Mini.Size
+	50, // [50:50] is the sub-list for method output_type
+	50, // [50:50] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
 }
 
 func init() { file_soapbox_v1_room_proto_init() }
@@ -3014,7 +3305,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_MuteUpdate); i {
+			switch v := v.(*RoomMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3026,7 +3317,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_Reaction); i {
+			switch v := v.(*Command_MuteUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3038,7 +3329,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_LinkShare); i {
+			switch v := v.(*Command_Reaction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3050,7 +3341,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_InviteAdmin); i {
+			switch v := v.(*Command_LinkShare); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3062,7 +3353,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_AcceptAdmin); i {
+			switch v := v.(*Command_InviteAdmin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3074,7 +3365,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_RemoveAdmin); i {
+			switch v := v.(*Command_AcceptAdmin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3086,7 +3377,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_RenameRoom); i {
+			switch v := v.(*Command_RemoveAdmin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3098,7 +3389,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_InviteUser); i {
+			switch v := v.(*Command_RenameRoom); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3110,7 +3401,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_KickUser); i {
+			switch v := v.(*Command_InviteUser); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3122,7 +3413,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_MuteUser); i {
+			switch v := v.(*Command_KickUser); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3134,7 +3425,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_RecordScreen); i {
+			switch v := v.(*Command_MuteUser); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3146,7 +3437,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_VisibilityUpdate); i {
+			switch v := v.(*Command_RecordScreen); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3158,7 +3449,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_PinLink); i {
+			switch v := v.(*Command_VisibilityUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3170,7 +3461,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_UnpinLink); i {
+			switch v := v.(*Command_PinLink); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3182,7 +3473,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_OpenMini); i {
+			switch v := v.(*Command_UnpinLink); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3194,7 +3485,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_CloseMini); i {
+			switch v := v.(*Command_OpenMini); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3206,7 +3497,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Command_RequestMini); i {
+			switch v := v.(*Command_CloseMini); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3218,7 +3509,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_Joined); i {
+			switch v := v.(*Command_RequestMini); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3230,7 +3521,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_Left); i {
+			switch v := v.(*Command_UpdateMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3242,7 +3533,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_MuteUpdated); i {
+			switch v := v.(*Event_Joined); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3254,7 +3545,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_Reacted); i {
+			switch v := v.(*Event_Left); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3266,7 +3557,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_LinkShared); i {
+			switch v := v.(*Event_MuteUpdated); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3278,7 +3569,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_InvitedAdmin); i {
+			switch v := v.(*Event_Reacted); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3290,7 +3581,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_AddedAdmin); i {
+			switch v := v.(*Event_LinkShared); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3302,7 +3593,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_RemovedAdmin); i {
+			switch v := v.(*Event_InvitedAdmin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3314,7 +3605,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_RenamedRoom); i {
+			switch v := v.(*Event_AddedAdmin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3326,7 +3617,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_RecordedScreen); i {
+			switch v := v.(*Event_RemovedAdmin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3338,7 +3629,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_MutedByAdmin); i {
+			switch v := v.(*Event_RenamedRoom); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3350,7 +3641,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_VisibilityUpdated); i {
+			switch v := v.(*Event_RecordedScreen); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3362,7 +3653,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_PinnedLink); i {
+			switch v := v.(*Event_MutedByAdmin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3374,7 +3665,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_UnpinnedLink); i {
+			switch v := v.(*Event_VisibilityUpdated); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3386,7 +3677,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_OpenedMini); i {
+			switch v := v.(*Event_PinnedLink); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3398,7 +3689,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_ClosedMini); i {
+			switch v := v.(*Event_UnpinnedLink); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3410,7 +3701,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event_RequestedMini); i {
+			switch v := v.(*Event_OpenedMini); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3422,7 +3713,7 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomState_RoomMember); i {
+			switch v := v.(*Event_ClosedMini); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3434,6 +3725,54 @@ func file_soapbox_v1_room_proto_init() {
 			}
 		}
 		file_soapbox_v1_room_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event_RequestedMini); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_soapbox_v1_room_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event_ServerRestarting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_soapbox_v1_room_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event_UpdatedMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_soapbox_v1_room_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomState_RoomMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_soapbox_v1_room_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RoomState_Mini); i {
 			case 0:
 				return &v.state
@@ -3464,6 +3803,7 @@ func file_soapbox_v1_room_proto_init() {
 		(*Command_OpenMini_)(nil),
 		(*Command_CloseMini_)(nil),
 		(*Command_RequestMini_)(nil),
+		(*Command_UpdateMetadata_)(nil),
 	}
 	file_soapbox_v1_room_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*Event_Joined_)(nil),
@@ -3483,6 +3823,8 @@ func file_soapbox_v1_room_proto_init() {
 		(*Event_OpenedMini_)(nil),
 		(*Event_ClosedMini_)(nil),
 		(*Event_RequestedMini_)(nil),
+		(*Event_ServerRestarting_)(nil),
+		(*Event_UpdatedMetadata_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -3490,7 +3832,7 @@ func file_soapbox_v1_room_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_soapbox_v1_room_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   39,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,176 @@
+package rooms
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/followers"
+)
+
+const (
+	followingWeight = 4.0
+	friendWeight    = 2.0
+	membersWeight   = 1.5
+	freshnessWeight = 2.0
+	languageWeight  = 3.0
+
+	// freshnessDecay is the age after which the freshness boost of a room has decayed to about a third.
+	freshnessDecay = time.Hour
+
+	// newRoomAge is the age below which a room is considered just started.
+	newRoomAge = 5 * time.Minute
+
+	// popularRoomSize is the amount of members from which a room is considered popular.
+	popularRoomSize = 10
+)
+
+// RankedRoom is a room with its score for a user and the reasons it was recommended.
+type RankedRoom struct {
+	Room    *Room
+	Score   float64
+	Reasons []string
+}
+
+// Ranker orders the rooms a user can join by how relevant they are to the user.
+type Ranker struct {
+	repository *Repository
+	auth       *Auth
+	followers  *followers.FollowersBackend
+	blocked    *blocks.Backend
+}
+
+func NewRanker(repository *Repository, auth *Auth, followers *followers.FollowersBackend, blocked *blocks.Backend) *Ranker {
+	return &Ranker{
+		repository: repository,
+		auth:       auth,
+		followers:  followers,
+		blocked:    blocked,
+	}
+}
+
+// Rank returns the open rooms a user can join, ordered by descending score as of now.
+// Rooms containing users who blocked the user, or who the user blocked, are excluded.
+// If user is 0 all rooms are returned and only the non personal signals are used.
+func (r *Ranker) Rank(ctx context.Context, user int, languages []string, now time.Time) ([]*RankedRoom, error) {
+	following := make(map[int]bool)
+	friends := make(map[int]bool)
+	excluded := make([]int, 0)
+
+	if user != 0 {
+		ids, err := r.followers.GetAllFollowingIDsFor(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			following[id] = true
+		}
+
+		ids, err = r.followers.GetAllFollowerIDsFor(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			if following[id] {
+				friends[id] = true
+			}
+		}
+
+		blockers, err := r.blocked.GetUsersWhoBlocked(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		blocked, err := r.blocked.GetUsersBlockedBy(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		excluded = append(blockers, blocked...)
+	}
+
+	spoken := make(map[string]bool)
+	for _, language := range languages {
+		spoken[language] = true
+	}
+
+	ranked := make([]*RankedRoom, 0)
+
+	r.repository.Map(func(room *Room) {
+		if user != 0 {
+			if room.ConnectionState() == closed || !r.auth.canJoin(room, user) {
+				return
+			}
+
+			if room.ContainsUsers(excluded) {
+				return
+			}
+		}
+
+		ranked = append(ranked, score(room, now, following, friends, spoken))
+	})
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+
+		return ranked[i].Room.ID() < ranked[j].Room.ID()
+	})
+
+	return ranked, nil
+}
+
+func score(room *Room, now time.Time, following, friends map[int]bool, languages map[string]bool) *RankedRoom {
+	ranked := &RankedRoom{Room: room, Reasons: make([]string, 0)}
+
+	members, followed, friendsPresent := 0, 0, 0
+	room.MapMembers(func(member *Member) {
+		members++
+
+		if following[member.id] {
+			followed++
+		}
+
+		if friends[member.id] {
+			friendsPresent++
+		}
+	})
+
+	ranked.Score += followingWeight*float64(followed) + friendWeight*float64(friendsPresent)
+	ranked.Score += membersWeight * math.Log1p(float64(members))
+
+	// the wall clock is used so that a time restored from a cursor gives the same age.
+	age := now.Round(0).Sub(room.Created())
+	ranked.Score += freshnessWeight * math.Exp(-age.Seconds()/freshnessDecay.Seconds())
+
+	if language := room.Language(); language != "" && len(languages) > 0 {
+		if languages[language] {
+			ranked.Score += languageWeight
+		} else {
+			ranked.Score -= languageWeight
+		}
+	}
+
+	switch {
+	case followed == 1:
+		ranked.Reasons = append(ranked.Reasons, "1 person you follow is here")
+	case followed > 1:
+		ranked.Reasons = append(ranked.Reasons, fmt.Sprintf("%d people you follow are here", followed))
+	}
+
+	if members >= popularRoomSize {
+		ranked.Reasons = append(ranked.Reasons, "Popular right now")
+	}
+
+	if age < newRoomAge {
+		ranked.Reasons = append(ranked.Reasons, "Just started")
+	}
+
+	return ranked
+}
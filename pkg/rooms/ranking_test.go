@@ -0,0 +1,191 @@
+package rooms
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/followers"
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+)
+
+func TestRanker_Rank(t *testing.T) {
+	user := 1
+
+	repository := NewRepository()
+	repository.Set(testRoom("following", pb.Visibility_VISIBILITY_PUBLIC, time.Hour, 2, 3))
+	repository.Set(testRoom("popular", pb.Visibility_VISIBILITY_PUBLIC, time.Hour, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19))
+	repository.Set(testRoom("new", pb.Visibility_VISIBILITY_PUBLIC, time.Minute, 20))
+	repository.Set(testRoom("blocked", pb.Visibility_VISIBILITY_PUBLIC, time.Hour, 2, 4))
+	repository.Set(testRoom("private", pb.Visibility_VISIBILITY_PRIVATE, time.Hour, 2))
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("^SELECT (.+)").ExpectQuery().WithArgs(user).
+		WillReturnRows(mock.NewRows([]string{"user_id"}).AddRow(2).AddRow(3))
+	mock.ExpectPrepare("^SELECT (.+)").ExpectQuery().WithArgs(user).
+		WillReturnRows(mock.NewRows([]string{"follower"}).AddRow(2))
+	mock.ExpectPrepare("^SELECT (.+)").ExpectQuery().WithArgs(user).
+		WillReturnRows(mock.NewRows([]string{"user_id"}).AddRow(4))
+	mock.ExpectPrepare("^SELECT (.+)").ExpectQuery().WithArgs(user).
+		WillReturnRows(mock.NewRows([]string{"blocked"}))
+
+	blocked := blocks.NewBackend(db)
+	ranker := NewRanker(repository, NewAuth(repository, blocked), followers.NewFollowersBackend(db), blocked)
+
+	ranked, err := ranker.Rank(context.Background(), user, []string{"en"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]string, 0)
+	for _, room := range ranked {
+		ids = append(ids, room.Room.ID())
+	}
+
+	expected := []string{"following", "popular", "new"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected %v actual %v", expected, ids)
+	}
+
+	reasons := [][]string{
+		{"2 people you follow are here"},
+		{"Popular right now"},
+		{"Just started"},
+	}
+
+	for i, room := range ranked {
+		if !reflect.DeepEqual(room.Reasons, reasons[i]) {
+			t.Fatalf("expected reasons %v actual %v", reasons[i], room.Reasons)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRanker_Rank_Language(t *testing.T) {
+	repository := NewRepository()
+
+	english := testRoom("english", pb.Visibility_VISIBILITY_PUBLIC, time.Hour, 2)
+	english.language = "en"
+	repository.Set(english)
+
+	german := testRoom("german", pb.Visibility_VISIBILITY_PUBLIC, time.Hour, 3)
+	german.language = "de"
+	repository.Set(german)
+
+	ranker := NewRanker(repository, nil, nil, nil)
+
+	ranked, err := ranker.Rank(context.Background(), 0, []string{"de"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ranked) != 2 || ranked[0].Room.ID() != "german" {
+		t.Fatalf("expected german room to be ranked first")
+	}
+}
+
+func TestParseLanguages(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []string
+	}{
+		{header: "", expected: []string{}},
+		{header: "en-US,en;q=0.9,DE;q=0.8", expected: []string{"en", "en", "de"}},
+		{header: "*", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			actual := parseLanguages(tt.header)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Fatalf("expected %v actual %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func testRoom(id string, visibility pb.Visibility, age time.Duration, members ...int) *Room {
+	room := &Room{
+		id:         id,
+		visibility: visibility,
+		created:    time.Now().Add(-age),
+		state:      open,
+		members:    make(map[int]*Member),
+		kicked:     make(map[int]bool),
+		invited:    make(map[int]bool),
+	}
+
+	for _, member := range members {
+		room.members[member] = &Member{id: member}
+	}
+
+	return room
+}
+
+func TestRankCursor_Before(t *testing.T) {
+	ranked := []*RankedRoom{
+		{Room: testRoom("a", pb.Visibility_VISIBILITY_PUBLIC, time.Hour), Score: 3},
+		{Room: testRoom("b", pb.Visibility_VISIBILITY_PUBLIC, time.Hour), Score: 2},
+		{Room: testRoom("c", pb.Visibility_VISIBILITY_PUBLIC, time.Hour), Score: 2},
+		{Room: testRoom("d", pb.Visibility_VISIBILITY_PUBLIC, time.Hour), Score: 1},
+	}
+
+	cursor := newRankCursor(ranked[1], time.Now())
+
+	expected := []bool{false, false, true, true}
+	for i, room := range ranked {
+		if actual := cursor.before(room); actual != expected[i] {
+			t.Fatalf("expected %v for %s actual %v", expected[i], room.Room.ID(), actual)
+		}
+	}
+}
+
+func TestRankCursor_Time(t *testing.T) {
+	repository := NewRepository()
+	repository.Set(testRoom("a", pb.Visibility_VISIBILITY_PUBLIC, time.Minute))
+	repository.Set(testRoom("b", pb.Visibility_VISIBILITY_PUBLIC, time.Minute))
+
+	ranker := NewRanker(repository, nil, nil, nil)
+
+	now := time.Now()
+	ranked, err := ranker.Rank(context.Background(), 0, []string{}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(newRankCursor(ranked[0], now))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor := rankCursor{}
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the next page is requested after the rooms lost some of their freshness.
+	time.Sleep(10 * time.Millisecond)
+
+	ranked, err = ranker.Rank(context.Background(), 0, []string{}, time.Unix(0, cursor.Time))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cursor.before(ranked[0]) || !cursor.before(ranked[1]) {
+		t.Fatalf("expected only %s after the cursor", ranked[1].Room.ID())
+	}
+}
@@ -44,6 +44,18 @@ func (r *Repository) Remove(id string) {
 	delete(r.rooms, id)
 }
 
+// Count returns the amount of rooms and the amount of peers in all rooms.
+func (r *Repository) Count() (rooms int, peers int) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	for _, room := range r.rooms {
+		peers += room.PeerCount()
+	}
+
+	return len(r.rooms), peers
+}
+
 func (r *Repository) Map(f func(room *Room)) {
 	r.mux.RLock()
 	defer r.mux.RUnlock()
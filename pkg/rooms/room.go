@@ -4,14 +4,15 @@ import (
 	"context"
 	"errors"
 	"io"
-	"log"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pion/ion-sfu/pkg/sfu"
 	"github.com/pion/webrtc/v3"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/minis"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/internal"
@@ -40,6 +41,20 @@ type Room struct {
 	name       string
 	visibility pb.Visibility
 
+	description string
+	topics      []string
+
+	// language is the spoken language code of the room, it is empty when unknown.
+	language string
+
+	created time.Time
+
+	// owner is the user who created the room.
+	owner int
+
+	// reactions is the amount of reactions sent in the room.
+	reactions int
+
 	state RoomConnectionState
 
 	members map[int]*Member
@@ -72,6 +87,7 @@ func NewRoom(
 	name string,
 	owner int,
 	visibility pb.Visibility,
+	metadata *pb.RoomMetadata,
 	session sfu.Session,
 	queue *pubsub.Queue,
 	backend *minis.Backend,
@@ -80,6 +96,8 @@ func NewRoom(
 		id:                   id,
 		name:                 name,
 		visibility:           visibility,
+		created:              time.Now(),
+		owner:                owner,
 		state:                closed,
 		members:              make(map[int]*Member),
 		adminInvites:         make(map[int]bool),
@@ -92,6 +110,11 @@ func NewRoom(
 		minis:                backend,
 	}
 
+	metadata = sanitizeMetadata(metadata)
+	r.description = metadata.Description
+	r.topics = metadata.Topics
+	r.language = metadata.Language
+
 	r.invited[owner] = true
 
 	dc := sfu.NewDataChannel(CHANNEL)
@@ -135,6 +158,37 @@ func (r *Room) Name() string {
 	return r.name
 }
 
+// Language returns the spoken language code of the room.
+func (r *Room) Language() string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	return r.language
+}
+
+// Metadata returns the description, topics and language of the room.
+func (r *Room) Metadata() *pb.RoomMetadata {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	return r.metadata()
+}
+
+// Created returns when the room was opened.
+func (r *Room) Created() time.Time {
+	return r.created
+}
+
+// Owner returns the ID of the user who created the room.
+func (r *Room) Owner() int {
+	return r.owner
+}
+
+// Reactions returns the amount of reactions sent in the room since it was opened.
+func (r *Room) Reactions() int {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	return r.reactions
+}
+
 func (r *Room) WasAdminOnDisconnect(id int) bool {
 	r.mux.RLock()
 	defer r.mux.RUnlock()
@@ -236,6 +290,7 @@ func (r *Room) ToProto() *pb.RoomState {
 		Visibility: r.visibility,
 		Link:       r.link,
 		Mini:       r.mini,
+		Metadata:   r.metadata(),
 	}
 
 	if r.mini != nil {
@@ -271,7 +326,7 @@ func (r *Room) Handle(me *Member) {
 	r.mux.Unlock()
 
 	me.peer.OnICEConnectionStateChange = func(state webrtc.ICEConnectionState) {
-		log.Printf("connection state changed %d for peer %d", state, me.id)
+		log.Ctx(me.Context()).Printf("connection state changed %d for peer %d", state, me.id)
 
 		switch state {
 		case webrtc.ICEConnectionStateConnected:
@@ -302,24 +357,19 @@ func (r *Room) Handle(me *Member) {
 			return
 		}
 
-		log.Printf("me.Signal err: %v", err)
+		log.Ctx(me.Context()).Printf("me.Signal err: %v", err)
 	}
 }
 
 func (r *Room) onDisconnected(id int64) {
-	log.Printf("disconnected %d", id)
-
-	peer := r.member(int(id))
-	if peer == nil {
+	// closing the peer makes its signal loop report the disconnect again, only the first report is handled.
+	r.mux.Lock()
+	peer, ok := r.members[int(id)]
+	if !ok {
+		r.mux.Unlock()
 		return
 	}
 
-	err := peer.Close()
-	if err != nil {
-		log.Printf("rtc.Close error %v\n", err)
-	}
-
-	r.mux.Lock()
 	if peer.Role() == pb.RoomState_RoomMember_ROLE_ADMIN {
 		r.adminsOnDisconnected[int(id)] = true
 	}
@@ -327,6 +377,13 @@ func (r *Room) onDisconnected(id int64) {
 	delete(r.members, int(id))
 	r.mux.Unlock()
 
+	log.Ctx(peer.Context()).Printf("disconnected %d", id)
+
+	err := peer.Close()
+	if err != nil {
+		log.Ctx(peer.Context()).Printf("rtc.Close error %v", err)
+	}
+
 	r.notify(&pb.Event{
 		From:    id,
 		Payload: &pb.Event_Left_{},
@@ -337,6 +394,32 @@ func (r *Room) onDisconnected(id int64) {
 	r.onDisconnectedHandlerFunc(r.id, peer)
 }
 
+// Disconnect removes a member from the room.
+func (r *Room) Disconnect(id int) {
+	r.onDisconnected(int64(id))
+}
+
+// NotifyRestarting tells all members that the server is about to restart.
+func (r *Room) NotifyRestarting() {
+	r.notify(&pb.Event{
+		Payload: &pb.Event_ServerRestarting_{ServerRestarting: &pb.Event_ServerRestarting{}},
+	})
+}
+
+// Close disconnects all members of the room.
+func (r *Room) Close() {
+	r.mux.RLock()
+	ids := make([]int, 0, len(r.members))
+	for id := range r.members {
+		ids = append(ids, id)
+	}
+	r.mux.RUnlock()
+
+	for _, id := range ids {
+		r.onDisconnected(int64(id))
+	}
+}
+
 func (r *Room) electRandomAdmin(previous int64) {
 	r.mux.Lock()
 	defer r.mux.Unlock()
@@ -420,6 +503,8 @@ func (r *Room) onMessage(from int, command *pb.Command) {
 		r.onCloseMini(from)
 	case *pb.Command_RequestMini_:
 		r.onRequestMini(from, command.GetRequestMini())
+	case *pb.Command_UpdateMetadata_:
+		r.onUpdateMetadata(from, command.GetUpdateMetadata())
 	}
 }
 
@@ -443,6 +528,10 @@ func (r *Room) onMuteUpdate(from int, cmd *pb.Command_MuteUpdate) {
 }
 
 func (r *Room) onReaction(from int, cmd *pb.Command_Reaction) {
+	r.mux.Lock()
+	r.reactions++
+	r.mux.Unlock()
+
 	r.notify(&pb.Event{
 		From:    int64(from),
 		Payload: &pb.Event_Reacted_{Reacted: &pb.Event_Reacted{Emoji: cmd.Emoji}},
@@ -451,6 +540,7 @@ func (r *Room) onReaction(from int, cmd *pb.Command_Reaction) {
 
 func (r *Room) onLinkShare(from int, cmd *pb.Command_LinkShare) {
 	_ = r.queue.Publish(
+		r.memberContext(from),
 		pubsub.RoomTopic,
 		pubsub.NewRoomLinkShareEvent(from, r.id),
 	)
@@ -548,6 +638,37 @@ func (r *Room) onRenameRoom(from int, cmd *pb.Command_RenameRoom) {
 		From:    int64(from),
 		Payload: &pb.Event_RenamedRoom_{RenamedRoom: &pb.Event_RenamedRoom{Name: r.name}},
 	})
+
+	_ = r.queue.Publish(
+		r.memberContext(from),
+		pubsub.RoomTopic,
+		pubsub.NewRoomRenameEvent(r.id, from),
+	)
+}
+
+func (r *Room) onUpdateMetadata(from int, cmd *pb.Command_UpdateMetadata) {
+	if !r.isAdmin(from) {
+		return
+	}
+
+	metadata := sanitizeMetadata(cmd.Metadata)
+
+	r.mux.Lock()
+	r.description = metadata.Description
+	r.topics = metadata.Topics
+	r.language = metadata.Language
+	r.mux.Unlock()
+
+	r.notify(&pb.Event{
+		From:    int64(from),
+		Payload: &pb.Event_UpdatedMetadata_{UpdatedMetadata: &pb.Event_UpdatedMetadata{Metadata: metadata}},
+	})
+
+	_ = r.queue.Publish(
+		r.memberContext(from),
+		pubsub.RoomTopic,
+		pubsub.NewRoomMetadataUpdateEvent(r.id, from),
+	)
 }
 
 func (r *Room) onInviteUser(from int, cmd *pb.Command_InviteUser) {
@@ -635,6 +756,17 @@ func (r *Room) onVisibilityUpdate(from int, cmd *pb.Command_VisibilityUpdate) {
 		From:    int64(from),
 		Payload: &pb.Event_VisibilityUpdated_{VisibilityUpdated: &pb.Event_VisibilityUpdated{Visibility: cmd.Visibility}},
 	})
+
+	visibility := pubsub.Public
+	if cmd.Visibility == pb.Visibility_VISIBILITY_PRIVATE {
+		visibility = pubsub.Private
+	}
+
+	_ = r.queue.Publish(
+		r.memberContext(from),
+		pubsub.RoomTopic,
+		pubsub.NewRoomVisibilityUpdateEvent(r.id, from, visibility),
+	)
 }
 
 func (r *Room) onPinLink(from int, cmd *pb.Command_PinLink) {
@@ -694,12 +826,15 @@ func (r *Room) onOpenMini(from int, mini *pb.Command_OpenMini) {
 		return
 	}
 
+	ctx := r.memberContext(from)
+
 	_ = r.queue.Publish(
+		ctx,
 		pubsub.RoomTopic,
 		pubsub.NewRoomOpenMiniEvent(from, int(mini.Id), r.id),
 	)
 
-	resp, err := r.getMini(mini)
+	resp, err := r.getMini(ctx, mini)
 	if err != nil {
 		return
 	}
@@ -742,9 +877,11 @@ func (r *Room) onRequestMini(from int, cmd *pb.Command_RequestMini) {
 		return
 	}
 
-	mini, err := r.minis.GetMiniWithID(int(id))
+	ctx := r.memberContext(from)
+
+	mini, err := r.minis.GetMiniWithID(ctx, int(id))
 	if err != nil {
-		log.Printf("failed to get mini: %d err: %s", id, err)
+		log.Ctx(ctx).Printf("failed to get mini: %d err: %s", id, err)
 		return
 	}
 
@@ -780,18 +917,30 @@ func (r *Room) onRequestMini(from int, cmd *pb.Command_RequestMini) {
 	})
 }
 
-func (r *Room) getMini(cmd *pb.Command_OpenMini) (*minis.Mini, error) {
+func (r *Room) getMini(ctx context.Context, cmd *pb.Command_OpenMini) (*minis.Mini, error) {
 	if cmd.GetId() != 0 {
-		return r.minis.GetMiniWithID(int(cmd.Id))
+		return r.minis.GetMiniWithID(ctx, int(cmd.Id))
 	}
 
 	if cmd.GetMini() != "" {
-		return r.minis.GetMiniWithSlug(cmd.Mini)
+		return r.minis.GetMiniWithSlug(ctx, cmd.Mini)
 	}
 
 	return nil, errors.New("no mini found")
 }
 
+// metadata must be called while holding the lock.
+func (r *Room) metadata() *pb.RoomMetadata {
+	topics := make([]string, len(r.topics))
+	copy(topics, r.topics)
+
+	return &pb.RoomMetadata{
+		Description: r.description,
+		Topics:      topics,
+		Language:    r.language,
+	}
+}
+
 func (r *Room) member(id int) *Member {
 	r.mux.RLock()
 	defer r.mux.RUnlock()
@@ -804,6 +953,16 @@ func (r *Room) member(id int) *Member {
 	return member
 }
 
+// memberContext returns the context of a member, or an empty context if they are not in the room.
+func (r *Room) memberContext(id int) context.Context {
+	member := r.member(id)
+	if member == nil {
+		return context.Background()
+	}
+
+	return member.Context()
+}
+
 func (r *Room) notify(event *pb.Event) {
 	data, err := proto.Marshal(event)
 	if err != nil {
@@ -827,7 +986,7 @@ func (r *Room) notify(event *pb.Event) {
 				continue
 			}
 
-			log.Printf("failed to notify: %v\n", err)
+			log.Printf("failed to notify: %v", err)
 		}
 	}
 }
@@ -1,18 +1,20 @@
 package rooms
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pion/ion-sfu/pkg/sfu"
 	"github.com/pion/webrtc/v3"
 
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/minis"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/rooms/internal"
@@ -25,6 +27,9 @@ import (
 
 const MAX_PEERS = 16
 
+// restartGracePeriod is how long members are given to receive the restarting event before they are disconnected.
+const restartGracePeriod = 2 * time.Second
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
@@ -42,6 +47,9 @@ type Server struct {
 
 	repository *Repository
 	auth       *Auth
+
+	mux      sync.RWMutex
+	draining bool
 }
 
 func NewServer(
@@ -69,9 +77,12 @@ func NewServer(
 }
 
 func (s *Server) Signal(w http.ResponseWriter, r *http.Request) {
+	// the member outlives the request, so we only keep its request ID.
+	ctx := httputil.DetachedContext(r.Context())
+
 	c, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Println(err)
+		log.Ctx(ctx).Println(err)
 		return
 	}
 
@@ -84,11 +95,17 @@ func (s *Server) Signal(w http.ResponseWriter, r *http.Request) {
 	}
 
 	peer := sfu.NewPeer(s.sfu)
-	me := NewMember(user.ID, user.DisplayName, user.Username, user.Image, peer, conn)
+	me := NewMember(ctx, user.ID, user.DisplayName, user.Username, user.Image, peer, conn)
 
 	in, err := me.ReceiveMsg()
 	if err != nil {
-		log.Printf("receive err: %v", err)
+		log.Ctx(ctx).Printf("receive err: %v", err)
+		_ = conn.Close()
+		return
+	}
+
+	if s.isDraining() {
+		_ = conn.WriteError(in.Id, pb.SignalReply_ERROR_CLOSED)
 		_ = conn.Close()
 		return
 	}
@@ -115,7 +132,7 @@ func (s *Server) Signal(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if !s.auth.CanJoin(join.Room, user.ID) {
+		if !s.auth.CanJoin(ctx, join.Room, user.ID) {
 			_ = conn.WriteError(in.Id, pb.SignalReply_ERROR_NOT_INVITED)
 			return
 		}
@@ -161,7 +178,7 @@ func (s *Server) Signal(w http.ResponseWriter, r *http.Request) {
 		})
 
 		if err != nil {
-			log.Printf("error sending join response %s", err)
+			log.Ctx(ctx).Printf("error sending join response %s", err)
 			return
 		}
 
@@ -180,6 +197,7 @@ func (s *Server) Signal(w http.ResponseWriter, r *http.Request) {
 			name,
 			me.id,
 			create.Visibility,
+			create.Metadata,
 		)
 
 		err = peer.Join(id, strconv.Itoa(user.ID))
@@ -218,7 +236,7 @@ func (s *Server) Signal(w http.ResponseWriter, r *http.Request) {
 		})
 
 		if err != nil {
-			log.Printf("error sending create response %s", err)
+			log.Ctx(ctx).Printf("error sending create response %s", err)
 			return
 		}
 
@@ -238,6 +256,55 @@ func (s *Server) Signal(w http.ResponseWriter, r *http.Request) {
 	room.Handle(me)
 }
 
+// Shutdown stops accepting new members and tells everyone in a room that the server is restarting.
+// Members are disconnected after a short grace period, or as soon as ctx is canceled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mux.Lock()
+	s.draining = true
+	s.mux.Unlock()
+
+	rooms := make([]*Room, 0)
+	s.repository.Map(func(room *Room) {
+		rooms = append(rooms, room)
+	})
+
+	for _, room := range rooms {
+		room.NotifyRestarting()
+	}
+
+	select {
+	case <-time.After(restartGracePeriod):
+	case <-ctx.Done():
+	}
+
+	for _, room := range rooms {
+		room.Close()
+	}
+
+	log.Printf("closed %d rooms", len(rooms))
+
+	return nil
+}
+
+// RemoveUser disconnects a user from every room they are in.
+func (s *Server) RemoveUser(user int) {
+	rooms := make([]*Room, 0)
+	s.repository.Map(func(room *Room) {
+		rooms = append(rooms, room)
+	})
+
+	for _, room := range rooms {
+		room.Disconnect(user)
+	}
+}
+
+func (s *Server) isDraining() bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return s.draining
+}
+
 func (s *Server) getRoom(id string, owner int) (*Room, error) {
 	r, err := s.repository.Get(id)
 	if err == nil {
@@ -254,7 +321,7 @@ func (s *Server) getRoom(id string, owner int) (*Room, error) {
 	}
 
 	// @TODO NAME
-	r = s.createRoom(id, "Welcome!", owner, pb.Visibility_VISIBILITY_PUBLIC)
+	r = s.createRoom(id, "Welcome!", owner, pb.Visibility_VISIBILITY_PUBLIC, nil)
 	s.repository.Set(r)
 
 	r.InviteUser(owner, user)
@@ -262,20 +329,20 @@ func (s *Server) getRoom(id string, owner int) (*Room, error) {
 	return r, nil
 }
 
-func (s *Server) createRoom(id, name string, owner int, visibility pb.Visibility) *Room {
+func (s *Server) createRoom(id, name string, owner int, visibility pb.Visibility, metadata *pb.RoomMetadata) *Room {
 	session, _ := s.sfu.GetSession(id)
 
-	room := NewRoom(id, name, owner, visibility, session, s.queue, s.minis)
+	room := NewRoom(id, name, owner, visibility, metadata, session, s.queue, s.minis)
 
 	room.OnDisconnected(func(room string, peer *Member) {
-		err := s.currentRoom.RemoveCurrentRoomForUser(peer.id)
+		err := s.currentRoom.RemoveCurrentRoomForUser(peer.Context(), peer.id)
 		if err != nil {
-			log.Printf("failed to remove current room for user %d, err: %s", peer.id, err)
+			log.Ctx(peer.Context()).Printf("failed to remove current room for user %d, err: %s", peer.id, err)
 		}
 
 		r, err := s.repository.Get(room)
 		if err != nil {
-			fmt.Printf("failed to get room %v\n", err)
+			log.Ctx(peer.Context()).Printf("failed to get room %v", err)
 		}
 
 		visibility := pubsub.Public
@@ -283,9 +350,9 @@ func (s *Server) createRoom(id, name string, owner int, visibility pb.Visibility
 			visibility = pubsub.Private
 		}
 
-		err = s.queue.Publish(pubsub.RoomTopic, pubsub.NewRoomLeftEvent(room, peer.id, visibility, peer.joined))
+		err = s.queue.Publish(peer.Context(), pubsub.RoomTopic, pubsub.NewRoomLeftEvent(room, peer.id, visibility, peer.joined))
 		if err != nil {
-			log.Printf("queue.Publish err: %v\n", err)
+			log.Ctx(peer.Context()).Printf("queue.Publish err: %v", err)
 		}
 
 		if r == nil {
@@ -298,6 +365,11 @@ func (s *Server) createRoom(id, name string, owner int, visibility pb.Visibility
 
 		s.repository.Remove(room)
 
+		err = s.queue.Publish(peer.Context(), pubsub.RoomTopic, pubsub.NewRoomClosedEvent(room, r.Name(), r.Owner(), visibility, r.Created(), r.Reactions()))
+		if err != nil {
+			log.Ctx(peer.Context()).Printf("queue.Publish err: %v", err)
+		}
+
 		log.Printf("room \"%s\" was closed", room)
 	})
 
@@ -307,9 +379,11 @@ func (s *Server) createRoom(id, name string, owner int, visibility pb.Visibility
 			return
 		}
 
-		err = s.queue.Publish(pubsub.RoomTopic, pubsub.NewRoomInviteEvent(r.Name(), r.id, from, to))
+		ctx := r.memberContext(from)
+
+		err = s.queue.Publish(ctx, pubsub.RoomTopic, pubsub.NewRoomInviteEvent(r.Name(), r.id, from, to))
 		if err != nil {
-			log.Printf("queue.Publish err: %v\n", err)
+			log.Ctx(ctx).Printf("queue.Publish err: %v", err)
 		}
 	})
 
@@ -327,18 +401,18 @@ func (s *Server) createRoom(id, name string, owner int, visibility pb.Visibility
 			event = pubsub.NewRoomJoinEvent(room.id, me.id, visibility)
 		}
 
-		err := s.queue.Publish(pubsub.RoomTopic, event)
+		err := s.queue.Publish(me.Context(), pubsub.RoomTopic, event)
 		if err != nil {
-			log.Printf("queue.Publish err: %v\n", err)
+			log.Ctx(me.Context()).Printf("queue.Publish err: %v", err)
 		}
 
 		if visibility == pubsub.Private {
 			return
 		}
 
-		err = s.currentRoom.SetCurrentRoomForUser(me.id, room.id)
+		err = s.currentRoom.SetCurrentRoomForUser(me.Context(), me.id, room.id)
 		if err != nil {
-			log.Printf("failed to set current room err: %v user: %d", err, me.id)
+			log.Ctx(me.Context()).Printf("failed to set current room err: %v user: %d", err, me.id)
 		}
 	})
 
@@ -351,7 +425,7 @@ func (s *Server) userForSession(r *http.Request) (*types.User, error) {
 		return nil, errors.New("not authenticated")
 	}
 
-	u, err := s.ub.FindByID(userID)
+	u, err := s.ub.FindByID(r.Context(), userID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,270 @@
+package rooms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/gorilla/websocket"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/redis"
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+type transport struct {
+	closed bool
+}
+
+func (t *transport) ReadMsg() (*pb.SignalRequest, error) {
+	return nil, nil
+}
+
+func (t *transport) Write(*pb.SignalReply) error {
+	return nil
+}
+
+func (t *transport) Close() error {
+	t.closed = true
+	return nil
+}
+
+// publishes records the events published through redis, as miniredis does not support pubsub.
+type publishes struct {
+	mux    sync.Mutex
+	events []pubsub.Event
+}
+
+func (p *publishes) BeforeProcess(ctx context.Context, cmd goredis.Cmder) (context.Context, error) {
+	if cmd.Name() != "publish" {
+		return ctx, nil
+	}
+
+	event := pubsub.Event{}
+	err := json.Unmarshal(cmd.Args()[2].([]byte), &event)
+	if err != nil {
+		return ctx, err
+	}
+
+	p.mux.Lock()
+	p.events = append(p.events, event)
+	p.mux.Unlock()
+
+	return ctx, nil
+}
+
+func (p *publishes) AfterProcess(context.Context, goredis.Cmder) error {
+	return nil
+}
+
+func (p *publishes) BeforeProcessPipeline(ctx context.Context, _ []goredis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (p *publishes) AfterProcessPipeline(context.Context, []goredis.Cmder) error {
+	return nil
+}
+
+func (p *publishes) count(t pubsub.EventType) int {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	count := 0
+	for _, event := range p.events {
+		if event.Type == t {
+			count++
+		}
+	}
+
+	return count
+}
+
+func newTestServer(t *testing.T) (*Server, sqlmock.Sqlmock, *publishes) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(mr.Close)
+
+	port, err := strconv.Atoi(mr.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewRedis(conf.RedisConf{Port: port, Host: mr.Host(), DisableTLS: true})
+
+	published := &publishes{}
+	rdb.AddHook(published)
+
+	repository := NewRepository()
+
+	server := NewServer(
+		sfu.NewSFU(sfu.Config{}),
+		nil,
+		users.NewBackend(db),
+		pubsub.NewQueue(rdb),
+		NewCurrentRoomBackend(db),
+		NewWelcomeStore(rdb),
+		repository,
+		nil,
+		NewAuth(repository, blocks.NewBackend(db)),
+	)
+
+	return server, mock, published
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	server, mock, published := newTestServer(t)
+
+	room := server.createRoom("1", "test", 1, pb.Visibility_VISIBILITY_PUBLIC, nil)
+	server.repository.Set(room)
+
+	signal := &transport{}
+	member := NewMember(context.Background(), 1, "foo", "foo", "", sfu.NewPeer(server.sfu), signal)
+	room.members[1] = member
+
+	mock.ExpectPrepare("DELETE FROM current_rooms").ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// the grace period is skipped as the context is already done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := &pb.Event{}
+	err = proto.Unmarshal(<-member.dataChannel.msgQueue, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.GetServerRestarting() == nil {
+		t.Fatalf("unexpected event %v", event)
+	}
+
+	if !signal.closed {
+		t.Fatal("member was not disconnected")
+	}
+
+	if _, err := server.repository.Get("1"); err == nil {
+		t.Fatal("room was not removed")
+	}
+
+	if count := published.count(pubsub.EventTypeRoomClosed); count != 1 {
+		t.Fatalf("room closed was published %d times", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_SignalWhileDraining(t *testing.T) {
+	server, mock, _ := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectPrepare("SELECT").
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "display_name", "username", "image", "bio", "email"}).AddRow(1, "foo", "foo", "", "", "foo@bar.com"))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.Signal(w, r.WithContext(httputil.WithUserID(r.Context(), 1)))
+	}))
+	defer ts.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	data, err := proto.Marshal(&pb.SignalRequest{
+		Id:      "1234",
+		Payload: &pb.SignalRequest_Join{Join: &pb.JoinRequest{Room: "1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = conn.WriteMessage(websocket.BinaryMessage, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, data, err = conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply := &pb.SignalReply{}
+	err = proto.Unmarshal(data, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply.Id != "1234" || reply.GetError() != pb.SignalReply_ERROR_CLOSED {
+		t.Fatalf("unexpected reply %v", reply)
+	}
+}
+
+func TestServer_RemoveUser(t *testing.T) {
+	server, mock, _ := newTestServer(t)
+
+	room := server.createRoom("1", "test", 1, pb.Visibility_VISIBILITY_PUBLIC, nil)
+	server.repository.Set(room)
+
+	for _, id := range []int{1, 2} {
+		room.members[id] = NewMember(context.Background(), id, "foo", "foo", "", sfu.NewPeer(server.sfu), &transport{})
+	}
+
+	mock.ExpectPrepare("DELETE FROM current_rooms").ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	server.RemoveUser(2)
+
+	if room.member(2) != nil || room.member(1) == nil {
+		t.Fatal("unexpected members")
+	}
+
+	if _, err := server.repository.Get("1"); err != nil {
+		t.Fatal("room was closed")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,128 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+
+	"github.com/soapboxsocial/soapbox/pkg/search/internal"
+)
+
+// elasticsearchQuery is a Query that can be translated to the Elasticsearch query DSL.
+type elasticsearchQuery interface {
+	Query
+
+	Build() map[string]interface{}
+	sort() []string
+}
+
+// ElasticsearchEngine is an Engine storing documents in Elasticsearch, the indexes are managed through Indices.
+// Writes are refreshed immediately so that they are visible to the next query.
+type ElasticsearchEngine struct {
+	client *elasticsearch.Client
+}
+
+func NewElasticsearchEngine(client *elasticsearch.Client) *ElasticsearchEngine {
+	return &ElasticsearchEngine{client: client}
+}
+
+func (e *ElasticsearchEngine) Index(ctx context.Context, index, id string, document interface{}) error {
+	body, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	res, err := esapi.IndexRequest{
+		Index:      index,
+		DocumentID: id,
+		Body:       bytes.NewReader(body),
+		Refresh:    "true",
+	}.Do(ctx, e.client)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to index %s in %s: %s", id, index, res.String())
+	}
+
+	return nil
+}
+
+func (e *ElasticsearchEngine) Delete(ctx context.Context, index, id string) error {
+	res, err := esapi.DeleteRequest{
+		Index:      index,
+		DocumentID: id,
+		Refresh:    "true",
+	}.Do(ctx, e.client)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete %s from %s: %s", id, index, res.String())
+	}
+
+	return nil
+}
+
+func (e *ElasticsearchEngine) Query(ctx context.Context, query Query, limit int, after json.RawMessage) (*Hits, error) {
+	q, ok := query.(elasticsearchQuery)
+	if !ok {
+		return nil, fmt.Errorf("unsupported query %T", query)
+	}
+
+	request := map[string]interface{}{"query": q.Build()}
+	if after != nil {
+		request["search_after"] = after
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := e.client.Search(
+		e.client.Search.WithContext(ctx),
+		e.client.Search.WithIndex(q.Index()),
+		e.client.Search.WithBody(bytes.NewReader(body)),
+		e.client.Search.WithSort(q.sort()...),
+		e.client.Search.WithSize(limit),
+		e.client.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("search failed: %s", res.String())
+	}
+
+	result := &internal.Result{}
+	err = json.NewDecoder(res.Body).Decode(result)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := &Hits{Documents: make([]json.RawMessage, 0, len(result.Hits.Hits))}
+	for _, hit := range result.Hits.Hits {
+		hits.Documents = append(hits.Documents, hit.Source)
+	}
+
+	if len(result.Hits.Hits) == limit {
+		hits.Next = result.Hits.Hits[limit-1].Sort
+	}
+
+	return hits, nil
+}
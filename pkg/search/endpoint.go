@@ -5,36 +5,49 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
 	"sync"
 
-	"github.com/elastic/go-elasticsearch/v7"
-	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/gorilla/mux"
 
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/followers"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
-	"github.com/soapboxsocial/soapbox/pkg/search/internal"
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	"github.com/soapboxsocial/soapbox/pkg/log"
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
 	"github.com/soapboxsocial/soapbox/pkg/users/types"
 )
 
-// @TODO maybe do a type?
-const (
-	usersIndex = "users"
-)
-
 type Response struct {
-	Users []*types.User `json:"users,omitempty"`
+	Users      []*types.User `json:"users,omitempty"`
+	Rooms      []*Room       `json:"rooms,omitempty"`
+	NextCursor *string       `json:"next_cursor"`
+}
+
+// cursor contains the sort values of the last hit returned for every index, used to continue the search after it.
+// An index without sort values has no more hits.
+type cursor struct {
+	Users json.RawMessage `json:"users,omitempty"`
+	Rooms json.RawMessage `json:"rooms,omitempty"`
 }
 
 type Endpoint struct {
-	client *elasticsearch.Client
+	engine    Engine
+	rooms     pb.RoomServiceClient
+	followers *followers.FollowersBackend
+	blocks    *blocks.Backend
 }
 
-func NewEndpoint(client *elasticsearch.Client) *Endpoint {
-	return &Endpoint{client: client}
+func NewEndpoint(engine Engine, rooms pb.RoomServiceClient, followers *followers.FollowersBackend, blocks *blocks.Backend) *Endpoint {
+	return &Endpoint{
+		engine:    engine,
+		rooms:     rooms,
+		followers: followers,
+		blocks:    blocks,
+	}
 }
 
 func (e *Endpoint) Router() *mux.Router {
@@ -58,25 +71,58 @@ func (e *Endpoint) Search(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	limit := httputil.GetInt(r.URL.Query(), "limit", 10)
-	offset := httputil.GetInt(r.URL.Query(), "offset", 0)
+	user, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusUnauthorized, httputil.ErrorCodeInvalidRequestBody, "unauthorized")
+		return
+	}
+
+	after := cursor{}
+	paginated, err := httputil.DecodeCursor(r.URL.Query(), &after)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
+		return
+	}
+
+	limit := httputil.GetLimit(r.URL.Query())
 
 	response := Response{}
+	next := cursor{}
 
 	var wg sync.WaitGroup
 	for _, index := range indexes {
-		if index == "users" {
+		if index == UsersIndex && (!paginated || after.Users != nil) {
 			wg.Add(1)
 
 			go func() {
-				list, err := e.searchUsers(query, limit, offset)
+				list, last, err := e.searchUsers(r.Context(), user, query, limit, after.Users)
 				if err != nil {
-					log.Printf("failed to search users: %s\n", err.Error())
+					log.Ctx(r.Context()).Printf("failed to search users: %s", err.Error())
 					wg.Done()
 					return
 				}
 
 				response.Users = list
+				next.Users = last
+
+				wg.Done()
+			}()
+		}
+
+		if index == RoomsIndex && (!paginated || after.Rooms != nil) {
+			wg.Add(1)
+
+			go func() {
+				list, last, err := e.searchRooms(r.Context(), user, query, limit, after.Rooms)
+				if err != nil {
+					log.Ctx(r.Context()).Printf("failed to search rooms: %s", err.Error())
+					wg.Done()
+					return
+				}
+
+				response.Rooms = list
+				next.Rooms = last
+
 				wg.Done()
 			}()
 		}
@@ -84,9 +130,19 @@ func (e *Endpoint) Search(w http.ResponseWriter, r *http.Request) {
 
 	wg.Wait()
 
+	if next.Users != nil || next.Rooms != nil {
+		c, err := httputil.EncodeCursor(next)
+		if err != nil {
+			httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+			return
+		}
+
+		response.NextCursor = &c
+	}
+
 	err = httputil.JsonEncode(w, response)
 	if err != nil {
-		log.Printf("failed to write search response: %s\n", err.Error())
+		log.Ctx(r.Context()).Printf("failed to write search response: %s", err.Error())
 	}
 }
 
@@ -98,7 +154,7 @@ func indexTypes(query url.Values) ([]string, error) {
 
 	vals := strings.Split(indexes, ",")
 	for _, val := range vals {
-		if val != usersIndex {
+		if val != UsersIndex && val != RoomsIndex {
 			return nil, fmt.Errorf("invalid index %s", vals)
 		}
 	}
@@ -106,56 +162,121 @@ func indexTypes(query url.Values) ([]string, error) {
 	return vals, nil
 }
 
-func (e *Endpoint) searchUsers(query string, limit, offset int) ([]*types.User, error) {
-	res, err := e.search("users", query, limit, offset)
+// searchUsers returns the users matching query, and the sort values of the last hit to continue the search from.
+// The sort values are nil if there are no more hits.
+func (e *Endpoint) searchUsers(ctx context.Context, user int, query string, limit int, after json.RawMessage) ([]*types.User, json.RawMessage, error) {
+	q, err := e.userQuery(ctx, user, query)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	hits, err := e.engine.Query(ctx, *q, limit, after)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	data := make([]*types.User, 0)
-	for _, hit := range res.Hits.Hits {
+	for _, document := range hits.Documents {
 		user := &types.User{}
-		err := json.Unmarshal(hit.Source, user)
+		err := json.Unmarshal(document, user)
 		if err != nil {
 			continue
 		}
 
+		user.Images = images.Variants(user.Image)
 		data = append(data, user)
 	}
 
-	return data, nil
+	return data, hits.Next, nil
 }
 
-func (e *Endpoint) search(index, query string, limit, offset int) (*internal.Result, error) {
-	config := []func(*esapi.SearchRequest){
-		e.client.Search.WithContext(context.Background()),
-		e.client.Search.WithIndex(index),
-		e.client.Search.WithQuery(query),
-		e.client.Search.WithSize(limit),
-		e.client.Search.WithFrom(offset),
-		e.client.Search.WithTrackTotalHits(true),
-	}
-
-	if index == "users" {
-		if query == "*" {
-			config = append(config, e.client.Search.WithSort("room_time:desc", "followers:desc"))
-		} else {
-			config = append(config, e.client.Search.WithSort("_score:desc"))
+// searchRooms returns the live rooms matching query that the user can join, and the sort values of the last hit.
+// Private rooms are never returned, even if the user was invited.
+func (e *Endpoint) searchRooms(ctx context.Context, user int, query string, limit int, after json.RawMessage) ([]*Room, json.RawMessage, error) {
+	data := make([]*Room, 0)
+
+	var states map[string]*pb.RoomState
+	for len(data) < limit {
+		// only as many hits as rooms are missing are requested, so the search continues after the last hit consumed.
+		hits, err := e.engine.Query(ctx, RoomQuery{Query: query}, limit-len(data), after)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(hits.Documents) == 0 {
+			return data, nil, nil
+		}
+
+		// the index can lag behind the rooms, so results are checked against the rooms the user can currently join.
+		if states == nil {
+			joinable, err := e.rooms.ListRooms(ctx, &pb.ListRoomsRequest{UserId: int64(user)})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			states = make(map[string]*pb.RoomState)
+			for _, state := range joinable.Rooms {
+				states[state.Id] = state
+			}
+		}
+
+		for _, document := range hits.Documents {
+			room := &Room{}
+			err := json.Unmarshal(document, room)
+			if err != nil {
+				continue
+			}
+
+			state, ok := states[room.ID]
+			if !ok || state.Visibility == pb.Visibility_VISIBILITY_PRIVATE {
+				continue
+			}
+
+			data = append(data, NewRoom(state))
+		}
+
+		after = hits.Next
+		if after == nil {
+			break
 		}
 	}
 
-	res, err := e.client.Search(config...)
+	return data, after, nil
+}
+
+// userQuery returns the query for a user search, boosting mutual follows and excluding users blocked in either direction.
+func (e *Endpoint) userQuery(ctx context.Context, user int, query string) (*UserQuery, error) {
+	following, err := e.followers.GetAllFollowingIDsFor(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	followers, err := e.followers.GetAllFollowerIDsFor(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
-	defer res.Body.Close()
+	followed := make(map[int]bool)
+	for _, id := range following {
+		followed[id] = true
+	}
+
+	mutuals := make([]int, 0)
+	for _, id := range followers {
+		if followed[id] {
+			mutuals = append(mutuals, id)
+		}
+	}
+
+	blocked, err := e.blocks.GetUsersBlockedBy(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
-	result := &internal.Result{}
-	err = json.NewDecoder(res.Body).Decode(result)
+	blockers, err := e.blocks.GetUsersWhoBlocked(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return &UserQuery{Query: query, Mutuals: mutuals, Excluded: append(blocked, blockers...)}, nil
 }
@@ -0,0 +1,160 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+
+	"github.com/soapboxsocial/soapbox/mocks"
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
+	"github.com/soapboxsocial/soapbox/pkg/followers"
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+	"github.com/soapboxsocial/soapbox/pkg/search"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+func TestEndpoint_Search(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rooms := mocks.NewMockRoomServiceClient(ctrl)
+	engine := search.NewMemoryEngine()
+
+	endpoint := search.NewEndpoint(engine, rooms, followers.NewFollowersBackend(db), blocks.NewBackend(db))
+
+	ctx := context.Background()
+	for _, user := range []*users.SearchUser{
+		{ID: 2, Username: "jeff", DisplayName: "Jeff"},
+		{ID: 3, Username: "jeffrey", DisplayName: "Jeffrey"},
+		{ID: 4, Username: "jefferson", DisplayName: "Jefferson"},
+	} {
+		err := engine.Index(ctx, search.UsersIndex, fmt.Sprint(user.ID), user)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, room := range []*search.Room{{ID: "a", Name: "Jeff's room"}, {ID: "b", Name: "Jeffrey's room"}} {
+		err := engine.Index(ctx, search.RoomsIndex, room.ID, room)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	user := 1
+
+	mock.ExpectPrepare("^SELECT user_id FROM followers").ExpectQuery().WithArgs(user).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3))
+	mock.ExpectPrepare("^SELECT follower FROM followers").ExpectQuery().WithArgs(user).
+		WillReturnRows(sqlmock.NewRows([]string{"follower"}).AddRow(3))
+	mock.ExpectPrepare("^SELECT blocked FROM blocks").ExpectQuery().WithArgs(user).
+		WillReturnRows(sqlmock.NewRows([]string{"blocked"}).AddRow(4))
+	mock.ExpectPrepare("^SELECT user_id FROM blocks").ExpectQuery().WithArgs(user).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	// room a is not joinable by the user anymore.
+	rooms.EXPECT().
+		ListRooms(gomock.Any(), gomock.Eq(&pb.ListRoomsRequest{UserId: int64(user)})).
+		Return(&pb.ListRoomsResponse{Rooms: []*pb.RoomState{{Id: "b", Name: "Jeffrey's room"}}}, nil)
+
+	r, err := http.NewRequest("GET", "/?type=users,rooms&query=jef", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	endpoint.Router().ServeHTTP(rr, r.WithContext(httputil.WithUserID(r.Context(), user)))
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	response := &search.Response{}
+	err = json.NewDecoder(rr.Body).Decode(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the mutual follow is ranked first and the blocked user is excluded.
+	if len(response.Users) != 2 || response.Users[0].ID != 3 || response.Users[1].ID != 2 {
+		t.Fatalf("unexpected users %+v", response.Users)
+	}
+
+	if len(response.Rooms) != 1 || response.Rooms[0].ID != "b" {
+		t.Fatalf("unexpected rooms %+v", response.Rooms)
+	}
+
+	if response.NextCursor != nil {
+		t.Fatalf("unexpected cursor %s", *response.NextCursor)
+	}
+}
+
+func TestEndpoint_Search_SkippedRooms(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rooms := mocks.NewMockRoomServiceClient(ctrl)
+	engine := search.NewMemoryEngine()
+
+	endpoint := search.NewEndpoint(engine, rooms, followers.NewFollowersBackend(db), blocks.NewBackend(db))
+
+	ctx := context.Background()
+	for _, room := range []*search.Room{{ID: "a", Name: "room"}, {ID: "b", Name: "room"}, {ID: "c", Name: "room"}} {
+		err := engine.Index(ctx, search.RoomsIndex, room.ID, room)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	user := 1
+
+	// rooms a and b are not joinable by the user anymore.
+	rooms.EXPECT().
+		ListRooms(gomock.Any(), gomock.Eq(&pb.ListRoomsRequest{UserId: int64(user)})).
+		Return(&pb.ListRoomsResponse{Rooms: []*pb.RoomState{{Id: "c", Name: "room"}}}, nil)
+
+	r, err := http.NewRequest("GET", "/?type=rooms&query=room&limit=2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	endpoint.Router().ServeHTTP(rr, r.WithContext(httputil.WithUserID(r.Context(), user)))
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	response := &search.Response{}
+	err = json.NewDecoder(rr.Body).Decode(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(response.Rooms) != 1 || response.Rooms[0].ID != "c" {
+		t.Fatalf("unexpected rooms %+v", response.Rooms)
+	}
+
+	if response.NextCursor != nil {
+		t.Fatalf("unexpected cursor %s", *response.NextCursor)
+	}
+}
@@ -0,0 +1,40 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	UsersIndex = "users"
+	RoomsIndex = "rooms"
+)
+
+// Query is a search on a single index that every Engine can execute.
+type Query interface {
+	// Index returns the index that is searched.
+	Index() string
+}
+
+// Hits are the documents matching a query, in order.
+type Hits struct {
+	// Documents contains the source of every hit.
+	Documents []json.RawMessage
+
+	// Next continues the query after the last hit, it is nil if there are no more hits.
+	// Its format is specific to the Engine that returned it.
+	Next json.RawMessage
+}
+
+// Engine stores the documents of every index and searches them.
+type Engine interface {
+	// Index adds the document with id to index, replacing a previous version.
+	Index(ctx context.Context, index, id string, document interface{}) error
+
+	// Delete removes the document with id from index, it is not an error if the document does not exist.
+	Delete(ctx context.Context, index, id string) error
+
+	// Query returns up to limit documents matching query. If after is not nil, the query is continued after the
+	// last hit of the previous call it was returned by.
+	Query(ctx context.Context, query Query, limit int, after json.RawMessage) (*Hits, error)
+}
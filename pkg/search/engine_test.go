@@ -0,0 +1,219 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v7"
+
+	"github.com/soapboxsocial/soapbox/pkg/search"
+	"github.com/soapboxsocial/soapbox/pkg/users"
+)
+
+func TestMemoryEngine(t *testing.T) {
+	testEngine(t, search.NewMemoryEngine())
+}
+
+// TestElasticsearchEngine runs against the cluster at ELASTICSEARCH_URL, its users and rooms indexes are replaced.
+func TestElasticsearchEngine(t *testing.T) {
+	if os.Getenv("ELASTICSEARCH_URL") == "" {
+		t.Skip("ELASTICSEARCH_URL is not set")
+	}
+
+	client, err := elasticsearch.NewDefaultClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	indices := search.NewIndices(client)
+
+	for _, index := range []string{search.UsersIndex, search.RoomsIndex} {
+		created, err := indices.Create(ctx, index)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = indices.Swap(ctx, index, created)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testEngine(t, search.NewElasticsearchEngine(client))
+}
+
+// testEngine is the behaviour every Engine has to implement, the indexes of engine have to be empty.
+func testEngine(t *testing.T, engine search.Engine) {
+	ctx := context.Background()
+
+	for _, user := range []*users.SearchUser{
+		{ID: 1, Username: "jeff", DisplayName: "Jeff Smith", Followers: 10},
+		{ID: 2, Username: "jefferson", DisplayName: "Thomas"},
+		{ID: 3, Username: "alice", DisplayName: "Alice Doe"},
+		{ID: 4, Username: "bob", DisplayName: "Robert"},
+	} {
+		err := engine.Index(ctx, search.UsersIndex, fmt.Sprint(user.ID), user)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, room := range []*search.Room{
+		{ID: "a", Name: "Morning Coffee", Topics: []string{"music"}, Members: []string{"Jeff Smith"}, MemberCount: 1},
+		{ID: "b", Name: "Late Night", Topics: []string{}, Members: []string{"Thomas", "Robert"}, MemberCount: 2},
+	} {
+		err := engine.Index(ctx, search.RoomsIndex, room.ID, room)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("prefix", func(t *testing.T) {
+		assertQuery(t, engine, search.UserQuery{Query: "jef"}, []string{"1", "2"}, false)
+	})
+
+	t.Run("typo", func(t *testing.T) {
+		assertQuery(t, engine, search.UserQuery{Query: "alics"}, []string{"3"}, true)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		assertQuery(t, engine, search.UserQuery{Query: "jef", Excluded: []int{1}}, []string{"2"}, true)
+	})
+
+	t.Run("mutuals", func(t *testing.T) {
+		hits, err := engine.Query(ctx, search.UserQuery{Query: "*", Mutuals: []int{4}}, 10, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := documentIDs(t, hits)
+		if len(ids) != 4 || ids[0] != "4" {
+			t.Fatalf("expected mutual first, got %v", ids)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		seen := make(map[string]bool)
+
+		var after json.RawMessage
+		for page := 0; page < 3; page++ {
+			hits, err := engine.Query(ctx, search.UserQuery{Query: "*"}, 3, after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, id := range documentIDs(t, hits) {
+				if seen[id] {
+					t.Fatalf("%s returned twice", id)
+				}
+
+				seen[id] = true
+			}
+
+			if hits.Next == nil {
+				break
+			}
+
+			after = hits.Next
+		}
+
+		if len(seen) != 4 {
+			t.Fatalf("expected 4 users, got %v", seen)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		err := engine.Index(ctx, search.UsersIndex, "2", &users.SearchUser{ID: 2, Username: "thomas", DisplayName: "Thomas"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertQuery(t, engine, search.UserQuery{Query: "jef"}, []string{"1"}, true)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			err := engine.Delete(ctx, search.UsersIndex, "3")
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		assertQuery(t, engine, search.UserQuery{Query: "alice"}, []string{}, true)
+	})
+
+	t.Run("rooms by size", func(t *testing.T) {
+		assertQuery(t, engine, search.RoomQuery{Query: "*"}, []string{"b", "a"}, true)
+	})
+
+	t.Run("rooms by name", func(t *testing.T) {
+		assertQuery(t, engine, search.RoomQuery{Query: "coffee"}, []string{"a"}, true)
+	})
+
+	t.Run("rooms by topic", func(t *testing.T) {
+		assertQuery(t, engine, search.RoomQuery{Query: "music"}, []string{"a"}, true)
+	})
+
+	t.Run("rooms by member", func(t *testing.T) {
+		assertQuery(t, engine, search.RoomQuery{Query: "robert"}, []string{"b"}, true)
+	})
+}
+
+// assertQuery checks that query returns the documents with expected IDs, in order if ordered is set.
+func assertQuery(t *testing.T, engine search.Engine, query search.Query, expected []string, ordered bool) {
+	t.Helper()
+
+	hits, err := engine.Query(context.Background(), query, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := documentIDs(t, hits)
+
+	if !ordered {
+		found := make(map[string]bool)
+		for _, id := range ids {
+			found[id] = true
+		}
+
+		ids = make([]string, 0, len(found))
+		for _, id := range expected {
+			if found[id] {
+				ids = append(ids, id)
+			}
+		}
+
+		if len(found) != len(ids) {
+			t.Fatalf("expected %v, got %v", expected, found)
+		}
+	}
+
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected %v, got %v", expected, ids)
+	}
+}
+
+func documentIDs(t *testing.T, hits *search.Hits) []string {
+	t.Helper()
+
+	ids := make([]string, 0, len(hits.Documents))
+	for _, document := range hits.Documents {
+		doc := struct {
+			ID interface{} `json:"id"`
+		}{}
+
+		err := json.Unmarshal(document, &doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, fmt.Sprint(doc.ID))
+	}
+
+	return ids
+}
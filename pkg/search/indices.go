@@ -0,0 +1,226 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+// mappings contains the settings and mappings of every index, named "<alias>.json".
+//
+//go:embed mappings/*.json
+var mappings embed.FS
+
+// Indices manages the versioned indexes behind the aliases that are searched and written to.
+// Every index is named "<alias>_<version>", so that a new mapping can be rolled out by reindexing into a new index
+// and atomically pointing the alias to it.
+type Indices struct {
+	client *elasticsearch.Client
+}
+
+func NewIndices(client *elasticsearch.Client) *Indices {
+	return &Indices{client: client}
+}
+
+// Mapping returns the settings and mappings for the index behind alias.
+func Mapping(alias string) ([]byte, error) {
+	return mappings.ReadFile("mappings/" + alias + ".json")
+}
+
+// Ensure creates an index for alias if neither an alias nor an index with that name exists.
+func (i *Indices) Ensure(ctx context.Context, alias string) error {
+	res, err := esapi.IndicesExistsRequest{Index: []string{alias}}.Do(ctx, i.client)
+	if err != nil {
+		return err
+	}
+
+	_ = res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	if res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("unexpected status checking index %s: %d", alias, res.StatusCode)
+	}
+
+	_, err = i.create(ctx, alias, true)
+	return err
+}
+
+// Reindex copies all documents of alias into a new index with the current mapping, points alias to the new index
+// and removes the previous ones. Searches are served by the previous index until the alias is swapped.
+// Documents written while the copy is running may be missing from the new index, they have to be written again
+// once the alias was swapped.
+func (i *Indices) Reindex(ctx context.Context, alias string) (string, error) {
+	index, err := i.Create(ctx, alias)
+	if err != nil {
+		return "", err
+	}
+
+	previous, legacy, err := i.resolve(ctx, alias)
+	if err != nil {
+		return "", err
+	}
+
+	if len(previous) > 0 || legacy {
+		body, err := json.Marshal(map[string]interface{}{
+			"source":    map[string]interface{}{"index": alias},
+			"dest":      map[string]interface{}{"index": index},
+			"conflicts": "proceed",
+		})
+		if err != nil {
+			return "", err
+		}
+
+		refresh := true
+		wait := true
+
+		err = i.do(ctx, esapi.ReindexRequest{Body: bytes.NewReader(body), Refresh: &refresh, WaitForCompletion: &wait})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = i.Swap(ctx, alias, index)
+	if err != nil {
+		return "", err
+	}
+
+	return index, nil
+}
+
+// Create creates a new version of the index behind alias with the current mapping, without pointing alias to it.
+func (i *Indices) Create(ctx context.Context, alias string) (string, error) {
+	return i.create(ctx, alias, false)
+}
+
+// Swap atomically points alias to index and removes the indexes alias pointed to before.
+func (i *Indices) Swap(ctx context.Context, alias, index string) error {
+	previous, legacy, err := i.resolve(ctx, alias)
+	if err != nil {
+		return err
+	}
+
+	actions := []map[string]interface{}{
+		{"add": map[string]interface{}{"index": index, "alias": alias}},
+	}
+
+	// an index named like the alias has to be removed in the same request that creates the alias.
+	if legacy {
+		actions = append(actions, map[string]interface{}{"remove_index": map[string]interface{}{"index": alias}})
+	}
+
+	for _, name := range previous {
+		actions = append(actions, map[string]interface{}{"remove": map[string]interface{}{"index": name, "alias": alias}})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return err
+	}
+
+	err = i.do(ctx, esapi.IndicesUpdateAliasesRequest{Body: bytes.NewReader(body)})
+	if err != nil {
+		return err
+	}
+
+	if len(previous) == 0 {
+		return nil
+	}
+
+	return i.do(ctx, esapi.IndicesDeleteRequest{Index: previous})
+}
+
+// resolve returns the indexes alias points to, or whether alias is the name of an index.
+func (i *Indices) resolve(ctx context.Context, alias string) ([]string, bool, error) {
+	res, err := esapi.IndicesGetAliasRequest{Name: []string{alias}}.Do(ctx, i.client)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		exists, err := esapi.IndicesExistsRequest{Index: []string{alias}}.Do(ctx, i.client)
+		if err != nil {
+			return nil, false, err
+		}
+
+		_ = exists.Body.Close()
+
+		return []string{}, exists.StatusCode == http.StatusOK, nil
+	}
+
+	if res.IsError() {
+		return nil, false, fmt.Errorf("failed to get alias %s: %s", alias, res.String())
+	}
+
+	result := make(map[string]interface{})
+	err = json.NewDecoder(res.Body).Decode(&result)
+	if err != nil {
+		return nil, false, err
+	}
+
+	indexes := make([]string, 0, len(result))
+	for index := range result {
+		indexes = append(indexes, index)
+	}
+
+	return indexes, false, nil
+}
+
+// create creates a new version of the index behind alias, optionally pointing alias to it.
+func (i *Indices) create(ctx context.Context, alias string, aliased bool) (string, error) {
+	mapping, err := Mapping(alias)
+	if err != nil {
+		return "", err
+	}
+
+	body := make(map[string]interface{})
+	err = json.Unmarshal(mapping, &body)
+	if err != nil {
+		return "", err
+	}
+
+	if aliased {
+		body["aliases"] = map[string]interface{}{alias: map[string]interface{}{}}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	index := fmt.Sprintf("%s_%s", alias, time.Now().UTC().Format("20060102150405"))
+
+	err = i.do(ctx, esapi.IndicesCreateRequest{Index: index, Body: strings.NewReader(string(data))})
+	if err != nil {
+		return "", err
+	}
+
+	return index, nil
+}
+
+func (i *Indices) do(ctx context.Context, req esapi.Request) error {
+	res, err := req.Do(ctx, i.client)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch error: %s", res.String())
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ type Hits struct {
 		ID     string          `json:"_id"`
 		Score  float64         `json:"_score"`
 		Source json.RawMessage `json:"_source"`
+		Sort   json.RawMessage `json:"sort"`
 	} `json:"hits"`
 }
 
@@ -0,0 +1,362 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// MemoryEngine is an Engine keeping all documents in memory, it is mainly useful in tests.
+// Queries are matched like the Elasticsearch mappings do it, by prefix and with typos, except for phonetic matching
+// which is not supported. Scores are only comparable with scores of the same engine.
+type MemoryEngine struct {
+	mux sync.RWMutex
+
+	indexes map[string]map[string]json.RawMessage
+}
+
+func NewMemoryEngine() *MemoryEngine {
+	return &MemoryEngine{indexes: make(map[string]map[string]json.RawMessage)}
+}
+
+// memoryHit is a matching document with the values it is sorted by.
+type memoryHit struct {
+	id       string
+	score    float64
+	document json.RawMessage
+}
+
+// userDocument contains the fields of a user document that are searched and ranked on.
+type userDocument struct {
+	ID          int    `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Followers   int    `json:"followers"`
+	RoomTime    int    `json:"room_time"`
+}
+
+func (m *MemoryEngine) Index(_ context.Context, index, id string, document interface{}) error {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	documents, ok := m.indexes[index]
+	if !ok {
+		documents = make(map[string]json.RawMessage)
+		m.indexes[index] = documents
+	}
+
+	documents[id] = data
+	return nil
+}
+
+func (m *MemoryEngine) Delete(_ context.Context, index, id string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	delete(m.indexes[index], id)
+	return nil
+}
+
+func (m *MemoryEngine) Query(_ context.Context, query Query, limit int, after json.RawMessage) (*Hits, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be positive")
+	}
+
+	var match func(json.RawMessage) (float64, bool, error)
+	switch q := query.(type) {
+	case UserQuery:
+		match = q.match
+	case RoomQuery:
+		match = q.match
+	default:
+		return nil, fmt.Errorf("unsupported query %T", query)
+	}
+
+	m.mux.RLock()
+	hits := make([]*memoryHit, 0)
+	for id, document := range m.indexes[query.Index()] {
+		score, ok, err := match(document)
+		if err != nil {
+			m.mux.RUnlock()
+			return nil, err
+		}
+
+		if ok {
+			hits = append(hits, &memoryHit{id: id, score: score, document: document})
+		}
+	}
+	m.mux.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		return hits[i].before(hits[j].score, hits[j].id)
+	})
+
+	if after != nil {
+		var values []interface{}
+		err := json.Unmarshal(after, &values)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) != 2 {
+			return nil, errors.New("invalid cursor")
+		}
+
+		score, scored := values[0].(float64)
+		id, identified := values[1].(string)
+		if !scored || !identified {
+			return nil, errors.New("invalid cursor")
+		}
+
+		start := sort.Search(len(hits), func(i int) bool {
+			return !hits[i].before(score, id) && (hits[i].score != score || hits[i].id != id)
+		})
+
+		hits = hits[start:]
+	}
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	result := &Hits{Documents: make([]json.RawMessage, 0, len(hits))}
+	for _, hit := range hits {
+		result.Documents = append(result.Documents, hit.document)
+	}
+
+	if len(hits) == limit {
+		last := hits[limit-1]
+
+		next, err := json.Marshal([]interface{}{last.score, last.id})
+		if err != nil {
+			return nil, err
+		}
+
+		result.Next = next
+	}
+
+	return result, nil
+}
+
+// before returns whether the hit is sorted before a hit with score and id, by descending score and ascending id.
+func (h *memoryHit) before(score float64, id string) bool {
+	if h.score != score {
+		return h.score > score
+	}
+
+	a, errA := strconv.Atoi(h.id)
+	b, errB := strconv.Atoi(id)
+	if errA == nil && errB == nil {
+		return a < b
+	}
+
+	return h.id < id
+}
+
+func (q UserQuery) match(data json.RawMessage) (float64, bool, error) {
+	user := &userDocument{}
+	err := json.Unmarshal(data, user)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, id := range q.Excluded {
+		if id == user.ID {
+			return 0, false, nil
+		}
+	}
+
+	score := 1.0
+
+	query := strings.TrimSpace(q.Query)
+	if query != "" && query != matchAll {
+		terms := tokenize(query)
+		score = math.Max(
+			3*prefixScore(terms, tokenize(user.Username)),
+			2*prefixScore(terms, tokenize(user.DisplayName)),
+		)
+
+		if score == 0 {
+			return 0, false, nil
+		}
+	}
+
+	// field_value_factor uses the common logarithm for the log1p modifier.
+	score += math.Log10(1 + roomTimeFactor*float64(user.RoomTime))
+	score += math.Log10(1 + float64(user.Followers))
+
+	for _, id := range q.Mutuals {
+		if id == user.ID {
+			score += mutualFollowWeight
+			break
+		}
+	}
+
+	return score, true, nil
+}
+
+func (q RoomQuery) match(data json.RawMessage) (float64, bool, error) {
+	room := &Room{}
+	err := json.Unmarshal(data, room)
+	if err != nil {
+		return 0, false, err
+	}
+
+	query := strings.TrimSpace(q.Query)
+	if query == "" || query == matchAll {
+		return float64(room.MemberCount), true, nil
+	}
+
+	terms := tokenize(query)
+
+	// topics are keywords, they only match the whole query.
+	topics := 0.0
+	for _, topic := range room.Topics {
+		topics = math.Max(topics, termScore(query, topic, false))
+	}
+
+	members := 0.0
+	for _, member := range room.Members {
+		members = math.Max(members, prefixScore(terms, tokenize(member)))
+	}
+
+	score := math.Max(
+		math.Max(3*prefixScore(terms, tokenize(room.Name)), 2*topics),
+		math.Max(members, fullScore(terms, tokenize(room.Description))),
+	)
+
+	return score, score > 0, nil
+}
+
+// tokenize splits text into lowercase words like the standard analyzer.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
+
+// prefixScore scores terms against the tokens of a field analyzed into edge n-grams, where terms match the
+// beginning of a token.
+func prefixScore(terms, tokens []string) float64 {
+	return fieldScore(terms, tokens, true)
+}
+
+// fullScore scores terms against the tokens of a field where terms match whole tokens.
+func fullScore(terms, tokens []string) float64 {
+	return fieldScore(terms, tokens, false)
+}
+
+func fieldScore(terms, tokens []string, prefix bool) float64 {
+	score := 0.0
+	for _, term := range terms {
+		best := 0.0
+		for _, token := range tokens {
+			best = math.Max(best, termScore(term, token, prefix))
+		}
+
+		score += best
+	}
+
+	return score
+}
+
+// termScore returns 1 if term matches token exactly and 0.5 if it matches with the typos allowed by the AUTO
+// fuzziness, where the first character has to match.
+func termScore(term, token string, prefix bool) float64 {
+	a, b := []rune(term), []rune(token)
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	if term == token || (prefix && len(b) > len(a) && string(b[:len(a)]) == term) {
+		return 1
+	}
+
+	if a[0] != b[0] {
+		return 0
+	}
+
+	allowed := fuzziness(len(a))
+	if allowed == 0 {
+		return 0
+	}
+
+	candidates := [][]rune{b}
+	if prefix {
+		for n := len(a) - allowed; n <= len(a)+allowed; n++ {
+			if n > 0 && n < len(b) {
+				candidates = append(candidates, b[:n])
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if distance(a, candidate) <= allowed {
+			return 0.5
+		}
+	}
+
+	return 0
+}
+
+// fuzziness returns the edits allowed for a term of length n, like the AUTO fuzziness.
+func fuzziness(n int) int {
+	switch {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = smallest(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func smallest(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,148 @@
+package search
+
+import "strings"
+
+const (
+	// mutualFollowWeight is added to the score of users who follow and are followed by the searching user.
+	mutualFollowWeight = 5.0
+
+	// roomTimeFactor scales the seconds spent in public rooms over the last week before the logarithm is applied.
+	roomTimeFactor = 0.01
+)
+
+// matchAll is the query used to list documents instead of searching them.
+const matchAll = "*"
+
+// UserQuery describes a search for users, personalised for the user searching.
+type UserQuery struct {
+	Query string
+
+	// Mutuals are boosted in the results.
+	Mutuals []int
+
+	// Excluded are never returned, for example because of blocks.
+	Excluded []int
+}
+
+// Build returns the Elasticsearch query for a user search.
+// Username and display name are matched by prefix through the edge n-gram analyzer, with typos through fuzzy
+// matching and by sound through the phonetic sub fields. Mutual follows, recent room activity and followers are
+// added to the score.
+func (q UserQuery) Build() map[string]interface{} {
+	var match map[string]interface{}
+
+	query := strings.TrimSpace(q.Query)
+	if query == "" || query == matchAll {
+		match = map[string]interface{}{"match_all": map[string]interface{}{}}
+	} else {
+		match = map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should": []interface{}{
+					map[string]interface{}{
+						"multi_match": map[string]interface{}{
+							"query":         query,
+							"fields":        []string{"username^3", "display_name^2"},
+							"fuzziness":     "AUTO",
+							"prefix_length": 1,
+						},
+					},
+					map[string]interface{}{
+						"multi_match": map[string]interface{}{
+							"query":  query,
+							"fields": []string{"username.phonetic", "display_name.phonetic"},
+							"boost":  0.5,
+						},
+					},
+				},
+				"minimum_should_match": 1,
+			},
+		}
+	}
+
+	excluded := q.Excluded
+	if excluded == nil {
+		excluded = []int{}
+	}
+
+	functions := []interface{}{
+		map[string]interface{}{
+			"field_value_factor": map[string]interface{}{
+				"field":    "room_time",
+				"factor":   roomTimeFactor,
+				"modifier": "log1p",
+				"missing":  0,
+			},
+		},
+		map[string]interface{}{
+			"field_value_factor": map[string]interface{}{
+				"field":    "followers",
+				"modifier": "log1p",
+				"missing":  0,
+			},
+		},
+	}
+
+	if len(q.Mutuals) > 0 {
+		functions = append(functions, map[string]interface{}{
+			"filter": map[string]interface{}{"terms": map[string]interface{}{"id": q.Mutuals}},
+			"weight": mutualFollowWeight,
+		})
+	}
+
+	return map[string]interface{}{
+		"function_score": map[string]interface{}{
+			"query": map[string]interface{}{
+				"bool": map[string]interface{}{
+					"must":     match,
+					"must_not": map[string]interface{}{"terms": map[string]interface{}{"id": excluded}},
+				},
+			},
+			"functions":  functions,
+			"score_mode": "sum",
+			"boost_mode": "sum",
+		},
+	}
+}
+
+func (q UserQuery) Index() string {
+	return UsersIndex
+}
+
+func (q UserQuery) sort() []string {
+	return []string{"_score:desc", "id:asc"}
+}
+
+// RoomQuery describes a search for rooms. Listing all rooms returns the largest rooms first.
+type RoomQuery struct {
+	Query string
+}
+
+// Build returns the Elasticsearch query for a room search.
+func (q RoomQuery) Build() map[string]interface{} {
+	query := strings.TrimSpace(q.Query)
+	if query == "" || query == matchAll {
+		return map[string]interface{}{"match_all": map[string]interface{}{}}
+	}
+
+	return map[string]interface{}{
+		"multi_match": map[string]interface{}{
+			"query":         query,
+			"fields":        []string{"name^3", "topics^2", "members", "description"},
+			"fuzziness":     "AUTO",
+			"prefix_length": 1,
+		},
+	}
+}
+
+func (q RoomQuery) Index() string {
+	return RoomsIndex
+}
+
+func (q RoomQuery) sort() []string {
+	query := strings.TrimSpace(q.Query)
+	if query == "" || query == matchAll {
+		return []string{"member_count:desc", "id:asc"}
+	}
+
+	return []string{"_score:desc", "id:asc"}
+}
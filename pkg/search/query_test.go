@@ -0,0 +1,79 @@
+package search_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/search"
+)
+
+func TestUserQuery_Build(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    search.UserQuery
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "match all",
+			query:    search.UserQuery{Query: "*"},
+			contains: []string{`"match_all":{}`, `"must_not":{"terms":{"id":[]}}`, `"field":"room_time"`},
+			excludes: []string{`"multi_match"`, `"weight"`},
+		},
+		{
+			name:     "special characters",
+			query:    search.UserQuery{Query: ` "foo:(bar `},
+			contains: []string{`"query":"\"foo:(bar"`, `"fuzziness":"AUTO"`, `"username.phonetic"`},
+		},
+		{
+			name:     "mutuals and excluded",
+			query:    search.UserQuery{Query: "foo", Mutuals: []int{1, 2}, Excluded: []int{3}},
+			contains: []string{`"filter":{"terms":{"id":[1,2]}}`, `"must_not":{"terms":{"id":[3]}}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.query.Build())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body := string(data)
+
+			for _, c := range tt.contains {
+				if !strings.Contains(body, c) {
+					t.Fatalf("expected %s to contain %s", body, c)
+				}
+			}
+
+			for _, c := range tt.excludes {
+				if strings.Contains(body, c) {
+					t.Fatalf("expected %s not to contain %s", body, c)
+				}
+			}
+		})
+	}
+}
+
+func TestMapping(t *testing.T) {
+	for _, index := range []string{"users", "rooms"} {
+		t.Run(index, func(t *testing.T) {
+			data, err := search.Mapping(index)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mapping := make(map[string]interface{})
+			err = json.Unmarshal(data, &mapping)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, ok := mapping["mappings"]; !ok {
+				t.Fatal("no mappings")
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package search
+
+import (
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+)
+
+// Room is the document stored in the rooms index for a live public room.
+type Room struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Topics      []string `json:"topics"`
+	Language    string   `json:"language"`
+	Visibility  string   `json:"visibility"`
+	Members     []string `json:"members"`
+	MemberCount int      `json:"member_count"`
+}
+
+// NewRoom returns the search document for a room.
+func NewRoom(state *pb.RoomState) *Room {
+	metadata := state.GetMetadata()
+
+	topics := metadata.GetTopics()
+	if topics == nil {
+		topics = make([]string, 0)
+	}
+
+	visibility := "public"
+	if state.Visibility == pb.Visibility_VISIBILITY_PRIVATE {
+		visibility = "private"
+	}
+
+	members := make([]string, 0, len(state.Members))
+	for _, member := range state.Members {
+		members = append(members, member.DisplayName)
+	}
+
+	return &Room{
+		ID:          state.Id,
+		Name:        state.Name,
+		Description: metadata.GetDescription(),
+		Topics:      topics,
+		Language:    metadata.GetLanguage(),
+		Visibility:  visibility,
+		Members:     members,
+		MemberCount: len(members),
+	}
+}
@@ -0,0 +1,38 @@
+package search_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/rooms/pb"
+	"github.com/soapboxsocial/soapbox/pkg/search"
+)
+
+func TestNewRoom(t *testing.T) {
+	state := &pb.RoomState{
+		Id:         "123",
+		Name:       "foo",
+		Visibility: pb.Visibility_VISIBILITY_PUBLIC,
+		Members: []*pb.RoomState_RoomMember{
+			{Id: 1, DisplayName: "bar"},
+			{Id: 2, DisplayName: "baz"},
+		},
+		Metadata: &pb.RoomMetadata{Description: "desc", Topics: []string{"music"}, Language: "en"},
+	}
+
+	expected := &search.Room{
+		ID:          "123",
+		Name:        "foo",
+		Description: "desc",
+		Topics:      []string{"music"},
+		Language:    "en",
+		Visibility:  "public",
+		Members:     []string{"bar", "baz"},
+		MemberCount: 2,
+	}
+
+	actual := search.NewRoom(state)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v actual %v", expected, actual)
+	}
+}
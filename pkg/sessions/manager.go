@@ -1,7 +1,9 @@
 package sessions
 
 import (
+	"context"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -16,7 +18,18 @@ func NewSessionManager(db *redis.Client) *SessionManager {
 }
 
 func (sm *SessionManager) NewSession(id string, user int, expiration time.Duration) error {
-	return sm.db.Set(sm.db.Context(), generateSessionKey(id), user, expiration).Err()
+	ctx := sm.db.Context()
+
+	pipe := sm.db.TxPipeline()
+	pipe.Set(ctx, generateSessionKey(id), user, expiration)
+	pipe.SAdd(ctx, generateUserSessionsKey(user), id)
+
+	if expiration > 0 {
+		pipe.Expire(ctx, generateUserSessionsKey(user), expiration)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
 }
 
 func (sm *SessionManager) GetUserIDForSession(id string) (int, error) {
@@ -29,10 +42,118 @@ func (sm *SessionManager) GetUserIDForSession(id string) (int, error) {
 }
 
 func (sm *SessionManager) CloseSession(id string) error {
-	_, err := sm.db.Del(sm.db.Context(), generateSessionKey(id)).Result()
+	ctx := sm.db.Context()
+
+	user, err := sm.GetUserIDForSession(id)
+	if err == redis.Nil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	pipe := sm.db.TxPipeline()
+	pipe.Del(ctx, generateSessionKey(id))
+	pipe.SRem(ctx, generateUserSessionsKey(user), id)
+
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// CloseAllSessions closes every session of a user, logging them out on all of their devices.
+func (sm *SessionManager) CloseAllSessions(user int) error {
+	ctx := sm.db.Context()
+
+	ids, err := sm.db.SMembers(ctx, generateUserSessionsKey(user)).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{generateUserSessionsKey(user)}
+	for _, id := range ids {
+		keys = append(keys, generateSessionKey(id))
+	}
+
+	return sm.db.Del(ctx, keys...).Err()
+}
+
+// IndexSessions adds the sessions opened before they were listed per user to the set of their user.
+// It only needs to run once, every session opened since is listed by NewSession.
+func (sm *SessionManager) IndexSessions() (int, error) {
+	ctx := sm.db.Context()
+
+	indexed := 0
+
+	var cursor uint64
+	for {
+		keys, next, err := sm.db.Scan(ctx, cursor, generateSessionKey("*"), 1000).Result()
+		if err != nil {
+			return indexed, err
+		}
+
+		for _, key := range keys {
+			err := sm.indexSession(ctx, key)
+			if err == redis.Nil {
+				continue
+			}
+
+			if err != nil {
+				return indexed, err
+			}
+
+			indexed++
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return indexed, nil
+		}
+	}
+}
+
+func (sm *SessionManager) indexSession(ctx context.Context, key string) error {
+	value, err := sm.db.Get(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	user, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+
+	ttl, err := sm.db.TTL(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	set := generateUserSessionsKey(user)
+
+	// the set lives as long as the longest session in it.
+	current, err := sm.db.TTL(ctx, set).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := sm.db.TxPipeline()
+	pipe.SAdd(ctx, set, strings.TrimPrefix(key, generateSessionKey("")))
+
+	switch {
+	case current == -2 && ttl > 0, current > 0 && ttl > current:
+		pipe.Expire(ctx, set, ttl)
+	case ttl < 0:
+		pipe.Persist(ctx, set)
+	}
+
+	_, err = pipe.Exec(ctx)
 	return err
 }
 
 func generateSessionKey(id string) string {
 	return "session_" + id
 }
+
+func generateUserSessionsKey(user int) string {
+	return "user_sessions_" + strconv.Itoa(user)
+}
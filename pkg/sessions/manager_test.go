@@ -0,0 +1,68 @@
+package sessions_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/redis"
+	"github.com/soapboxsocial/soapbox/pkg/sessions"
+)
+
+func TestSessionManager_IndexSessions(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer mr.Close()
+
+	port, err := strconv.Atoi(mr.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sm := sessions.NewSessionManager(redis.NewRedis(conf.RedisConf{Port: port, Host: mr.Host(), DisableTLS: true}))
+
+	err = sm.NewSession("new", 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sessions opened before they were listed per user.
+	_ = mr.Set("session_legacy", "1")
+	mr.SetTTL("session_legacy", 2*time.Hour)
+	_ = mr.Set("session_other", "2")
+
+	indexed, err := sm.IndexSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if indexed != 3 {
+		t.Fatalf("unexpected indexed sessions %d", indexed)
+	}
+
+	if ttl := mr.TTL("user_sessions_1"); ttl != 2*time.Hour {
+		t.Fatalf("unexpected ttl %s", ttl)
+	}
+
+	err = sm.CloseAllSessions(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"new", "legacy"} {
+		if _, err := sm.GetUserIDForSession(id); err == nil {
+			t.Fatalf("session %s was not closed", id)
+		}
+	}
+
+	user, err := sm.GetUserIDForSession("other")
+	if err != nil || user != 2 {
+		t.Fatalf("unexpected user %d err %v", user, err)
+	}
+}
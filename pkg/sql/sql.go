@@ -5,18 +5,23 @@ import (
 	"database/sql"
 	"fmt"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"github.com/soapboxsocial/soapbox/pkg/conf"
 )
 
 // Open opens a Postgres database from the passed config.
+// Queries made with a context that is part of a trace are recorded as spans.
 func Open(config conf.PostgresConf) (*sql.DB, error) {
-	return sql.Open(
-		"postgres",
+	connector, err := pq.NewConnector(
 		fmt.Sprintf(
 			"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 			config.Host, config.Port, config.User, config.Password, config.Database, config.SSL,
 		),
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(&tracingConnector{connector: connector, database: config.Database}), nil
 }
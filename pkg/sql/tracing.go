@@ -0,0 +1,238 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
+)
+
+// tracingConnector wraps the connections of a driver so that queries made with a traced context create spans.
+type tracingConnector struct {
+	connector driver.Connector
+	database  string
+}
+
+func (c *tracingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracingConn{conn: conn, database: c.database}, nil
+}
+
+func (c *tracingConnector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
+
+type tracingConn struct {
+	conn     driver.Conn
+	database string
+}
+
+func (c *tracingConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *tracingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+
+	if p, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = p.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracingStmt{stmt: stmt, query: query, database: c.database}, nil
+}
+
+func (c *tracingConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *tracingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *tracingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+
+	return c.conn.Begin()
+}
+
+func (c *tracingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := startSpan(ctx, query, c.database)
+	rows, err := q.QueryContext(ctx, query, args)
+	endSpan(span, err)
+
+	return rows, err
+}
+
+func (c *tracingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := startSpan(ctx, query, c.database)
+	result, err := e.ExecContext(ctx, query, args)
+	endSpan(span, err)
+
+	return result, err
+}
+
+func (c *tracingConn) Ping(ctx context.Context) error {
+	if p, ok := c.conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+
+	return nil
+}
+
+type tracingStmt struct {
+	stmt     driver.Stmt
+	query    string
+	database string
+}
+
+func (s *tracingStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *tracingStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *tracingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.stmt.Exec(args)
+}
+
+func (s *tracingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.stmt.Query(args)
+}
+
+func (s *tracingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := startSpan(ctx, s.query, s.database)
+	defer span.End()
+
+	var (
+		result driver.Result
+		err    error
+	)
+
+	if e, ok := s.stmt.(driver.StmtExecContext); ok {
+		result, err = e.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		values, err = namedValuesToValues(args)
+		if err == nil {
+			result, err = s.stmt.Exec(values)
+		}
+	}
+
+	recordError(span, err)
+	return result, err
+}
+
+func (s *tracingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := startSpan(ctx, s.query, s.database)
+	defer span.End()
+
+	var (
+		rows driver.Rows
+		err  error
+	)
+
+	if q, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		values, err = namedValuesToValues(args)
+		if err == nil {
+			rows, err = s.stmt.Query(values)
+		}
+	}
+
+	recordError(span, err)
+	return rows, err
+}
+
+// startSpan only records queries that are part of a trace, queries without a parent are not worth the noise.
+func startSpan(ctx context.Context, query, database string) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.IsRecording() {
+		return ctx, parent
+	}
+
+	operation := operationFor(query)
+
+	return tracing.Start(
+		ctx,
+		operation+" "+database,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String("postgresql"),
+			semconv.DBNameKey.String(database),
+			semconv.DBOperationKey.String(operation),
+			semconv.DBStatementKey.String(query),
+		),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	recordError(span, err)
+	span.End()
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil || err == driver.ErrSkip {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// operationFor returns the SQL keyword a query starts with, for example "SELECT".
+func operationFor(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+func namedValuesToValues(named []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(named))
+	for i, arg := range named {
+		if arg.Name != "" {
+			return nil, errors.New("sql: driver does not support the use of Named Parameters")
+		}
+
+		values[i] = arg.Value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func TestTracingConnector(t *testing.T) {
+	mockDB, mock, err := sqlmock.NewWithDSN("tracing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer mockDB.Close()
+
+	db := sql.OpenDB(&tracingConnector{connector: dsnConnector{dsn: "tracing", driver: mockDB.Driver()}, database: "soapbox"})
+	defer db.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare("SELECT id FROM users").
+		ExpectQuery().
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	// queries without a trace are not recorded.
+	_, err = db.Exec("UPDATE users SET display_name = $1", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "parent")
+
+	stmt, err := db.PrepareContext(ctx, "SELECT id FROM users WHERE id = $1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = rows.Close()
+	span.End()
+
+	err = provider.ForceFlush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("unexpected amount of spans %d", len(spans))
+	}
+
+	query := spans[0]
+	if query.Name != "SELECT soapbox" {
+		t.Fatalf("unexpected name %s", query.Name)
+	}
+
+	if query.Parent.SpanID() != spans[1].SpanContext.SpanID() {
+		t.Fatal("query span is not a child of the parent")
+	}
+}
@@ -0,0 +1,50 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+)
+
+type key string
+
+const txKey key = "tx"
+
+// Executor is implemented by both *sql.DB and *sql.Tx, so that backends can run queries in or outside a transaction.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// ExecutorFrom returns the transaction started by Transaction for ctx, or db if there is none.
+func ExecutorFrom(ctx context.Context, db *sql.DB) Executor {
+	tx, ok := ctx.Value(txKey).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return db
+}
+
+// Transaction runs f in a transaction that is committed if f returns no error, and rolled back otherwise.
+// Backend methods called with the context passed to f join the transaction, which lets callers compose writes
+// across backends atomically. If ctx already belongs to a transaction, f simply runs as part of it.
+func Transaction(ctx context.Context, db *sql.DB, f func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey).(*sql.Tx); ok {
+		return f(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = f(context.WithValue(ctx, txKey, tx))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
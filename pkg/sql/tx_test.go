@@ -0,0 +1,70 @@
+package sql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+)
+
+func TestTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("^INSERT INTO blocks").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("^DELETE FROM followers").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err = sqlutil.Transaction(context.Background(), db, func(ctx context.Context) error {
+		_, err := sqlutil.ExecutorFrom(ctx, db).ExecContext(ctx, "INSERT INTO blocks (user_id, blocked) VALUES (1, 2);")
+		if err != nil {
+			return err
+		}
+
+		// nested transactions join the outer one.
+		return sqlutil.Transaction(ctx, db, func(ctx context.Context) error {
+			_, err := sqlutil.ExecutorFrom(ctx, db).ExecContext(ctx, "DELETE FROM followers;")
+			return err
+		})
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("^INSERT INTO blocks").WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+
+	err = sqlutil.Transaction(context.Background(), db, func(ctx context.Context) error {
+		_, err := sqlutil.ExecutorFrom(ctx, db).ExecContext(ctx, "INSERT INTO blocks (user_id, blocked) VALUES (1, 2);")
+		return err
+	})
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
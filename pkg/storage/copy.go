@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+)
+
+// Copy copies every blob in src that is missing from dst, it returns the number of blobs copied.
+func Copy(ctx context.Context, src, dst Blob) (int, error) {
+	names, err := src.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	existing, err := dst.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	copied := make(map[string]bool)
+	for _, name := range existing {
+		copied[name] = true
+	}
+
+	count := 0
+	for _, name := range names {
+		if copied[name] {
+			continue
+		}
+
+		err := copyBlob(ctx, src, dst, name)
+		if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func copyBlob(ctx context.Context, src, dst Blob, name string) error {
+	r, err := src.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	return dst.Put(ctx, name, r, ContentType(name))
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores blobs as files in a directory.
+type Local struct {
+	path string
+}
+
+func NewLocal(path string) *Local {
+	return &Local{path: path}
+}
+
+func (l *Local) Put(_ context.Context, name string, r io.Reader, _ string) error {
+	file, err := ioutil.TempFile(l.path, ".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, r)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	// files are served directly, they need to be readable by the web server.
+	err = os.Chmod(file.Name(), 0644)
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	// renaming makes sure a partially written blob is never served.
+	err = os.Rename(file.Name(), l.file(name))
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	return nil
+}
+
+func (l *Local) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	file, err := os.Open(l.file(name))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return file, err
+}
+
+func (l *Local) Delete(_ context.Context, name string) error {
+	err := os.Remove(l.file(name))
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+func (l *Local) List(_ context.Context) ([]string, error) {
+	entries, err := ioutil.ReadDir(l.path)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		names = append(names, entry.Name())
+	}
+
+	return names, nil
+}
+
+func (l *Local) file(name string) string {
+	return filepath.Join(l.path, filepath.Base(name))
+}
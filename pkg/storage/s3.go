@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+)
+
+// partSize is the size of the parts uploads are split into, it bounds how much of a blob is held in memory.
+const partSize = 16 << 20
+
+// S3 stores blobs in a bucket of an S3-compatible object store like AWS S3 or MinIO.
+// Buckets are always addressed path-style, blobs are stored with the configured prefix.
+type S3 struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+func NewS3(config conf.StorageConf) (*S3, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	client, err := minio.New(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
+		Secure:       endpoint.Scheme == "https",
+		Region:       region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3{client: client, bucket: config.Bucket, prefix: config.Prefix}, nil
+}
+
+func (s *S3) Put(ctx context.Context, name string, r io.Reader, contentType string) error {
+	// the size is unknown, so the blob is streamed as a multipart upload unless it fits into a single part.
+	_, err := s.client.PutObject(ctx, s.bucket, s.prefix+name, r, -1, minio.PutObjectOptions{
+		ContentType: contentType,
+		PartSize:    partSize,
+	})
+
+	return err
+}
+
+func (s *S3) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, s.prefix+name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	// objects are fetched lazily, so a missing one is only noticed once it is used.
+	_, err = object.Stat()
+	if err != nil {
+		_ = object.Close()
+		return nil, convertError(err)
+	}
+
+	return object, nil
+}
+
+func (s *S3) Delete(ctx context.Context, name string) error {
+	// S3 does not report whether a deleted object existed, so it has to be checked first.
+	_, err := s.client.StatObject(ctx, s.bucket, s.prefix+name, minio.StatObjectOptions{})
+	if err != nil {
+		return convertError(err)
+	}
+
+	return s.client.RemoveObject(ctx, s.bucket, s.prefix+name, minio.RemoveObjectOptions{})
+}
+
+func (s *S3) List(ctx context.Context) ([]string, error) {
+	names := make([]string, 0)
+
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+
+		names = append(names, strings.TrimPrefix(object.Key, s.prefix))
+	}
+
+	return names, nil
+}
+
+func (s *S3) PresignedURL(name string, expires time.Duration) (string, error) {
+	u, err := s.client.PresignedGetObject(context.Background(), s.bucket, s.prefix+name, expires, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
+func convertError(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NotFound":
+		return ErrNotFound
+	default:
+		return err
+	}
+}
@@ -0,0 +1,83 @@
+// Package storage contains the blob stores media like images and stories are kept in.
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+)
+
+// ErrNotFound is returned when a blob does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Blob stores named files.
+type Blob interface {
+	// Put stores the content of r under name, replacing any existing blob.
+	Put(ctx context.Context, name string, r io.Reader, contentType string) error
+
+	// Get opens a blob for reading, it returns ErrNotFound if it does not exist.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+
+	// Delete removes a blob, it returns ErrNotFound if it does not exist.
+	Delete(ctx context.Context, name string) error
+
+	// List returns the names of all stored blobs.
+	List(ctx context.Context) ([]string, error)
+}
+
+// Presigner is implemented by stores that can grant temporary access to a private blob.
+type Presigner interface {
+	// PresignedURL returns a URL the blob can be downloaded from until it expires.
+	PresignedURL(name string, expires time.Duration) (string, error)
+}
+
+// Open returns the store described by a configuration, stores are local unless configured otherwise.
+func Open(config conf.StorageConf) (Blob, error) {
+	switch config.Type {
+	case "", "local":
+		return NewLocal(config.Path), nil
+	case "s3":
+		return NewS3(config)
+	default:
+		return nil, fmt.Errorf("unknown storage type %s", config.Type)
+	}
+}
+
+// ContentType returns the content type for a blob based on its name.
+func ContentType(name string) string {
+	ext := filepath.Ext(name)
+
+	// not every system knows these, so they are not left to the mime package.
+	switch ext {
+	case ".aac":
+		return "audio/aac"
+	case ".webp":
+		return "image/webp"
+	}
+
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return contentType
+	}
+
+	return "application/octet-stream"
+}
+
+// NewName returns a random name for a new blob with the given extension.
+func NewName(ext string) (string, error) {
+	b := make([]byte, 8)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatUint(binary.BigEndian.Uint64(b), 10) + ext, nil
+}
@@ -0,0 +1,207 @@
+package storage_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/conf"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
+)
+
+func TestLocal(t *testing.T) {
+	testBlob(t, storage.NewLocal(t.TempDir()))
+}
+
+// TestS3 runs against an existing bucket of an S3-compatible store, for example a local MinIO:
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//	STORAGE_S3_ENDPOINT=http://localhost:9000 STORAGE_S3_BUCKET=test go test ./pkg/storage
+func TestS3(t *testing.T) {
+	endpoint := os.Getenv("STORAGE_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("STORAGE_S3_ENDPOINT is not set")
+	}
+
+	blob, err := storage.NewS3(conf.StorageConf{
+		Endpoint:  endpoint,
+		Bucket:    os.Getenv("STORAGE_S3_BUCKET"),
+		Prefix:    "test/",
+		AccessKey: getenv("STORAGE_S3_ACCESS_KEY", "minioadmin"),
+		SecretKey: getenv("STORAGE_S3_SECRET_KEY", "minioadmin"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testBlob(t, blob)
+
+	err = blob.Put(context.Background(), "presigned.txt", strings.NewReader("foo"), "text/plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := blob.PresignedURL("presigned.txt", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 actual %d", resp.StatusCode)
+	}
+
+	_ = blob.Delete(context.Background(), "presigned.txt")
+}
+
+func TestContentType(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
+	}{
+		{"123.png", "image/png"},
+		{"123_64.webp", "image/webp"},
+		{"story.aac", "audio/aac"},
+		{"unknown", "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := storage.ContentType(tt.name)
+			if result != tt.expected {
+				t.Fatalf("expected %s actual %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func testBlob(t *testing.T, blob storage.Blob) {
+	ctx := context.Background()
+
+	err := blob.Put(ctx, "foo.txt", strings.NewReader("bar"), "text/plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := blob.Get(ctx, "foo.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadAll(r)
+	_ = r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "bar" {
+		t.Fatalf("expected bar actual %s", data)
+	}
+
+	names, err := blob.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 1 || names[0] != "foo.txt" {
+		t.Fatalf("unexpected blobs %v", names)
+	}
+
+	err = blob.Delete(ctx, "foo.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = blob.Get(ctx, "foo.txt")
+	if err != storage.ErrNotFound {
+		t.Fatalf("expected ErrNotFound actual %v", err)
+	}
+
+	err = blob.Delete(ctx, "foo.txt")
+	if err != storage.ErrNotFound {
+		t.Fatalf("expected ErrNotFound actual %v", err)
+	}
+}
+
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+
+	src := storage.NewLocal(t.TempDir())
+	dst := storage.NewLocal(t.TempDir())
+
+	for _, name := range []string{"1.png", "2.aac"} {
+		err := src.Put(ctx, name, strings.NewReader(name), storage.ContentType(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := dst.Put(ctx, "1.png", strings.NewReader("1.png"), "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := storage.Copy(ctx, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Fatalf("expected 1 copied blob actual %d", count)
+	}
+
+	names, err := dst.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 2 {
+		t.Fatalf("unexpected blobs %v", names)
+	}
+}
+
+func TestS3_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusNotFound)
+
+		if r.Method != http.MethodHead {
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+		}
+	}))
+	defer server.Close()
+
+	blob, err := storage.NewS3(conf.StorageConf{Endpoint: server.URL, Bucket: "test", AccessKey: "key", SecretKey: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = blob.Get(context.Background(), "missing")
+	if err != storage.ErrNotFound {
+		t.Fatalf("unexpected get err %v", err)
+	}
+
+	err = blob.Delete(context.Background(), "missing")
+	if err != storage.ErrNotFound {
+		t.Fatalf("unexpected delete err %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package stories
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// MaxSize is the largest story upload in bytes.
+	MaxSize = 10 << 20
+
+	// MaxDuration is the longest a story can be.
+	MaxDuration = 5 * time.Minute
+
+	// MaxReplyDuration is the longest a reply to a story can be.
+	MaxReplyDuration = time.Minute
+
+	// PeakCount is the number of peaks generated for the waveform of a story.
+	PeakCount = 100
+
+	// peaksSampleRate is the sample rate stories are decoded at to generate their peaks.
+	peaksSampleRate = 8000
+
+	// transcodeMargin is how much longer than the limit audio is transcoded, so that uploads above the limit can be
+	// told apart from uploads that are exactly as long as it.
+	transcodeMargin = time.Second
+
+	// durationSlack is how much the transcoded audio may exceed the limit, as the AAC encoder pads it slightly.
+	durationSlack = 100 * time.Millisecond
+)
+
+var (
+	ErrInvalidAudio  = errors.New("invalid audio")
+	ErrAudioTooLarge = errors.New("audio too large")
+	ErrAudioTooLong  = errors.New("audio too long")
+)
+
+// supportedCodecs are the codecs stories may be uploaded in, as named by ffprobe.
+var supportedCodecs = map[string]bool{"aac": true, "opus": true}
+
+// Audio is a story transcoded into the format it is served in.
+type Audio struct {
+	// Data contains mono AAC-LC in an ADTS stream, loudness normalised for speech.
+	Data []byte
+
+	Duration time.Duration
+
+	// Peaks contains the loudest sample of every segment of the story from 0 to 100.
+	Peaks []int
+}
+
+// ProcessAudio validates an uploaded story or reply no longer than max and transcodes it using ffmpeg.
+func ProcessAudio(ctx context.Context, data []byte, max time.Duration) (*Audio, error) {
+	if len(data) > MaxSize {
+		return nil, ErrAudioTooLarge
+	}
+
+	dir, err := ioutil.TempDir("", "story")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "upload")
+	dst := filepath.Join(dir, "story.aac")
+
+	err = ioutil.WriteFile(src, data, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := exec.CommandContext(ctx, "ffprobe", "-v", "error", "-show_entries", "stream=codec_type,codec_name", "-of", "json", src).Output()
+	if err != nil {
+		// ffprobe fails on anything it can not parse as media.
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil, ErrInvalidAudio
+		}
+
+		return nil, err
+	}
+
+	err = validateProbe(out)
+	if err != nil {
+		return nil, err
+	}
+
+	// the duration ffprobe reports is only an estimate for some formats like ADTS, so the length of the transcoded
+	// audio is checked instead, transcoding stops shortly after the limit.
+	out, err = exec.CommandContext(
+		ctx, "ffmpeg", "-v", "error", "-i", src,
+		"-t", strconv.FormatFloat((max+transcodeMargin).Seconds(), 'f', 3, 64),
+		"-map", "0:a:0", "-map_metadata", "-1",
+		"-af", "loudnorm=I=-16:TP=-1.5:LRA=11",
+		"-ac", "1", "-ar", "44100", "-c:a", "aac", "-b:a", "64k",
+		"-f", "adts", dst,
+	).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, out)
+	}
+
+	transcoded, err := ioutil.ReadFile(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	samples, err := exec.CommandContext(
+		ctx, "ffmpeg", "-v", "error", "-i", dst,
+		"-ac", "1", "-ar", strconv.Itoa(peaksSampleRate), "-f", "s16le", "-",
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg failed to decode peaks: %w", err)
+	}
+
+	duration := sampleDuration(samples, peaksSampleRate)
+	if duration == 0 {
+		return nil, ErrInvalidAudio
+	}
+
+	if duration > max+durationSlack {
+		return nil, ErrAudioTooLong
+	}
+
+	return &Audio{Data: transcoded, Duration: duration, Peaks: peaks(samples, PeakCount)}, nil
+}
+
+// validateProbe fails with ErrInvalidAudio unless the JSON output of ffprobe describes a single supported audio stream.
+func validateProbe(data []byte) error {
+	probe := struct {
+		Streams []struct {
+			CodecType string `json:"codec_type"`
+			CodecName string `json:"codec_name"`
+		} `json:"streams"`
+	}{}
+
+	err := json.Unmarshal(data, &probe)
+	if err != nil {
+		return err
+	}
+
+	if len(probe.Streams) != 1 {
+		return ErrInvalidAudio
+	}
+
+	stream := probe.Streams[0]
+	if stream.CodecType != "audio" || !supportedCodecs[stream.CodecName] {
+		return ErrInvalidAudio
+	}
+
+	return nil
+}
+
+// sampleDuration returns how long little-endian 16-bit mono samples at rate play for.
+func sampleDuration(samples []byte, rate int) time.Duration {
+	return time.Duration(len(samples)/2) * time.Second / time.Duration(rate)
+}
+
+// peaks splits little-endian 16-bit samples into n segments and returns the loudest sample of each from 0 to 100.
+func peaks(samples []byte, n int) []int {
+	result := make([]int, n)
+
+	count := len(samples) / 2
+	if count == 0 {
+		return result
+	}
+
+	r := bytes.NewReader(samples)
+	values := make([]int16, count)
+	_ = binary.Read(r, binary.LittleEndian, values)
+
+	for i := range result {
+		start, end := i*count/n, (i+1)*count/n
+
+		var peak float64
+		for _, sample := range values[start:end] {
+			peak = math.Max(peak, math.Abs(float64(sample)))
+		}
+
+		result[i] = int(math.Round(math.Min(peak/math.MaxInt16, 1) * 100))
+	}
+
+	return result
+}
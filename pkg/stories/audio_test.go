@@ -0,0 +1,88 @@
+package stories
+
+import (
+	"context"
+	"encoding/binary"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateProbe(t *testing.T) {
+	var tests = []struct {
+		name  string
+		probe string
+		err   error
+	}{
+		{"aac", `{"streams": [{"codec_name": "aac", "codec_type": "audio"}]}`, nil},
+		{"opus", `{"streams": [{"codec_name": "opus", "codec_type": "audio"}]}`, nil},
+		{"mp3", `{"streams": [{"codec_name": "mp3", "codec_type": "audio"}]}`, ErrInvalidAudio},
+		{
+			"video",
+			`{"streams": [{"codec_name": "h264", "codec_type": "video"}, {"codec_name": "aac", "codec_type": "audio"}]}`,
+			ErrInvalidAudio,
+		},
+		{"no streams", `{"streams": []}`, ErrInvalidAudio},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProbe([]byte(tt.probe))
+			if err != tt.err {
+				t.Fatalf("expected err %v actual %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSampleDuration(t *testing.T) {
+	duration := sampleDuration(make([]byte, 2*12000), 8000)
+	if duration != 1500*time.Millisecond {
+		t.Fatalf("expected duration %v actual %v", 1500*time.Millisecond, duration)
+	}
+}
+
+func TestPeaks(t *testing.T) {
+	samples := make([]byte, 8)
+	for i, sample := range []int16{100, -32767, 16384, 0} {
+		binary.LittleEndian.PutUint16(samples[i*2:], uint16(sample))
+	}
+
+	result := peaks(samples, 2)
+
+	expected := []int{100, 50}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v actual %v", expected, result)
+	}
+}
+
+func TestProcessAudio(t *testing.T) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+
+	data, err := exec.Command("ffmpeg", "-v", "error", "-f", "lavfi", "-i", "sine=duration=2", "-c:a", "aac", "-f", "adts", "-").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	audio, err := ProcessAudio(context.Background(), data, MaxDuration)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if audio.Duration < time.Second || len(audio.Peaks) != PeakCount {
+		t.Fatalf("unexpected audio duration %v peaks %d", audio.Duration, len(audio.Peaks))
+	}
+
+	_, err = ProcessAudio(context.Background(), data, time.Second)
+	if err != ErrAudioTooLong {
+		t.Fatalf("expected ErrAudioTooLong actual %v", err)
+	}
+
+	_, err = ProcessAudio(context.Background(), []byte("not audio"), MaxDuration)
+	if err != ErrInvalidAudio {
+		t.Fatalf("expected ErrInvalidAudio actual %v", err)
+	}
+}
@@ -1,10 +1,19 @@
 package stories
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 )
 
+// ErrStoryNotFound is returned when a story does not exist or is not owned by the user accessing it.
+var ErrStoryNotFound = errors.New("story not found")
+
 type Backend struct {
 	db *sql.DB
 }
@@ -14,13 +23,13 @@ func NewBackend(db *sql.DB) *Backend {
 }
 
 // DeleteExpired deletes all stories where the expire_at time has passed and returns their IDs.
-func (b *Backend) DeleteExpired(time int64) ([]string, error) {
-	stmt, err := b.db.Prepare("DELETE FROM stories WHERE expires_at <= $1 RETURNING id;")
+func (b *Backend) DeleteExpired(ctx context.Context, time int64) ([]string, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "DELETE FROM stories WHERE expires_at <= $1 RETURNING id;")
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(time)
+	rows, err := stmt.QueryContext(ctx, time)
 
 	result := make([]string, 0)
 	if err != nil {
@@ -41,13 +50,19 @@ func (b *Backend) DeleteExpired(time int64) ([]string, error) {
 	return result, nil
 }
 
-func (b *Backend) GetStoriesForUser(user int, time int64) ([]*Story, error) {
-	stmt, err := b.db.Prepare("SELECT id, expires_at, device_timestamp FROM stories WHERE user_id = $1 AND expires_at >= $2 ORDER BY device_timestamp;")
+// GetStoriesForUser returns the stories of user as seen by requester, view counts are only included for the owner.
+func (b *Backend) GetStoriesForUser(ctx context.Context, user, requester int, time int64) ([]*Story, error) {
+	query := `SELECT stories.id, stories.expires_at, stories.device_timestamp, stories.duration, stories.peaks,
+       CASE WHEN stories.user_id = $3 THEN (SELECT COUNT(*) FROM story_views WHERE story_views.story_id = stories.id) END AS views,
+       EXISTS (SELECT 1 FROM story_views WHERE story_views.story_id = stories.id AND story_views.user_id = $3) AS heard
+       FROM stories WHERE stories.user_id = $1 AND stories.expires_at >= $2 ORDER BY stories.device_timestamp;`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(user, time)
+	rows, err := stmt.QueryContext(ctx, user, time, requester)
 	if err != nil {
 		return nil, err
 	}
@@ -57,12 +72,24 @@ func (b *Backend) GetStoriesForUser(user int, time int64) ([]*Story, error) {
 	for rows.Next() {
 		story := &Story{}
 
-		err := rows.Scan(&story.ID, &story.ExpiresAt, &story.DeviceTimestamp)
+		var peaks []byte
+		var views sql.NullInt64
+		err := rows.Scan(&story.ID, &story.ExpiresAt, &story.DeviceTimestamp, &story.Duration, &peaks, &views, &story.Heard)
 		if err != nil {
 			return nil, err // @todo
 		}
 
-		reactions, err := b.GetReactions(story.ID)
+		if views.Valid {
+			count := int(views.Int64)
+			story.Views = &count
+		}
+
+		err = json.Unmarshal(peaks, &story.Peaks)
+		if err != nil {
+			return nil, err
+		}
+
+		reactions, err := b.GetReactions(ctx, story.ID)
 		if err != nil {
 			continue
 		}
@@ -75,13 +102,23 @@ func (b *Backend) GetStoriesForUser(user int, time int64) ([]*Story, error) {
 	return result, nil
 }
 
-func (b *Backend) GetStoriesForFollower(user int, time int64) (map[int][]Story, error) {
-	stmt, err := b.db.Prepare("SELECT stories.user_id, stories.id, stories.expires_at, stories.device_timestamp FROM stories INNER JOIN followers ON (stories.user_id = followers.user_id) WHERE followers.follower = $1 AND stories.expires_at >= $2;")
+// GetStoriesForFollower returns the stories of up to limit users followed by user, keyed by user ID.
+// Stories are marked as heard if user already listened to them.
+// Users are ordered by ID, starting after the user with the ID after.
+func (b *Backend) GetStoriesForFollower(ctx context.Context, user int, time int64, after, limit int) (map[int][]Story, error) {
+	query := `SELECT stories.user_id, stories.id, stories.expires_at, stories.device_timestamp, stories.duration, stories.peaks,
+       EXISTS (SELECT 1 FROM story_views WHERE story_views.story_id = stories.id AND story_views.user_id = $1) AS heard
+       FROM stories WHERE stories.expires_at >= $2 AND stories.user_id IN (
+		SELECT DISTINCT stories.user_id FROM stories INNER JOIN followers ON (stories.user_id = followers.user_id)
+		WHERE followers.follower = $1 AND stories.expires_at >= $2 AND stories.user_id > $3 ORDER BY stories.user_id LIMIT $4
+	);`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(user, time)
+	rows, err := stmt.QueryContext(ctx, user, time, after, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -92,12 +129,18 @@ func (b *Backend) GetStoriesForFollower(user int, time int64) (map[int][]Story,
 		story := Story{}
 
 		var user int
-		err := rows.Scan(&user, &story.ID, &story.ExpiresAt, &story.DeviceTimestamp)
+		var peaks []byte
+		err := rows.Scan(&user, &story.ID, &story.ExpiresAt, &story.DeviceTimestamp, &story.Duration, &peaks, &story.Heard)
 		if err != nil {
 			return nil, err // @todo
 		}
 
-		reactions, err := b.GetReactions(story.ID)
+		err = json.Unmarshal(peaks, &story.Peaks)
+		if err != nil {
+			return nil, err
+		}
+
+		reactions, err := b.GetReactions(ctx, story.ID)
 		if err != nil {
 			continue
 		}
@@ -115,59 +158,321 @@ func (b *Backend) GetStoriesForFollower(user int, time int64) (map[int][]Story,
 	return result, nil
 }
 
-func (b *Backend) DeleteStory(story string, user int) error {
-	query := "DELETE FROM stories WHERE id = $1 AND user_id = $2;"
+// DeleteStory deletes a story owned by user along with its replies, it returns the IDs of the deleted replies.
+func (b *Backend) DeleteStory(ctx context.Context, story string, user int) ([]string, error) {
+	var replies []string
+
+	err := sqlutil.Transaction(ctx, b.db, func(ctx context.Context) error {
+		var err error
+		replies, err = b.deleteReplies(ctx, story, user)
+		if err != nil {
+			return err
+		}
 
-	stmt, err := b.db.Prepare(query)
+		stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "DELETE FROM stories WHERE id = $1 AND user_id = $2;")
+		if err != nil {
+			return err
+		}
+
+		res, err := stmt.ExecContext(ctx, story, user)
+		if err != nil {
+			return err
+		}
+
+		count, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if count != 1 {
+			return errors.New("no story deleted")
+		}
+
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	res, err := stmt.Exec(story, user)
+	return replies, nil
+}
+
+func (b *Backend) AddStory(ctx context.Context, story string, user int, expires, timestamp int64, audio *Audio) error {
+	peaks, err := json.Marshal(audio.Peaks)
 	if err != nil {
 		return err
 	}
 
-	count, err := res.RowsAffected()
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO stories (id, user_id, expires_at, device_timestamp, duration, peaks) VALUES ($1, $2, $3, $4, $5, $6);")
 	if err != nil {
 		return err
 	}
 
-	if count != 1 {
-		return errors.New("no story deleted")
+	_, err = stmt.ExecContext(ctx, story, user, expires, timestamp, audio.Duration.Milliseconds(), peaks)
+	return err
+}
+
+func (b *Backend) ReactToStory(ctx context.Context, story, reaction string, user int) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO story_reactions (story_id, user_id, reaction) VALUES ($1, $2, $3);")
+	if err != nil {
+		return err
 	}
 
-	return nil
+	_, err = stmt.ExecContext(ctx, story, user, reaction)
+	return err
 }
 
-func (b *Backend) AddStory(story string, user int, expires, timestamp int64) error {
-	stmt, err := b.db.Prepare("INSERT INTO stories (id, user_id, expires_at, device_timestamp) VALUES ($1, $2, $3, $4);")
+// ViewStory records that user listened to a story,
+// views by the owner and by users who blocked or were blocked by the owner are not recorded.
+func (b *Backend) ViewStory(ctx context.Context, story string, user int) error {
+	query := `INSERT INTO story_views (story_id, user_id) SELECT id, $2 FROM stories WHERE id = $1 AND user_id != $2
+		AND NOT EXISTS (
+			SELECT 1 FROM blocks WHERE (blocks.user_id = stories.user_id AND blocks.blocked = $2) OR (blocks.user_id = $2 AND blocks.blocked = stories.user_id)
+		)
+		ON CONFLICT (story_id, user_id) DO NOTHING;`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(story, user, expires, timestamp)
+	_, err = stmt.ExecContext(ctx, story, user)
 	return err
 }
 
-func (b *Backend) ReactToStory(story, reaction string, user int) error {
-	stmt, err := b.db.Prepare("INSERT INTO story_reactions (story_id, user_id, reaction) VALUES ($1, $2, $3);")
+// GetViewers returns up to limit users that listened to a story owned by owner, most recent first.
+// If before is set only views older than before, or at the same time by a user with a lower ID than beforeID, are returned.
+// It returns ErrStoryNotFound if owner does not own the story.
+func (b *Backend) GetViewers(ctx context.Context, story string, owner int, before *time.Time, beforeID, limit int) ([]Viewer, error) {
+	id, err := b.GetOwner(ctx, story)
+	if err != nil {
+		return nil, err
+	}
+
+	if id != owner {
+		return nil, ErrStoryNotFound
+	}
+
+	query := `SELECT users.id, users.display_name, users.username, users.image, story_reactions.reaction, story_views.viewed_at
+		FROM story_views
+		INNER JOIN users ON users.id = story_views.user_id
+		LEFT JOIN story_reactions ON story_reactions.story_id = story_views.story_id AND story_reactions.user_id = story_views.user_id
+		WHERE story_views.story_id = $1 AND users.deletion_scheduled_at IS NULL
+		AND ($2::TIMESTAMPTZ IS NULL OR (story_views.viewed_at, story_views.user_id) < ($2, $3))
+		ORDER BY story_views.viewed_at DESC, story_views.user_id DESC LIMIT $4;`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, story, before, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]Viewer, 0)
+	for rows.Next() {
+		viewer := Viewer{}
+
+		var reaction sql.NullString
+		var viewed time.Time
+		err := rows.Scan(&viewer.ID, &viewer.DisplayName, &viewer.Username, &viewer.Image, &reaction, &viewed)
+		if err != nil {
+			return nil, err
+		}
+
+		if reaction.Valid {
+			viewer.Reaction = &reaction.String
+		}
+
+		viewer.Images = images.Variants(viewer.Image)
+		viewer.ViewedAt = viewed.Unix()
+		viewer.viewedAt = viewed
+
+		result = append(result, viewer)
+	}
+
+	return result, rows.Err()
+}
+
+// GetOwner returns the ID of the user that created a story, or ErrStoryNotFound if it does not exist.
+func (b *Backend) GetOwner(ctx context.Context, story string) (int, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT user_id FROM stories WHERE id = $1;")
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = stmt.QueryRowContext(ctx, story).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, ErrStoryNotFound
+	}
+
+	return id, err
+}
+
+// AddReply stores a reply by user to a story.
+func (b *Backend) AddReply(ctx context.Context, reply, story string, user int, audio *Audio) error {
+	peaks, err := json.Marshal(audio.Peaks)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO story_replies (id, story_id, user_id, duration, peaks) VALUES ($1, $2, $3, $4, $5);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(story, user, reaction)
+	_, err = stmt.ExecContext(ctx, reply, story, user, audio.Duration.Milliseconds(), peaks)
 	return err
 }
 
-func (b *Backend) GetReactions(story string) ([]Reaction, error) {
+// GetReplies returns up to limit replies to a story owned by owner, most recent first.
+// Replies by users the owner has blocked are left out.
+// If before is set only replies older than before, or at the same time with a lower ID than beforeID, are returned.
+// It returns ErrStoryNotFound if owner does not own the story.
+func (b *Backend) GetReplies(ctx context.Context, story string, owner int, before *time.Time, beforeID string, limit int) ([]Reply, error) {
+	id, err := b.GetOwner(ctx, story)
+	if err != nil {
+		return nil, err
+	}
+
+	if id != owner {
+		return nil, ErrStoryNotFound
+	}
+
+	query := `SELECT story_replies.id, story_replies.duration, story_replies.peaks, story_replies.created_at,
+		users.id, users.display_name, users.username, users.image
+		FROM story_replies
+		INNER JOIN users ON users.id = story_replies.user_id
+		WHERE story_replies.story_id = $1 AND users.deletion_scheduled_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM blocks WHERE blocks.user_id = $2 AND blocks.blocked = story_replies.user_id)
+		AND ($3::TIMESTAMPTZ IS NULL OR (story_replies.created_at, story_replies.id) < ($3, $4))
+		ORDER BY story_replies.created_at DESC, story_replies.id DESC LIMIT $5;`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, story, owner, before, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]Reply, 0)
+	for rows.Next() {
+		reply := Reply{}
+
+		var peaks []byte
+		var created time.Time
+		err := rows.Scan(
+			&reply.ID, &reply.Duration, &peaks, &created,
+			&reply.User.ID, &reply.User.DisplayName, &reply.User.Username, &reply.User.Image,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(peaks, &reply.Peaks)
+		if err != nil {
+			return nil, err
+		}
+
+		reply.User.Images = images.Variants(reply.User.Image)
+		reply.CreatedAt = created.Unix()
+		reply.createdAt = created
+
+		result = append(result, reply)
+	}
+
+	return result, rows.Err()
+}
+
+// IsReplyRecipient returns whether user created the story a reply was sent to,
+// replies by users they have since blocked are not considered theirs.
+func (b *Backend) IsReplyRecipient(ctx context.Context, reply string, user int) (bool, error) {
+	query := `SELECT EXISTS (
+		SELECT 1 FROM story_replies INNER JOIN stories ON stories.id = story_replies.story_id
+		WHERE story_replies.id = $1 AND stories.user_id = $2
+		AND NOT EXISTS (SELECT 1 FROM blocks WHERE blocks.user_id = $2 AND blocks.blocked = story_replies.user_id)
+	);`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
+	if err != nil {
+		return false, err
+	}
+
+	var recipient bool
+	err = stmt.QueryRowContext(ctx, reply, user).Scan(&recipient)
+	return recipient, err
+}
+
+// deleteReplies deletes the replies to a story owned by user and returns their IDs.
+func (b *Backend) deleteReplies(ctx context.Context, story string, user int) ([]string, error) {
+	query := `DELETE FROM story_replies USING stories
+		WHERE story_replies.story_id = stories.id AND stories.id = $1 AND stories.user_id = $2 RETURNING story_replies.id;`
+
+	return b.queryIDs(ctx, query, story, user)
+}
+
+// DeleteExpiredReplies deletes the replies to all stories where the expire_at time has passed and returns their IDs.
+func (b *Backend) DeleteExpiredReplies(ctx context.Context, time int64) ([]string, error) {
+	query := `DELETE FROM story_replies USING stories
+		WHERE story_replies.story_id = stories.id AND stories.expires_at <= $1 RETURNING story_replies.id;`
+
+	return b.queryIDs(ctx, query, time)
+}
+
+// GetRepliesInvolvingUser returns the IDs of all replies sent by user or to the stories of user.
+func (b *Backend) GetRepliesInvolvingUser(ctx context.Context, user int) ([]string, error) {
+	query := `SELECT story_replies.id FROM story_replies INNER JOIN stories ON stories.id = story_replies.story_id
+		WHERE story_replies.user_id = $1 OR stories.user_id = $1;`
+
+	return b.queryIDs(ctx, query, user)
+}
+
+func (b *Backend) queryIDs(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]string, 0)
+	for rows.Next() {
+		var id string
+
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, id)
+	}
+
+	return result, rows.Err()
+}
+
+func (b *Backend) GetReactions(ctx context.Context, story string) ([]Reaction, error) {
 	reactions := make([]Reaction, 0)
-	stmt, err := b.db.Prepare("SELECT reaction, COUNT(*) FROM story_reactions WHERE story_id = $1 GROUP BY reaction;")
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT reaction, COUNT(*) FROM story_reactions WHERE story_id = $1 GROUP BY reaction;")
 	if err != nil {
 		return reactions, err
 	}
 
-	rows, err := stmt.Query(story)
+	rows, err := stmt.QueryContext(ctx, story)
 	if err != nil {
 		return reactions, err
 	}
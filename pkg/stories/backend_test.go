@@ -0,0 +1,202 @@
+package stories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/soapboxsocial/soapbox/pkg/stories"
+)
+
+func TestBackend_ViewStory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := stories.NewBackend(db)
+
+	mock.ExpectPrepare("^INSERT INTO story_views").ExpectExec().
+		WithArgs("123", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = backend.ViewStory(context.Background(), "123", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackend_ViewStory_Blocked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := stories.NewBackend(db)
+
+	// blocks in either direction prevent the view from being recorded.
+	mock.ExpectPrepare(`^INSERT INTO story_views .+ AND NOT EXISTS \(\s*SELECT 1 FROM blocks WHERE \(blocks.user_id = stories.user_id AND blocks.blocked = \$2\) OR \(blocks.user_id = \$2 AND blocks.blocked = stories.user_id\)`).
+		ExpectExec().
+		WithArgs("123", 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = backend.ViewStory(context.Background(), "123", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackend_GetViewers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := stories.NewBackend(db)
+
+	mock.ExpectPrepare("^SELECT user_id FROM stories").ExpectQuery().
+		WithArgs("123").
+		WillReturnRows(mock.NewRows([]string{"user_id"}).AddRow(1))
+
+	mock.ExpectPrepare("^SELECT users.id").ExpectQuery().
+		WithArgs("123", nil, 0, 10).
+		WillReturnRows(
+			mock.NewRows([]string{"id", "display_name", "username", "image", "reaction", "viewed_at"}).
+				AddRow(2, "dean", "dean", "123.png", "🔥", time.Unix(100, 0)).
+				AddRow(3, "jeff", "jeff", "", nil, time.Unix(50, 0)),
+		)
+
+	viewers, err := backend.GetViewers(context.Background(), "123", 1, nil, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(viewers) != 2 {
+		t.Fatalf("expected 2 viewers actual %d", len(viewers))
+	}
+
+	if viewers[0].Reaction == nil || *viewers[0].Reaction != "🔥" || viewers[1].Reaction != nil {
+		t.Fatalf("unexpected reactions %v", viewers)
+	}
+
+	if viewers[0].ViewedAt != 100 {
+		t.Fatalf("expected viewed_at 100 actual %d", viewers[0].ViewedAt)
+	}
+}
+
+func TestBackend_GetViewers_NotOwner(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := stories.NewBackend(db)
+
+	mock.ExpectPrepare("^SELECT user_id FROM stories").ExpectQuery().
+		WithArgs("123").
+		WillReturnRows(mock.NewRows([]string{"user_id"}).AddRow(2))
+
+	_, err = backend.GetViewers(context.Background(), "123", 1, nil, 0, 10)
+	if err != stories.ErrStoryNotFound {
+		t.Fatalf("expected ErrStoryNotFound actual %v", err)
+	}
+}
+
+func TestBackend_GetReplies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := stories.NewBackend(db)
+
+	mock.ExpectPrepare("^SELECT user_id FROM stories").ExpectQuery().
+		WithArgs("123").
+		WillReturnRows(mock.NewRows([]string{"user_id"}).AddRow(1))
+
+	mock.ExpectPrepare("^SELECT story_replies.id").ExpectQuery().
+		WithArgs("123", 1, nil, "", 10).
+		WillReturnRows(
+			mock.NewRows([]string{"id", "duration", "peaks", "created_at", "user_id", "display_name", "username", "image"}).
+				AddRow("456", 2000, []byte("[1,2]"), time.Unix(100, 0), 2, "dean", "dean", ""),
+		)
+
+	replies, err := backend.GetReplies(context.Background(), "123", 1, nil, "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(replies) != 1 || replies[0].ID != "456" || replies[0].User.ID != 2 || len(replies[0].Peaks) != 2 {
+		t.Fatalf("unexpected replies %v", replies)
+	}
+
+	if replies[0].CreatedAt != 100 {
+		t.Fatalf("expected created_at 100 actual %d", replies[0].CreatedAt)
+	}
+}
+
+func TestBackend_GetReplies_NotOwner(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := stories.NewBackend(db)
+
+	mock.ExpectPrepare("^SELECT user_id FROM stories").ExpectQuery().
+		WithArgs("123").
+		WillReturnRows(mock.NewRows([]string{"user_id"}).AddRow(2))
+
+	_, err = backend.GetReplies(context.Background(), "123", 1, nil, "", 10)
+	if err != stories.ErrStoryNotFound {
+		t.Fatalf("expected ErrStoryNotFound actual %v", err)
+	}
+}
+
+func TestBackend_DeleteStory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	backend := stories.NewBackend(db)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare("^DELETE FROM story_replies").ExpectQuery().
+		WithArgs("123", 1).
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow("456").AddRow("789"))
+	mock.ExpectPrepare("^DELETE FROM stories").ExpectExec().
+		WithArgs("123", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	replies, err := backend.DeleteStory(context.Background(), "123", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(replies) != 2 {
+		t.Fatalf("expected 2 replies actual %d", len(replies))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,26 +1,32 @@
 package stories
 
 import (
+	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/soapboxsocial/soapbox/pkg/blocks"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/storage"
 )
 
 type Endpoint struct {
 	backend *Backend
 	files   *FileBackend
+	replies *FileBackend
+	blocks  *blocks.Backend
 	queue   *pubsub.Queue
 }
 
-func NewEndpoint(backend *Backend, files *FileBackend, queue *pubsub.Queue) *Endpoint {
-	return &Endpoint{backend: backend, files: files, queue: queue}
+// NewEndpoint creates the stories endpoint, replies should be stored separately from stories as they are not public.
+func NewEndpoint(backend *Backend, files, replies *FileBackend, blocks *blocks.Backend, queue *pubsub.Queue) *Endpoint {
+	return &Endpoint{backend: backend, files: files, replies: replies, blocks: blocks, queue: queue}
 }
 
 func (e *Endpoint) Router() *mux.Router {
@@ -29,11 +35,19 @@ func (e *Endpoint) Router() *mux.Router {
 	r.Path("/upload").Methods("POST").HandlerFunc(e.UploadStory)
 	r.Path("/{id:[0-9]+}").Methods("DELETE").HandlerFunc(e.DeleteStory)
 	r.Path("/{id:[0-9]+}/react").Methods("POST").HandlerFunc(e.Reacted)
+	r.Path("/{id:[0-9]+}/view").Methods("POST").HandlerFunc(e.Viewed)
+	r.Path("/{id:[0-9]+}/viewers").Methods("GET").HandlerFunc(e.Viewers)
+	r.Path("/{id:[0-9]+}/reply").Methods("POST").HandlerFunc(e.Reply)
+	r.Path("/{id:[0-9]+}/replies").Methods("GET").HandlerFunc(e.Replies)
+	r.Path("/replies/{id:[0-9]+}").Methods("GET").HandlerFunc(e.ReplyAudio)
 
 	return r
 }
 
 func (e *Endpoint) UploadStory(w http.ResponseWriter, r *http.Request) {
+	// leaves room for the other form fields next to the story itself.
+	r.Body = http.MaxBytesReader(w, r.Body, MaxSize+(1<<20))
+
 	err := r.ParseMultipartForm(2 << 20)
 	if err != nil {
 		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "")
@@ -66,19 +80,28 @@ func (e *Endpoint) UploadStory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	name, err := e.files.Store(bytes)
+	audio, err := ProcessAudio(r.Context(), bytes, MaxDuration)
 	if err != nil {
-		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "no story")
+		writeAudioError(w, r, "story", err)
+		return
+	}
+
+	name, err := e.files.Store(r.Context(), audio.Data)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("files.Store err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 		return
 	}
 
-	err = e.backend.AddStory(IDFromName(name), userID, expires, timestamp)
+	err = e.backend.AddStory(r.Context(), IDFromName(name), userID, expires, timestamp, audio)
 	if err != nil {
+		_ = e.files.Remove(r.Context(), name)
+
 		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "no story")
 		return
 	}
 
-	_ = e.queue.Publish(pubsub.StoryTopic, pubsub.NewStoryCreationEvent(userID))
+	_ = e.queue.Publish(r.Context(), pubsub.StoryTopic, pubsub.NewStoryCreationEvent(userID))
 
 	// @TODO CLEANUP
 	httputil.JsonSuccess(w)
@@ -94,15 +117,22 @@ func (e *Endpoint) DeleteStory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := e.backend.DeleteStory(id, userID)
+	replies, err := e.backend.DeleteStory(r.Context(), id, userID)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
 		return
 	}
 
-	err = e.files.Remove(id + ".aac")
+	err = e.files.Remove(r.Context(), id+".aac")
 	if err != nil {
-		log.Printf("files.Remove err: %v\n", err)
+		log.Ctx(r.Context()).Printf("files.Remove err: %v", err)
+	}
+
+	for _, reply := range replies {
+		err := e.replies.Remove(r.Context(), reply+".aac")
+		if err != nil {
+			log.Ctx(r.Context()).Printf("replies.Remove err: %v", err)
+		}
 	}
 
 	httputil.JsonSuccess(w)
@@ -132,13 +162,304 @@ func (e *Endpoint) Reacted(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = e.backend.ReactToStory(id, reaction, userID)
+	err = e.backend.ReactToStory(r.Context(), id, reaction, userID)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
 		return
 	}
 
-	_ = e.queue.Publish(pubsub.StoryTopic, pubsub.NewStoryReactionEvent(userID))
+	owner, err := e.backend.GetOwner(r.Context(), id)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.GetOwner err: %v", err)
+	}
+
+	_ = e.queue.Publish(r.Context(), pubsub.StoryTopic, pubsub.NewStoryReactionEvent(userID, id, owner))
 
 	httputil.JsonSuccess(w)
 }
+
+func (e *Endpoint) Viewed(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	userID, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
+		return
+	}
+
+	err := e.backend.ViewStory(r.Context(), id, userID)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.ViewStory err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	httputil.JsonSuccess(w)
+}
+
+// viewerCursor is the position in a list of viewers, which is ordered by the time and user of the view.
+type viewerCursor struct {
+	ViewedAt time.Time `json:"viewed_at"`
+	ID       int       `json:"id"`
+}
+
+func (e *Endpoint) Viewers(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	userID, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
+		return
+	}
+
+	cursor := viewerCursor{}
+	ok, err := httputil.DecodeCursor(r.URL.Query(), &cursor)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
+		return
+	}
+
+	var before *time.Time
+	if ok {
+		before = &cursor.ViewedAt
+	}
+
+	limit := httputil.GetLimit(r.URL.Query())
+
+	viewers, err := e.backend.GetViewers(r.Context(), id, userID, before, cursor.ID, limit)
+	if err == ErrStoryNotFound {
+		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "story not found")
+		return
+	}
+
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.GetViewers err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	var next interface{}
+	if len(viewers) == limit {
+		last := viewers[len(viewers)-1]
+		next = viewerCursor{ViewedAt: last.viewedAt, ID: last.ID}
+	}
+
+	page, err := httputil.NewPage(viewers, next)
+	if err != nil {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = httputil.JsonEncode(w, page)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to write viewers response: %s", err.Error())
+	}
+}
+
+func (e *Endpoint) Reply(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxSize+(1<<20))
+
+	err := r.ParseMultipartForm(2 << 20)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+
+	userID, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
+		return
+	}
+
+	owner, err := e.backend.GetOwner(r.Context(), id)
+	if err == ErrStoryNotFound {
+		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "story not found")
+		return
+	}
+
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.GetOwner err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	if owner == userID {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "cannot reply to own story")
+		return
+	}
+
+	blocked, err := e.blocks.IsBlocked(r.Context(), userID, owner)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("blocks.IsBlocked err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	// blocked users should not learn about the block, so the story simply does not exist for them.
+	if blocked {
+		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "story not found")
+		return
+	}
+
+	file, _, err := r.FormFile("reply")
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "no reply")
+		return
+	}
+
+	bytes, err := ioutil.ReadAll(file)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "failed to upload")
+		return
+	}
+
+	audio, err := ProcessAudio(r.Context(), bytes, MaxReplyDuration)
+	if err != nil {
+		writeAudioError(w, r, "reply", err)
+		return
+	}
+
+	name, err := e.replies.Store(r.Context(), audio.Data)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("replies.Store err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = e.backend.AddReply(r.Context(), IDFromName(name), id, userID, audio)
+	if err != nil {
+		_ = e.replies.Remove(r.Context(), name)
+
+		log.Ctx(r.Context()).Printf("backend.AddReply err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = e.queue.Publish(r.Context(), pubsub.StoryTopic, pubsub.NewStoryReplyEvent(id, userID, owner))
+	if err != nil {
+		log.Ctx(r.Context()).Printf("queue.Publish err: %v", err)
+	}
+
+	httputil.JsonSuccess(w)
+}
+
+// replyCursor is the position in a list of replies, which is ordered by the time and ID of the reply.
+type replyCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+func (e *Endpoint) Replies(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	userID, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
+		return
+	}
+
+	cursor := replyCursor{}
+	ok, err := httputil.DecodeCursor(r.URL.Query(), &cursor)
+	if err != nil {
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
+		return
+	}
+
+	var before *time.Time
+	if ok {
+		before = &cursor.CreatedAt
+	}
+
+	limit := httputil.GetLimit(r.URL.Query())
+
+	replies, err := e.backend.GetReplies(r.Context(), id, userID, before, cursor.ID, limit)
+	if err == ErrStoryNotFound {
+		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "story not found")
+		return
+	}
+
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.GetReplies err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	var next interface{}
+	if len(replies) == limit {
+		last := replies[len(replies)-1]
+		next = replyCursor{CreatedAt: last.createdAt, ID: last.ID}
+	}
+
+	page, err := httputil.NewPage(replies, next)
+	if err != nil {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	err = httputil.JsonEncode(w, page)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to write replies response: %s", err.Error())
+	}
+}
+
+// ReplyAudio streams a reply to the creator of the story it was sent to, replies are never served publicly.
+func (e *Endpoint) ReplyAudio(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	userID, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
+		return
+	}
+
+	recipient, err := e.backend.IsReplyRecipient(r.Context(), id, userID)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("backend.IsReplyRecipient err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	if !recipient {
+		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "reply not found")
+		return
+	}
+
+	file, err := e.replies.Open(r.Context(), id+".aac")
+	if err == storage.ErrNotFound {
+		httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeNotFound, "reply not found")
+		return
+	}
+
+	if err != nil {
+		log.Ctx(r.Context()).Printf("replies.Open err: %v", err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+		return
+	}
+
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "audio/aac")
+	w.Header().Set("Cache-Control", "private")
+
+	_, err = io.Copy(w, file)
+	if err != nil {
+		log.Ctx(r.Context()).Printf("failed to write reply %s err: %v", id, err)
+	}
+}
+
+// writeAudioError writes the response for an upload of kind that ProcessAudio rejected.
+func writeAudioError(w http.ResponseWriter, r *http.Request, kind string, err error) {
+	switch err {
+	case ErrInvalidAudio:
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid audio")
+	case ErrAudioTooLarge:
+		httputil.JsonError(w, http.StatusRequestEntityTooLarge, httputil.ErrorCodeInvalidRequestBody, kind+" too large")
+	case ErrAudioTooLong:
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, kind+" too long")
+	default:
+		log.Ctx(r.Context()).Printf("failed to process %s err: %v", kind, err)
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
+	}
+}
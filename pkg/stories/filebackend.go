@@ -1,37 +1,42 @@
 package stories
 
 import (
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/soapboxsocial/soapbox/pkg/storage"
 )
 
 type FileBackend struct {
-	path string
+	blob storage.Blob
 }
 
-func NewFileBackend(path string) *FileBackend {
-	return &FileBackend{path: path}
+func NewFileBackend(blob storage.Blob) *FileBackend {
+	return &FileBackend{blob: blob}
 }
 
-// Store places a story in the designated directory
-func (fb *FileBackend) Store(bytes []byte) (string, error) {
-	file, err := ioutil.TempFile(fb.path, "*.aac")
+// Store places a story in the blob storage
+func (fb *FileBackend) Store(ctx context.Context, data []byte) (string, error) {
+	name, err := storage.NewName(".aac")
 	if err != nil {
 		return "", err
 	}
 
-	defer file.Close()
-
-	_, err = file.Write(bytes)
+	err = fb.blob.Put(ctx, name, bytes.NewReader(data), "audio/aac")
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
-	return filepath.Base(file.Name()), nil
+	return name, nil
+}
+
+// Open opens a stored story for reading
+func (fb *FileBackend) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	return fb.blob.Get(ctx, name)
 }
 
-// Remove permanently deletes a story from the file system
-func (fb *FileBackend) Remove(name string) error {
-	return os.Remove(fb.path + "/" + name)
+// Remove permanently deletes a story from the blob storage
+func (fb *FileBackend) Remove(ctx context.Context, name string) error {
+	return fb.blob.Delete(ctx, name)
 }
@@ -1,6 +1,10 @@
 package stories
 
-import "github.com/soapboxsocial/soapbox/pkg/users/types"
+import (
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/users/types"
+)
 
 // Reaction represents the reactions users submitted to the story.
 type Reaction struct {
@@ -13,7 +17,34 @@ type Story struct {
 	ID              string     `json:"id"`
 	ExpiresAt       int64      `json:"expires_at"`
 	DeviceTimestamp int64      `json:"device_timestamp"`
+	Duration        int64      `json:"duration"` // milliseconds
+	Peaks           []int      `json:"peaks"`
 	Reactions       []Reaction `json:"reactions"`
+	Views           *int       `json:"views,omitempty"` // only set for the owner
+	Heard           bool       `json:"heard"`
+}
+
+// Viewer represents a user that listened to a story, along with their reaction.
+type Viewer struct {
+	types.User
+
+	Reaction *string `json:"reaction,omitempty"`
+	ViewedAt int64   `json:"viewed_at"`
+
+	// viewedAt is the exact time of the view, which viewers are paginated by.
+	viewedAt time.Time
+}
+
+// Reply represents a voice message sent privately to the creator of a story.
+type Reply struct {
+	ID        string     `json:"id"`
+	User      types.User `json:"user"`
+	Duration  int64      `json:"duration"` // milliseconds
+	Peaks     []int      `json:"peaks"`
+	CreatedAt int64      `json:"created_at"`
+
+	// createdAt is the exact time of the reply, which replies are paginated by.
+	createdAt time.Time
 }
 
 // StoryFeed represents all of a users stories.
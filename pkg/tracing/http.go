@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+)
+
+// RequestIDKey is the span attribute containing the request ID.
+const RequestIDKey = "request.id"
+
+// Handler starts a server span for every request, continuing traces passed in the request headers.
+// The span is named after the method until a route is matched, see RouteMiddleware.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		ctx, span := Start(
+			ctx,
+			"HTTP "+r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("", "", r)...),
+		)
+		defer span.End()
+
+		if id, ok := httputil.GetRequestIDFromContext(ctx); ok {
+			span.SetAttributes(attribute.String(RequestIDKey, id))
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(recorder.status)...)
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(recorder.status))
+	})
+}
+
+// RouteMiddleware names the current span after the route template matched by the router.
+// The prefix is the path the router is mounted under.
+func RouteMiddleware(prefix string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			template, err := route.GetPathTemplate()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			template = prefix + template
+
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + template)
+			span.SetAttributes(semconv.HTTPRouteKey.String(template))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// statusRecorder captures the status code written by a handler.
+// It keeps supporting hijacking as the room server upgrades requests to websockets.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+
+	s.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
@@ -0,0 +1,78 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	httputil "github.com/soapboxsocial/soapbox/pkg/http"
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
+)
+
+func TestHandler(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	r := mux.NewRouter()
+	r.Use(tracing.RouteMiddleware("/v1/users"))
+	r.HandleFunc("/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		if !trace.SpanFromContext(r.Context()).IsRecording() {
+			t.Fatal("no span in request context")
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest("GET", "/12", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	rr := httptest.NewRecorder()
+	httputil.RequestID(tracing.Handler(r)).ServeHTTP(rr, req)
+
+	err := provider.ForceFlush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("unexpected amount of spans %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name != "GET /v1/users/{id:[0-9]+}" {
+		t.Fatalf("unexpected name %s", span.Name)
+	}
+
+	if span.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("trace was not continued %s", span.SpanContext.TraceID())
+	}
+
+	if span.Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Fatalf("unexpected parent %s", span.Parent.SpanID())
+	}
+
+	attributes := make(map[string]interface{})
+	for _, kv := range span.Attributes {
+		attributes[string(kv.Key)] = kv.Value.AsInterface()
+	}
+
+	if attributes["http.status_code"] != int64(http.StatusNotFound) {
+		t.Fatalf("unexpected status %v", attributes["http.status_code"])
+	}
+
+	if _, ok := attributes[tracing.RequestIDKey]; !ok {
+		t.Fatal("missing request id")
+	}
+}
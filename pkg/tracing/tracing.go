@@ -0,0 +1,147 @@
+// Package tracing sets up distributed tracing for our services using the OpenTelemetry SDK.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/soapboxsocial/soapbox"
+
+// Exporter names that can be configured.
+const (
+	ExporterNone   = ""
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+// Config describes how spans are sampled and where they are exported to.
+type Config struct {
+	Service  string `mapstructure:"service"`
+	Exporter string `mapstructure:"exporter"`
+
+	// Endpoint is the OTLP/HTTP collector endpoint, for example "http://localhost:4318".
+	Endpoint string            `mapstructure:"endpoint"`
+	Headers  map[string]string `mapstructure:"headers"`
+
+	// Path is the file spans are appended to when using the file exporter.
+	Path string `mapstructure:"path"`
+
+	// SampleRatio is the fraction of new traces that are recorded, 0 records everything.
+	SampleRatio float64 `mapstructure:"sample-ratio"`
+}
+
+// Init installs the global tracer provider and propagator described by the config.
+// The returned provider must be shut down before exiting in order to flush pending spans.
+// When no exporter is configured tracing stays disabled and the returned provider records nothing.
+func Init(config Config) (*sdktrace.TracerProvider, error) {
+	exporter, err := newExporter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if exporter == nil {
+		return sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample())), nil
+	}
+
+	ratio := config.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(config.Service))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider, nil
+}
+
+// Start starts a new span using the global tracer provider.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+func newExporter(config Config) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		return newFileExporter(config.Path)
+	case ExporterOTLP:
+		return newOTLPExporter(config)
+	default:
+		return nil, fmt.Errorf("unknown exporter \"%s\"", config.Exporter)
+	}
+}
+
+// fileExporter appends spans as JSON to a file, closing it on shutdown.
+type fileExporter struct {
+	*stdouttrace.Exporter
+
+	file *os.File
+}
+
+func newFileExporter(path string) (*fileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &fileExporter{Exporter: exporter, file: file}, nil
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if err != nil {
+		return err
+	}
+
+	return e.file.Close()
+}
+
+func newOTLPExporter(config Config) (sdktrace.SpanExporter, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(endpoint.Host),
+		otlptracehttp.WithHeaders(config.Headers),
+	}
+
+	if endpoint.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	if endpoint.Path != "" && endpoint.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(endpoint.Path))
+	}
+
+	return otlptracehttp.New(context.Background(), opts...)
+}
@@ -0,0 +1,96 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/tracing"
+)
+
+func TestInit_FileExporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "spans.json")
+
+	provider, err := tracing.Init(tracing.Config{Service: "test", Exporter: tracing.ExporterFile, Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	_, child := tracing.Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	err = provider.Shutdown(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected amount of lines %d", len(lines))
+	}
+
+	span := make(map[string]interface{})
+	err = json.Unmarshal([]byte(lines[0]), &span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if span["Name"] != "child" || span["Parent"] == nil || !strings.Contains(string(lines[0]), `"Value":"test"`) {
+		t.Fatalf("unexpected span %v", span)
+	}
+}
+
+func TestInit(t *testing.T) {
+	var tests = []struct {
+		exporter string
+		enabled  bool
+		err      bool
+	}{
+		{tracing.ExporterNone, false, false},
+		{tracing.ExporterStdout, true, false},
+		{tracing.ExporterOTLP, true, false},
+		{"zipkin", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exporter, func(t *testing.T) {
+			provider, err := tracing.Init(tracing.Config{Exporter: tt.exporter, Endpoint: "http://localhost:4318"})
+			if err != nil {
+				if tt.err {
+					return
+				}
+
+				t.Fatalf("unexpected err %s", err)
+			}
+
+			if tt.err {
+				t.Fatal("expected err")
+			}
+
+			_, span := provider.Tracer("test").Start(context.Background(), "test")
+			if span.IsRecording() != tt.enabled {
+				t.Fatalf("unexpected recording %v", span.IsRecording())
+			}
+
+			_ = provider.Shutdown(context.Background())
+		})
+	}
+}
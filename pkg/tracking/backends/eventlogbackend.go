@@ -0,0 +1,34 @@
+package backends
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+	"github.com/soapboxsocial/soapbox/pkg/tracking"
+)
+
+// EventLogBackend stores tracked events in the events table.
+type EventLogBackend struct {
+	db *sql.DB
+}
+
+func NewEventLogBackend(db *sql.DB) *EventLogBackend {
+	return &EventLogBackend{db: db}
+}
+
+func (b *EventLogBackend) Store(ctx context.Context, record *tracking.Record) error {
+	params, err := json.Marshal(record.Params)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO events (type, params, request_id, time) VALUES ($1, $2, NULLIF($3, ''), $4);")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, record.Type, params, record.RequestID, record.Time)
+	return err
+}
@@ -0,0 +1,203 @@
+package backends
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
+)
+
+// RoomSession summarises how a room performed, from when it was opened until it closed.
+type RoomSession struct {
+	Room       string    `json:"room"`
+	Host       int       `json:"-"`
+	Name       string    `json:"name"`
+	Visibility string    `json:"visibility"`
+	Started    time.Time `json:"started"`
+	Ended      time.Time `json:"ended"`
+
+	// PeakMembers is the largest amount of members that were in the room at the same time, including the host.
+	PeakMembers int `json:"peak_members"`
+
+	// UniqueListeners is the amount of users other than the host who joined the room.
+	UniqueListeners int `json:"unique_listeners"`
+
+	// AverageListenTime is the time in seconds a listener spent in the room on average.
+	AverageListenTime int `json:"average_listen_time"`
+
+	// NewFollowers is the amount of users who followed the host while the room was open.
+	NewFollowers int `json:"new_followers"`
+
+	Reactions int `json:"reactions"`
+}
+
+type RoomSessionBackend struct {
+	db *sql.DB
+}
+
+func NewRoomSessionBackend(db *sql.DB) *RoomSessionBackend {
+	return &RoomSessionBackend{db: db}
+}
+
+// Summarize sets the member statistics of a session from the user room logs of the room.
+// The session ends when the last member left, or at ended if there are no logs.
+func (b *RoomSessionBackend) Summarize(ctx context.Context, session *RoomSession, ended time.Time) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT user_id, join_time, left_time FROM user_room_logs WHERE room = $1 AND join_time IS NOT NULL AND left_time IS NOT NULL;")
+	if err != nil {
+		return err
+	}
+
+	rows, err := stmt.QueryContext(ctx, session.Room)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	type change struct {
+		at    time.Time
+		delta int
+	}
+
+	changes := make([]change, 0)
+	listeners := make(map[int]bool)
+	var listened time.Duration
+
+	session.Ended = time.Time{}
+
+	for rows.Next() {
+		var user int
+		var joined, left time.Time
+
+		err := rows.Scan(&user, &joined, &left)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, change{at: joined, delta: 1}, change{at: left, delta: -1})
+
+		if left.After(session.Ended) {
+			session.Ended = left
+		}
+
+		if user == session.Host {
+			continue
+		}
+
+		listeners[user] = true
+		listened += left.Sub(joined)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	if session.Ended.IsZero() {
+		session.Ended = ended
+	}
+
+	// members leaving are counted before members joining at the same time, so that reconnects are not counted twice.
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].at.Equal(changes[j].at) {
+			return changes[i].delta < changes[j].delta
+		}
+
+		return changes[i].at.Before(changes[j].at)
+	})
+
+	members := 0
+	session.PeakMembers = 0
+	for _, c := range changes {
+		members += c.delta
+		if members > session.PeakMembers {
+			session.PeakMembers = members
+		}
+	}
+
+	session.UniqueListeners = len(listeners)
+	session.AverageListenTime = 0
+	if len(listeners) > 0 {
+		session.AverageListenTime = int(listened.Seconds()) / len(listeners)
+	}
+
+	return nil
+}
+
+// Store saves a session, a session that was already stored is not changed.
+func (b *RoomSessionBackend) Store(ctx context.Context, session *RoomSession) error {
+	query := `INSERT INTO room_sessions (
+		room, host, name, visibility, started, ended, peak_members, unique_listeners, average_listen_time, new_followers, reactions
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT (room) DO NOTHING;`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(
+		ctx,
+		session.Room,
+		session.Host,
+		session.Name,
+		session.Visibility,
+		session.Started,
+		session.Ended,
+		session.PeakMembers,
+		session.UniqueListeners,
+		session.AverageListenTime,
+		session.NewFollowers,
+		session.Reactions,
+	)
+
+	return err
+}
+
+// GetSessionsForHost returns up to limit sessions of rooms hosted by host, most recently ended first.
+// If before is set only sessions that ended before it, or at the same time with a lower room ID than beforeRoom, are returned.
+func (b *RoomSessionBackend) GetSessionsForHost(ctx context.Context, host int, before *time.Time, beforeRoom string, limit int) ([]*RoomSession, error) {
+	query := `SELECT room, host, name, visibility, started, ended, peak_members, unique_listeners, average_listen_time, new_followers, reactions
+		FROM room_sessions
+		WHERE host = $1 AND ($2::TIMESTAMPTZ IS NULL OR (ended, room) < ($2, $3))
+		ORDER BY ended DESC, room DESC LIMIT $4;`
+
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, host, before, beforeRoom, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*RoomSession, 0)
+	for rows.Next() {
+		session := &RoomSession{}
+
+		err := rows.Scan(
+			&session.Room,
+			&session.Host,
+			&session.Name,
+			&session.Visibility,
+			&session.Started,
+			&session.Ended,
+			&session.PeakMembers,
+			&session.UniqueListeners,
+			&session.AverageListenTime,
+			&session.NewFollowers,
+			&session.Reactions,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, session)
+	}
+
+	return result, rows.Err()
+}
@@ -1,10 +1,21 @@
 package backends
 
 import (
+	"context"
 	"database/sql"
 	"time"
+
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 )
 
+// UserRoomLog is a record of a user being in a room.
+type UserRoomLog struct {
+	Room       string    `json:"room"`
+	Visibility string    `json:"visibility"`
+	Joined     time.Time `json:"joined"`
+	Left       time.Time `json:"left"`
+}
+
 type UserRoomLogBackend struct {
 	db *sql.DB
 }
@@ -13,12 +24,42 @@ func NewUserRoomLogBackend(db *sql.DB) *UserRoomLogBackend {
 	return &UserRoomLogBackend{db: db}
 }
 
-func (b *UserRoomLogBackend) Store(user int, room, visibility string, joined, left time.Time) error {
-	stmt, err := b.db.Prepare("INSERT INTO user_room_logs (user_id, room, join_time, left_time, visibility) VALUES ($1, $2, $3, $4, $5);")
+func (b *UserRoomLogBackend) Store(ctx context.Context, user int, room, visibility string, joined, left time.Time) error {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO user_room_logs (user_id, room, join_time, left_time, visibility) VALUES ($1, $2, $3, $4, $5);")
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(user, room, joined, left, visibility)
+	_, err = stmt.ExecContext(ctx, user, room, joined, left, visibility)
 	return err
 }
+
+// GetLogsForUser returns every room a user has been in, most recent first.
+func (b *UserRoomLogBackend) GetLogsForUser(ctx context.Context, user int) ([]UserRoomLog, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT room, visibility, join_time, left_time FROM user_room_logs WHERE user_id = $1 ORDER BY join_time DESC;")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]UserRoomLog, 0)
+
+	for rows.Next() {
+		log := UserRoomLog{}
+
+		err := rows.Scan(&log.Room, &log.Visibility, &log.Joined, &log.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, log)
+	}
+
+	return result, rows.Err()
+}
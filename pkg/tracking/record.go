@@ -0,0 +1,30 @@
+package tracking
+
+import (
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+// Record is an event as it is exported for analysis.
+type Record struct {
+	Type      string                 `json:"type"`
+	Params    map[string]interface{} `json:"params"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Time      time.Time              `json:"time"`
+}
+
+// NewRecord returns the record of an event tracked at a time.
+func NewRecord(event *pubsub.Event, at time.Time) *Record {
+	params := event.Params
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+
+	return &Record{
+		Type:      event.Type.String(),
+		Params:    params,
+		RequestID: event.RequestID,
+		Time:      at.UTC(),
+	}
+}
@@ -0,0 +1,27 @@
+package trackers
+
+import (
+	"context"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracking"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
+)
+
+// EventLogTracker stores every event in postgres.
+type EventLogTracker struct {
+	backend *backends.EventLogBackend
+}
+
+func NewEventLogTracker(backend *backends.EventLogBackend) *EventLogTracker {
+	return &EventLogTracker{backend: backend}
+}
+
+func (e *EventLogTracker) CanTrack(*pubsub.Event) bool {
+	return true
+}
+
+func (e *EventLogTracker) Track(ctx context.Context, event *pubsub.Event) error {
+	return e.backend.Store(ctx, tracking.NewRecord(event, time.Now()))
+}
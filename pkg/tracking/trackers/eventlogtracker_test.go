@@ -0,0 +1,43 @@
+package trackers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/trackers"
+)
+
+func TestEventLogTracker_Track(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tracker := trackers.NewEventLogTracker(backends.NewEventLogBackend(db))
+
+	event, err := getRawEvent(pubsub.NewFollowerEvent(1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.
+		ExpectPrepare("^INSERT INTO events").
+		ExpectExec().
+		WithArgs("new_follower", []byte(`{"follower":1,"id":2}`), "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = tracker.Track(context.Background(), event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,194 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/log"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracking"
+)
+
+const (
+	// completeExtension is the extension of files that are no longer written to and can be loaded.
+	completeExtension = ".ndjson"
+
+	// partialExtension is the extension of the file currently written to.
+	partialExtension = ".ndjson.part"
+)
+
+// FileTracker writes every event as a line of JSON to files in a directory, ready to be loaded into a warehouse.
+// Files are named after the time they were started at and only get the ".ndjson" extension once they are complete,
+// which is the case after the rotation interval, once they reach the maximum size, or when the tracker is closed.
+// Files are rotated on the interval even if no events arrive, so the warehouse never waits on an idle file.
+type FileTracker struct {
+	mux sync.Mutex
+
+	dir      string
+	interval time.Duration
+	maxSize  int64
+
+	file    *os.File
+	started time.Time
+	size    int64
+
+	now func() time.Time
+
+	stop    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// NewFileTracker returns a tracker writing to dir. Files are rotated after interval or once they contain maxSize
+// bytes, rotation is disabled when either is 0. Partial files left over by a previous run are marked complete.
+func NewFileTracker(dir string, interval time.Duration, maxSize int64) (*FileTracker, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	partial, err := filepath.Glob(filepath.Join(dir, "*"+partialExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range partial {
+		err := os.Rename(path, strings.TrimSuffix(path, partialExtension)+completeExtension)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f := &FileTracker{
+		dir:      dir,
+		interval: interval,
+		maxSize:  maxSize,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+	}
+
+	if interval > 0 {
+		f.stopped.Add(1)
+		go f.rotate()
+	}
+
+	return f, nil
+}
+
+func (f *FileTracker) CanTrack(*pubsub.Event) bool {
+	return true
+}
+
+func (f *FileTracker) Track(_ context.Context, event *pubsub.Event) error {
+	now := f.now()
+
+	data, err := json.Marshal(tracking.NewRecord(event, now))
+	if err != nil {
+		return err
+	}
+
+	data = append(data, '\n')
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.file != nil && f.expired(now) {
+		err := f.complete()
+		if err != nil {
+			return err
+		}
+	}
+
+	if f.file == nil {
+		err := f.open(now)
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+
+	return err
+}
+
+// Close stops the rotation and completes the file currently written to.
+func (f *FileTracker) Close() error {
+	close(f.stop)
+	f.stopped.Wait()
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	return f.complete()
+}
+
+// rotate completes the file currently written to on every tick of the interval, until the tracker is closed.
+func (f *FileTracker) rotate() {
+	defer f.stopped.Done()
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+			f.mux.Lock()
+
+			if f.file != nil {
+				err := f.complete()
+				if err != nil {
+					log.Printf("failed to rotate file: %v", err)
+				}
+			}
+
+			f.mux.Unlock()
+		}
+	}
+}
+
+func (f *FileTracker) expired(now time.Time) bool {
+	if f.interval > 0 && now.Sub(f.started) >= f.interval {
+		return true
+	}
+
+	return f.maxSize > 0 && f.size >= f.maxSize
+}
+
+func (f *FileTracker) open(now time.Time) error {
+	name := fmt.Sprintf("events-%s%s", now.UTC().Format("20060102T150405.000000000"), partialExtension)
+
+	file, err := os.OpenFile(filepath.Join(f.dir, name), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	f.file = file
+	f.started = now
+	f.size = 0
+
+	return nil
+}
+
+func (f *FileTracker) complete() error {
+	path := f.file.Name()
+
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(path, strings.TrimSuffix(path, partialExtension)+completeExtension)
+}
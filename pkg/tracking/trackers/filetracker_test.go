@@ -0,0 +1,143 @@
+package trackers_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracking"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/trackers"
+)
+
+func TestFileTracker_Track(t *testing.T) {
+	dir := t.TempDir()
+
+	// every file is rotated after its first event.
+	tracker, err := trackers.NewFileTracker(dir, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, event := range []pubsub.Event{pubsub.NewUserEvent(1, "foo"), pubsub.NewFollowerEvent(1, 2)} {
+		event.RequestID = "1234"
+
+		err := tracker.Track(context.Background(), &event)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertFiles(t, dir, "*.ndjson", 1)
+	assertFiles(t, dir, "*.ndjson.part", 1)
+
+	err = tracker.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := assertFiles(t, dir, "*.ndjson", 2)
+	assertFiles(t, dir, "*.ndjson.part", 0)
+
+	records := make([]*tracking.Record, 0)
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			record := &tracking.Record{}
+			err := json.Unmarshal(scanner.Bytes(), record)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			records = append(records, record)
+		}
+
+		_ = file.Close()
+	}
+
+	if len(records) != 2 || records[0].Type != "new_user" || records[1].Type != "new_follower" {
+		t.Fatalf("unexpected records %+v", records)
+	}
+
+	if records[0].RequestID != "1234" || records[0].Params["username"] != "foo" {
+		t.Fatalf("unexpected record %+v", records[0])
+	}
+}
+
+func TestFileTracker_RotatesIdleFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	tracker, err := trackers.NewFileTracker(dir, 10*time.Millisecond, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer tracker.Close()
+
+	event := pubsub.NewUserEvent(1, "foo")
+	err = tracker.Track(context.Background(), &event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no further events arrive, the file is completed by the rotation alone.
+	deadline := time.Now().Add(time.Second)
+	for {
+		files, err := filepath.Glob(filepath.Join(dir, "*.ndjson"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(files) == 1 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("file was not rotated")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	assertFiles(t, dir, "*.ndjson.part", 0)
+}
+
+func TestNewFileTracker_CompletesPartialFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "events-1.ndjson.part"), []byte("{}\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = trackers.NewFileTracker(dir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertFiles(t, dir, "events-1.ndjson", 1)
+}
+
+func assertFiles(t *testing.T, dir, pattern string, count int) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != count {
+		t.Fatalf("expected %d files matching %s, got %v", count, pattern, files)
+	}
+
+	return files
+}
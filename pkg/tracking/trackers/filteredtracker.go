@@ -0,0 +1,135 @@
+package trackers
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+// Filter configures which events a tracker receives and which of their params it sees.
+type Filter struct {
+	// Include are the names of the event types that are tracked, every type is tracked when empty.
+	Include []string `mapstructure:"include"`
+
+	// Exclude are the names of the event types that are never tracked.
+	Exclude []string `mapstructure:"exclude"`
+
+	// SampleRatio is the fraction of events that are tracked, 0 tracks every event.
+	SampleRatio float64 `mapstructure:"sample-ratio"`
+
+	// Scrub are the params removed from events before they are tracked, for example "email" or "ip".
+	// Params are matched case insensitively, at any depth.
+	Scrub []string `mapstructure:"scrub"`
+}
+
+// FilteredTracker passes the events matching a Filter to a tracker.
+type FilteredTracker struct {
+	tracker Tracker
+
+	include map[pubsub.EventType]bool
+	exclude map[pubsub.EventType]bool
+	ratio   float64
+	scrub   map[string]bool
+
+	random func() float64
+}
+
+// NewFilteredTracker returns a tracker applying filter to tracker, it fails if the filter contains unknown event types.
+func NewFilteredTracker(tracker Tracker, filter Filter) (*FilteredTracker, error) {
+	include, err := parseEventTypes(filter.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := parseEventTypes(filter.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	scrub := make(map[string]bool)
+	for _, param := range filter.Scrub {
+		scrub[strings.ToLower(param)] = true
+	}
+
+	return &FilteredTracker{
+		tracker: tracker,
+		include: include,
+		exclude: exclude,
+		ratio:   filter.SampleRatio,
+		scrub:   scrub,
+		random:  rand.Float64,
+	}, nil
+}
+
+func (f *FilteredTracker) CanTrack(event *pubsub.Event) bool {
+	if len(f.include) > 0 && !f.include[event.Type] {
+		return false
+	}
+
+	if f.exclude[event.Type] || !f.tracker.CanTrack(event) {
+		return false
+	}
+
+	return f.ratio <= 0 || f.random() < f.ratio
+}
+
+func (f *FilteredTracker) Track(ctx context.Context, event *pubsub.Event) error {
+	if len(f.scrub) == 0 {
+		return f.tracker.Track(ctx, event)
+	}
+
+	scrubbed := *event
+	scrubbed.Params = f.scrubMap(event.Params)
+
+	return f.tracker.Track(ctx, &scrubbed)
+}
+
+// scrubMap returns a copy of params without the scrubbed params.
+func (f *FilteredTracker) scrubMap(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		if f.scrub[strings.ToLower(key)] {
+			continue
+		}
+
+		result[key] = f.scrubValue(value)
+	}
+
+	return result
+}
+
+func (f *FilteredTracker) scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return f.scrubMap(v)
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, f.scrubValue(item))
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+func parseEventTypes(names []string) (map[pubsub.EventType]bool, error) {
+	types := make(map[pubsub.EventType]bool)
+	for _, name := range names {
+		t, err := pubsub.ParseEventType(name)
+		if err != nil {
+			return nil, err
+		}
+
+		types[t] = true
+	}
+
+	return types, nil
+}
@@ -0,0 +1,94 @@
+package trackers_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/trackers"
+)
+
+// recordingTracker keeps every event it tracks.
+type recordingTracker struct {
+	events []*pubsub.Event
+}
+
+func (r *recordingTracker) CanTrack(*pubsub.Event) bool {
+	return true
+}
+
+func (r *recordingTracker) Track(_ context.Context, event *pubsub.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestFilteredTracker_CanTrack(t *testing.T) {
+	tracker, err := trackers.NewFilteredTracker(&recordingTracker{}, trackers.Filter{
+		Include: []string{"new_user", "user_heartbeat"},
+		Exclude: []string{"user_heartbeat"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		event    pubsub.Event
+		expected bool
+	}{
+		{pubsub.NewUserEvent(1, "foo"), true},
+		{pubsub.NewUserHeartbeatEvent(1), false},
+		{pubsub.NewFollowerEvent(1, 2), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.event.Type.String(), func(t *testing.T) {
+			if tracker.CanTrack(&tt.event) != tt.expected {
+				t.Fatalf("expected %v", tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilteredTracker_Track(t *testing.T) {
+	recorder := &recordingTracker{}
+
+	tracker, err := trackers.NewFilteredTracker(recorder, trackers.Filter{Scrub: []string{"Email", "ip"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := &pubsub.Event{
+		Type: pubsub.EventTypeNewUser,
+		Params: map[string]interface{}{
+			"id":      1.0,
+			"email":   "foo@bar.com",
+			"devices": []interface{}{map[string]interface{}{"ip": "127.0.0.1", "os": "ios"}},
+		},
+	}
+
+	err = tracker.Track(context.Background(), event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"id":      1.0,
+		"devices": []interface{}{map[string]interface{}{"os": "ios"}},
+	}
+
+	if !reflect.DeepEqual(recorder.events[0].Params, expected) {
+		t.Fatalf("unexpected params %v", recorder.events[0].Params)
+	}
+
+	if _, ok := event.Params["email"]; !ok {
+		t.Fatal("original event was modified")
+	}
+}
+
+func TestNewFilteredTracker_UnknownEventType(t *testing.T) {
+	_, err := trackers.NewFilteredTracker(&recordingTracker{}, trackers.Filter{Include: []string{"foo"}})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -1,6 +1,7 @@
 package trackers
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -26,10 +27,13 @@ func NewMixpanelTracker(client mixpanel.Mixpanel) *MixpanelTracker {
 func (m *MixpanelTracker) CanTrack(event *pubsub.Event) bool {
 	return event.Type != pubsub.EventTypeRoomInvite &&
 		event.Type != pubsub.EventTypeUserUpdate &&
-		event.Type != pubsub.EventTypeWelcomeRoom
+		event.Type != pubsub.EventTypeWelcomeRoom &&
+		event.Type != pubsub.EventTypeRoomVisibilityUpdate &&
+		event.Type != pubsub.EventTypeRoomClosed &&
+		event.Type != pubsub.EventTypeUserBlocked
 }
 
-func (m *MixpanelTracker) Track(event *pubsub.Event) error {
+func (m *MixpanelTracker) Track(ctx context.Context, event *pubsub.Event) error {
 	log := transform(event)
 	if log == nil {
 		return fmt.Errorf("invalid type for tracker: %d", event.Type)
@@ -133,6 +137,19 @@ func transform(event *pubsub.Event) *tracking.Event {
 				"following_id": event.Params["id"],
 			},
 		}
+	case pubsub.EventTypeUnfollow:
+		id, err := event.GetInt("follower")
+		if err != nil {
+			return nil
+		}
+
+		return &tracking.Event{
+			ID:   strconv.Itoa(id),
+			Name: "unfollowed",
+			Properties: map[string]interface{}{
+				"following_id": event.Params["id"],
+			},
+		}
 	case pubsub.EventTypeNewStory:
 		id, err := event.GetInt("creator")
 		if err != nil {
@@ -155,6 +172,17 @@ func transform(event *pubsub.Event) *tracking.Event {
 			Name:       "story_reaction",
 			Properties: map[string]interface{}{},
 		}
+	case pubsub.EventTypeStoryReply:
+		id, err := event.GetInt("from")
+		if err != nil {
+			return nil
+		}
+
+		return &tracking.Event{
+			ID:         strconv.Itoa(id),
+			Name:       "story_reply",
+			Properties: map[string]interface{}{},
+		}
 	case pubsub.EventTypeUserHeartbeat:
 
 		// @TODO ADD HEARTBEAT COOLDOWN of 30 mins
@@ -201,6 +229,32 @@ func transform(event *pubsub.Event) *tracking.Event {
 				"room_id": event.Params["room"],
 			},
 		}
+	case pubsub.EventTypeRoomMetadataUpdate:
+		id, err := event.GetInt("creator")
+		if err != nil {
+			return nil
+		}
+
+		return &tracking.Event{
+			ID:   strconv.Itoa(id),
+			Name: "room_update_metadata",
+			Properties: map[string]interface{}{
+				"room_id": event.Params["id"],
+			},
+		}
+	case pubsub.EventTypeRoomRename:
+		id, err := event.GetInt("creator")
+		if err != nil {
+			return nil
+		}
+
+		return &tracking.Event{
+			ID:   strconv.Itoa(id),
+			Name: "room_rename",
+			Properties: map[string]interface{}{
+				"room_id": event.Params["id"],
+			},
+		}
 	case pubsub.EventTypeDeleteUser:
 		id, err := event.GetInt("id")
 		if err != nil {
@@ -1,6 +1,7 @@
 package trackers_test
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"strconv"
@@ -22,7 +23,7 @@ func TestMixpanelTracker_Track(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err = tracker.Track(event)
+	err = tracker.Track(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -47,9 +48,13 @@ func TestMixpanelTracker_CanTrack(t *testing.T) {
 		pubsub.EventTypeNewUser,
 		pubsub.EventTypeNewStory,
 		pubsub.EventTypeStoryReaction,
+		pubsub.EventTypeStoryReply,
 		pubsub.EventTypeUserHeartbeat,
 		pubsub.EventTypeRoomLinkShare,
 		pubsub.EventTypeRoomOpenMini,
+		pubsub.EventTypeRoomMetadataUpdate,
+		pubsub.EventTypeRoomRename,
+		pubsub.EventTypeUnfollow,
 		pubsub.EventTypeDeleteUser,
 	}
 
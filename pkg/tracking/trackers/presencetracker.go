@@ -0,0 +1,67 @@
+package trackers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/soapboxsocial/soapbox/pkg/presence"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
+
+// PresenceTracker keeps the presence of users up to date from their activity.
+type PresenceTracker struct {
+	backend *presence.Backend
+}
+
+func NewPresenceTracker(backend *presence.Backend) *PresenceTracker {
+	return &PresenceTracker{backend: backend}
+}
+
+func (p *PresenceTracker) CanTrack(event *pubsub.Event) bool {
+	return event.Type == pubsub.EventTypeUserHeartbeat ||
+		event.Type == pubsub.EventTypeNewRoom ||
+		event.Type == pubsub.EventTypeRoomJoin ||
+		event.Type == pubsub.EventTypeRoomLeft ||
+		event.Type == pubsub.EventTypeRoomVisibilityUpdate ||
+		event.Type == pubsub.EventTypeDeleteUser
+}
+
+func (p *PresenceTracker) Track(ctx context.Context, event *pubsub.Event) error {
+	switch event.Type {
+	case pubsub.EventTypeUserHeartbeat:
+		id, err := event.GetInt("id")
+		if err != nil {
+			return err
+		}
+
+		return p.backend.Heartbeat(ctx, id)
+	case pubsub.EventTypeDeleteUser:
+		id, err := event.GetInt("id")
+		if err != nil {
+			return err
+		}
+
+		return p.backend.Remove(ctx, id)
+	}
+
+	id, err := event.GetInt("creator")
+	if err != nil {
+		return err
+	}
+
+	room, ok := event.Params["id"].(string)
+	if !ok {
+		return fmt.Errorf("failed to recover room")
+	}
+
+	visibility, _ := event.Params["visibility"].(string)
+
+	switch event.Type {
+	case pubsub.EventTypeRoomLeft:
+		return p.backend.LeaveRoom(ctx, id, room)
+	case pubsub.EventTypeRoomVisibilityUpdate:
+		return p.backend.UpdateRoomVisibility(ctx, room, pubsub.RoomVisibility(visibility))
+	default:
+		return p.backend.JoinRoom(ctx, id, room, pubsub.RoomVisibility(visibility))
+	}
+}
@@ -0,0 +1,113 @@
+package trackers_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/presence"
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/trackers"
+)
+
+func TestPresenceTracker_CanTrack(t *testing.T) {
+	tests := []pubsub.EventType{
+		pubsub.EventTypeUserHeartbeat,
+		pubsub.EventTypeNewRoom,
+		pubsub.EventTypeRoomJoin,
+		pubsub.EventTypeRoomLeft,
+		pubsub.EventTypeRoomVisibilityUpdate,
+		pubsub.EventTypeDeleteUser,
+	}
+
+	tracker := trackers.NewPresenceTracker(nil)
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(int(tt)), func(t *testing.T) {
+
+			if !tracker.CanTrack(&pubsub.Event{Type: tt}) {
+				t.Fatalf("cannot track: %d", tt)
+			}
+		})
+	}
+
+	if tracker.CanTrack(&pubsub.Event{Type: pubsub.EventTypeNewFollower}) {
+		t.Fatal("should not track followers")
+	}
+}
+
+func TestPresenceTracker_TrackVisibilityUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	backend := presence.NewBackend(rdb, db, pubsub.NewQueue(rdb))
+	tracker := trackers.NewPresenceTracker(backend)
+	ctx := context.Background()
+
+	// every published update and get reads the settings of the users.
+	mock.MatchExpectationsInOrder(false)
+	for i := 0; i < 5; i++ {
+		mock.ExpectPrepare("^SELECT user_id, appear_offline, hide_room FROM presence_settings").ExpectQuery().
+			WillReturnRows(mock.NewRows([]string{"user_id", "appear_offline", "hide_room"}))
+	}
+
+	for _, e := range []pubsub.Event{
+		pubsub.NewRoomJoinEvent("foo", 1, pubsub.Public),
+		pubsub.NewRoomJoinEvent("foo", 2, pubsub.Public),
+		pubsub.NewRoomJoinEvent("bar", 3, pubsub.Public),
+	} {
+		event, err := getRawEvent(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = tracker.Track(ctx, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	event, err := getRawEvent(pubsub.NewRoomVisibilityUpdateEvent("foo", 1, pubsub.Private))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tracker.Track(ctx, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := backend.Get(ctx, []int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result[0].Room != nil || result[1].Room != nil {
+		t.Fatalf("private room is still visible %v %v", result[0], result[1])
+	}
+
+	if result[2].Room == nil || *result[2].Room != "bar" {
+		t.Fatalf("unexpected room for other member %v", result[2])
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,11 +1,12 @@
 package trackers
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/soapboxsocial/soapbox/pkg/activeusers"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/redis"
 )
@@ -27,7 +28,7 @@ func (r *RecentlyActiveTracker) CanTrack(event *pubsub.Event) bool {
 		event.Type == pubsub.EventTypeRoomLeft
 }
 
-func (r *RecentlyActiveTracker) Track(event *pubsub.Event) error {
+func (r *RecentlyActiveTracker) Track(ctx context.Context, event *pubsub.Event) error {
 	id, err := event.GetInt("id")
 	if err != nil {
 		return err
@@ -38,7 +39,7 @@ func (r *RecentlyActiveTracker) Track(event *pubsub.Event) error {
 		return nil
 	}
 
-	err = r.backend.SetLastActiveTime(id, time.Now())
+	err = r.backend.SetLastActiveTime(ctx, id, time.Now())
 	if err != nil {
 		return err
 	}
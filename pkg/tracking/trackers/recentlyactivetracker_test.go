@@ -1,6 +1,7 @@
 package trackers_test
 
 import (
+	"context"
 	"strconv"
 	"testing"
 
@@ -77,7 +78,7 @@ func TestRecentlyActiveTracker_Track(t *testing.T) {
 		ExpectExec().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = tracker.Track(event)
+	err = tracker.Track(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
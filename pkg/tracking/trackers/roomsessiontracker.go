@@ -0,0 +1,161 @@
+package trackers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
+)
+
+// roomSessionTTL is how long the state of an open room is kept, rooms are expected to close before.
+const roomSessionTTL = 24 * time.Hour
+
+// RoomSessionTracker stores a summary of every room when it closes.
+// It counts the followers a host gains while their room is open, the member statistics are computed from the
+// user room logs, which requires the UserRoomLogTracker to run in the same process.
+type RoomSessionTracker struct {
+	backend *backends.RoomSessionBackend
+	rdb     *redis.Client
+}
+
+func NewRoomSessionTracker(backend *backends.RoomSessionBackend, rdb *redis.Client) *RoomSessionTracker {
+	return &RoomSessionTracker{
+		backend: backend,
+		rdb:     rdb,
+	}
+}
+
+func (r *RoomSessionTracker) CanTrack(event *pubsub.Event) bool {
+	return event.Type == pubsub.EventTypeNewRoom ||
+		event.Type == pubsub.EventTypeNewFollower ||
+		event.Type == pubsub.EventTypeRoomClosed
+}
+
+func (r *RoomSessionTracker) Track(ctx context.Context, event *pubsub.Event) error {
+	switch event.Type {
+	case pubsub.EventTypeNewRoom:
+		return r.onNewRoom(ctx, event)
+	case pubsub.EventTypeNewFollower:
+		return r.onNewFollower(ctx, event)
+	case pubsub.EventTypeRoomClosed:
+		return r.onRoomClosed(ctx, event)
+	default:
+		return fmt.Errorf("invalid type for tracker: %d", event.Type)
+	}
+}
+
+func (r *RoomSessionTracker) onNewRoom(ctx context.Context, event *pubsub.Event) error {
+	room, ok := event.Params["id"].(string)
+	if !ok {
+		return errors.New("failed to recover room ID")
+	}
+
+	host, err := event.GetInt("creator")
+	if err != nil {
+		return err
+	}
+
+	return r.rdb.Set(ctx, hostingKey(host), room, roomSessionTTL).Err()
+}
+
+func (r *RoomSessionTracker) onNewFollower(ctx context.Context, event *pubsub.Event) error {
+	host, err := event.GetInt("id")
+	if err != nil {
+		return err
+	}
+
+	room, err := r.rdb.Get(ctx, hostingKey(host)).Result()
+	if err == redis.Nil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	key := followersKey(room)
+
+	pipe := r.rdb.TxPipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, roomSessionTTL)
+
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *RoomSessionTracker) onRoomClosed(ctx context.Context, event *pubsub.Event) error {
+	room, ok := event.Params["id"].(string)
+	if !ok {
+		return errors.New("failed to recover room ID")
+	}
+
+	host, err := event.GetInt("creator")
+	if err != nil {
+		return err
+	}
+
+	started, err := getTime(event, "started")
+	if err != nil {
+		return err
+	}
+
+	reactions, err := event.GetInt("reactions")
+	if err != nil {
+		return err
+	}
+
+	name, _ := event.Params["name"].(string)
+	visibility, _ := event.Params["visibility"].(string)
+
+	followers, err := r.rdb.Get(ctx, followersKey(room)).Int()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	session := &backends.RoomSession{
+		Room:         room,
+		Host:         host,
+		Name:         name,
+		Visibility:   visibility,
+		Started:      started,
+		NewFollowers: followers,
+		Reactions:    reactions,
+	}
+
+	err = r.backend.Summarize(ctx, session, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = r.backend.Store(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	hosting, err := r.rdb.Get(ctx, hostingKey(host)).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	keys := []string{followersKey(room)}
+	if hosting == room {
+		keys = append(keys, hostingKey(host))
+	}
+
+	return r.rdb.Del(ctx, keys...).Err()
+}
+
+// hostingKey contains the ID of the open room hosted by a user.
+func hostingKey(host int) string {
+	return fmt.Sprintf("room_session_hosting_%d", host)
+}
+
+// followersKey contains the amount of users who followed the host of an open room.
+func followersKey(room string) string {
+	return fmt.Sprintf("room_session_followers_%s", room)
+}
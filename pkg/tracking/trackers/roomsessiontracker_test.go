@@ -0,0 +1,83 @@
+package trackers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/backends"
+	"github.com/soapboxsocial/soapbox/pkg/tracking/trackers"
+)
+
+func TestRoomSessionTracker_Track(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	tracker := trackers.NewRoomSessionTracker(backends.NewRoomSessionBackend(db), rdb)
+
+	room, host := "123", 1
+	started := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	for _, e := range []pubsub.Event{
+		pubsub.NewRoomCreationEvent(room, host, pubsub.Public),
+		pubsub.NewFollowerEvent(2, host),
+		pubsub.NewFollowerEvent(3, host),
+		pubsub.NewFollowerEvent(3, 4),
+		pubsub.NewRoomClosedEvent(room, "foo", host, pubsub.Public, started, 7),
+	} {
+		event, err := getRawEvent(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if event.Type == pubsub.EventTypeRoomClosed {
+			// the host is in the room for the whole hour, listeners 2 and 3 overlap for 10 minutes.
+			mock.ExpectPrepare("^SELECT user_id, join_time, left_time FROM user_room_logs").
+				ExpectQuery().
+				WithArgs(room).
+				WillReturnRows(
+					sqlmock.NewRows([]string{"user_id", "join_time", "left_time"}).
+						AddRow(host, started, started.Add(time.Hour)).
+						AddRow(2, started.Add(10*time.Minute), started.Add(30*time.Minute)).
+						AddRow(3, started.Add(20*time.Minute), started.Add(40*time.Minute)).
+						AddRow(2, started.Add(40*time.Minute), started.Add(50*time.Minute)),
+				)
+
+			mock.ExpectPrepare("^INSERT INTO room_sessions").
+				ExpectExec().
+				WithArgs(room, host, "foo", "public", started, started.Add(time.Hour), 3, 2, 1500, 2, 7).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+
+		err = tracker.Track(context.Background(), event)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mr.Keys()) != 0 {
+		t.Fatalf("room state was not removed: %v", mr.Keys())
+	}
+}
@@ -1,6 +1,10 @@
 package trackers
 
-import "github.com/soapboxsocial/soapbox/pkg/pubsub"
+import (
+	"context"
+
+	"github.com/soapboxsocial/soapbox/pkg/pubsub"
+)
 
 // Tracker is a interface for tracking Events
 type Tracker interface {
@@ -9,5 +13,5 @@ type Tracker interface {
 	CanTrack(event *pubsub.Event) bool
 
 	// Track tracks an event, returns an error if failed.
-	Track(event *pubsub.Event) error
+	Track(ctx context.Context, event *pubsub.Event) error
 }
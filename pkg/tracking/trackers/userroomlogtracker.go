@@ -1,6 +1,7 @@
 package trackers
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -25,7 +26,7 @@ func (r UserRoomLogTracker) CanTrack(event *pubsub.Event) bool {
 	return event.Type == pubsub.EventTypeRoomLeft
 }
 
-func (r UserRoomLogTracker) Track(event *pubsub.Event) error {
+func (r UserRoomLogTracker) Track(ctx context.Context, event *pubsub.Event) error {
 	if event.Type != pubsub.EventTypeRoomLeft {
 		return fmt.Errorf("invalid type for tracker: %d", event.Type)
 	}
@@ -40,12 +41,12 @@ func (r UserRoomLogTracker) Track(event *pubsub.Event) error {
 		return err
 	}
 
-	err = r.backend.Store(user, event.Params["id"].(string), event.Params["visibility"].(string), joined, time.Now())
+	err = r.backend.Store(ctx, user, event.Params["id"].(string), event.Params["visibility"].(string), joined, time.Now())
 	if err != nil {
 		return err
 	}
 
-	_ = r.queue.Publish(pubsub.UserTopic, pubsub.NewUserUpdateEvent(user))
+	_ = r.queue.Publish(event.Context(), pubsub.UserTopic, pubsub.NewUserUpdateEvent(user))
 
 	return nil
 }
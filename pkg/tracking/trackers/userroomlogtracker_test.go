@@ -1,6 +1,7 @@
 package trackers_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -44,7 +45,7 @@ func TestUserRoomLogTracker_Track(t *testing.T) {
 		ExpectExec().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = tracker.Track(event)
+	err = tracker.Track(context.Background(), event)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"strings"
 
+	"github.com/soapboxsocial/soapbox/pkg/images"
+	sqlutil "github.com/soapboxsocial/soapbox/pkg/sql"
 	"github.com/soapboxsocial/soapbox/pkg/users/types"
 )
 
@@ -29,17 +31,18 @@ type LinkedAccount struct {
 // This means certain fields like `email` are omitted,
 // and others are added like `follower_counts` and relationships.
 type Profile struct {
-	ID             int             `json:"id"`
-	DisplayName    string          `json:"display_name"`
-	Username       string          `json:"username"`
-	Bio            string          `json:"bio"`
-	Followers      int             `json:"followers"`
-	Following      int             `json:"following"`
-	FollowedBy     *bool           `json:"followed_by,omitempty"`
-	IsFollowing    *bool           `json:"is_following,omitempty"`
-	IsBlocked      *bool           `json:"is_blocked,omitempty"`
-	Image          string          `json:"image"`
-	LinkedAccounts []LinkedAccount `json:"linked_accounts"`
+	ID             int              `json:"id"`
+	DisplayName    string           `json:"display_name"`
+	Username       string           `json:"username"`
+	Bio            string           `json:"bio"`
+	Followers      int              `json:"followers"`
+	Following      int              `json:"following"`
+	FollowedBy     *bool            `json:"followed_by,omitempty"`
+	IsFollowing    *bool            `json:"is_following,omitempty"`
+	IsBlocked      *bool            `json:"is_blocked,omitempty"`
+	Image          string           `json:"image"`
+	Images         []images.Variant `json:"images,omitempty"`
+	LinkedAccounts []LinkedAccount  `json:"linked_accounts"`
 }
 
 type NotificationUser struct {
@@ -58,14 +61,14 @@ func NewBackend(db *sql.DB) *Backend {
 	}
 }
 
-func (b *Backend) GetIDForUsername(username string) (int, error) {
-	stmt, err := b.db.Prepare("SELECT id FROM users WHERE username = $1;")
+func (b *Backend) GetIDForUsername(ctx context.Context, username string) (int, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT id FROM users WHERE username = $1 AND deletion_scheduled_at IS NULL;")
 	if err != nil {
 		return 0, err
 	}
 
 	var id int
-	err = stmt.QueryRow(username).Scan(&id)
+	err = stmt.QueryRowContext(ctx, username).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -73,30 +76,32 @@ func (b *Backend) GetIDForUsername(username string) (int, error) {
 	return id, nil
 }
 
-func (b *Backend) GetUserByUsername(username string) (*types.User, error) {
-	stmt, err := b.db.Prepare("SELECT id, display_name, image, bio FROM users WHERE username = $1;")
+func (b *Backend) GetUserByUsername(ctx context.Context, username string) (*types.User, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT id, display_name, image, bio FROM users WHERE username = $1 AND deletion_scheduled_at IS NULL;")
 	if err != nil {
 		return nil, err
 	}
 
 	user := &types.User{}
-	err = stmt.QueryRow(username).Scan(&user.ID, &user.DisplayName, &user.Image, &user.Bio)
+	err = stmt.QueryRowContext(ctx, username).Scan(&user.ID, &user.DisplayName, &user.Image, &user.Bio)
 	if err != nil {
 		return nil, err
 	}
 
 	user.Username = username
 
+	user.Images = images.Variants(user.Image)
+
 	return user, nil
 }
 
-func (b *Backend) GetUserForSearchEngine(id int) (*SearchUser, error) {
+func (b *Backend) GetUserForSearchEngine(ctx context.Context, id int) (*SearchUser, error) {
 	query := `SELECT 
        id, display_name, username, image, bio,
        (SELECT COUNT(*) FROM followers WHERE user_id = id) AS followers, 
-       (SELECT CAST(FLOOR(SUM(EXTRACT(EPOCH FROM (left_time - join_time)))) as INT) FROM user_room_logs WHERE user_id = id AND join_time >= NOW() - INTERVAL '7 DAYS' AND visibility = 'public') FROM users WHERE id = $1;`
+       (SELECT CAST(FLOOR(SUM(EXTRACT(EPOCH FROM (left_time - join_time)))) as INT) FROM user_room_logs WHERE user_id = id AND join_time >= NOW() - INTERVAL '7 DAYS' AND visibility = 'public') FROM users WHERE id = $1 AND deletion_scheduled_at IS NULL;`
 
-	stmt, err := b.db.Prepare(query)
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +109,7 @@ func (b *Backend) GetUserForSearchEngine(id int) (*SearchUser, error) {
 	roomTime := sql.NullInt64{}
 
 	profile := &SearchUser{}
-	err = stmt.QueryRow(id).Scan(
+	err = stmt.QueryRowContext(ctx, id).Scan(
 		&profile.ID,
 		&profile.DisplayName,
 		&profile.Username,
@@ -127,19 +132,19 @@ func (b *Backend) GetUserForSearchEngine(id int) (*SearchUser, error) {
 	return profile, nil
 }
 
-func (b *Backend) GetMyProfile(id int) (*Profile, error) {
+func (b *Backend) GetMyProfile(ctx context.Context, id int) (*Profile, error) {
 	query := `SELECT 
        id, display_name, username, image, bio,
        (SELECT COUNT(*) FROM followers WHERE user_id = id) AS followers,
        (SELECT COUNT(*) FROM followers WHERE follower = id) AS following FROM users WHERE id = $1;`
 
-	stmt, err := b.db.Prepare(query)
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
 	profile := &Profile{}
-	err = stmt.QueryRow(id).Scan(
+	err = stmt.QueryRowContext(ctx, id).Scan(
 		&profile.ID,
 		&profile.DisplayName,
 		&profile.Username,
@@ -152,24 +157,26 @@ func (b *Backend) GetMyProfile(id int) (*Profile, error) {
 		return nil, err
 	}
 
-	accounts, err := b.LinkedAccounts(id)
+	accounts, err := b.LinkedAccounts(ctx, id)
 	if err == nil {
 		profile.LinkedAccounts = accounts
 	}
 
+	profile.Images = images.Variants(profile.Image)
+
 	return profile, nil
 }
 
-func (b *Backend) ProfileByID(id, from int) (*Profile, error) {
+func (b *Backend) ProfileByID(ctx context.Context, id, from int) (*Profile, error) {
 	query := `SELECT 
        id, display_name, username, image, bio,
        (SELECT COUNT(*) FROM followers WHERE user_id = id) AS followers,
        (SELECT COUNT(*) FROM followers WHERE follower = id) AS following,
        (SELECT COUNT(*) FROM followers WHERE follower = id AND user_id = $1) AS followed_by,
        (SELECT COUNT(*) FROM followers WHERE follower = $1 AND user_id = id) AS is_following,
-       (SELECT COUNT(*) FROM blocks WHERE user_id = $1 AND blocked = id) AS is_following FROM users WHERE id = $2;`
+       (SELECT COUNT(*) FROM blocks WHERE user_id = $1 AND blocked = id) AS is_following FROM users WHERE id = $2 AND deletion_scheduled_at IS NULL;`
 
-	stmt, err := b.db.Prepare(query)
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +184,7 @@ func (b *Backend) ProfileByID(id, from int) (*Profile, error) {
 	profile := &Profile{}
 
 	var followedBy, isFollowing, isBlocked int
-	err = stmt.QueryRow(from, id).Scan(
+	err = stmt.QueryRowContext(ctx, from, id).Scan(
 		&profile.ID,
 		&profile.DisplayName,
 		&profile.Username,
@@ -201,25 +208,27 @@ func (b *Backend) ProfileByID(id, from int) (*Profile, error) {
 	profile.FollowedBy = &followed
 	profile.IsBlocked = &blocked
 
-	accounts, err := b.LinkedAccounts(id)
+	accounts, err := b.LinkedAccounts(ctx, id)
 	if err == nil {
 		profile.LinkedAccounts = accounts
 	}
 
+	profile.Images = images.Variants(profile.Image)
+
 	return profile, nil
 }
 
-func (b *Backend) NotificationUserFor(id int) (*NotificationUser, error) {
-	query := `SELECT id, username, image FROM users WHERE id = $1;`
+func (b *Backend) NotificationUserFor(ctx context.Context, id int) (*NotificationUser, error) {
+	query := `SELECT id, username, image FROM users WHERE id = $1 AND deletion_scheduled_at IS NULL;`
 
-	stmt, err := b.db.Prepare(query)
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
 	profile := &NotificationUser{}
 
-	err = stmt.QueryRow(id).Scan(
+	err = stmt.QueryRowContext(ctx, id).Scan(
 		&profile.ID,
 		&profile.Username,
 		&profile.Image,
@@ -232,14 +241,14 @@ func (b *Backend) NotificationUserFor(id int) (*NotificationUser, error) {
 	return profile, nil
 }
 
-func (b *Backend) IsAppleIDAccount(email string) (bool, error) {
-	stmt, err := b.db.Prepare("SELECT COUNT(*) FROM apple_authentication WHERE user_id = (SELECT id FROM users WHERE email = $1);")
+func (b *Backend) IsAppleIDAccount(ctx context.Context, email string) (bool, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT COUNT(*) FROM apple_authentication WHERE user_id = (SELECT id FROM users WHERE email = $1);")
 	if err != nil {
 		return false, err
 	}
 
 	var id int
-	err = stmt.QueryRow(email).Scan(&id)
+	err = stmt.QueryRowContext(ctx, email).Scan(&id)
 	if err != nil {
 		return false, err
 	}
@@ -247,44 +256,48 @@ func (b *Backend) IsAppleIDAccount(email string) (bool, error) {
 	return id == 1, nil
 }
 
-func (b *Backend) FindByAppleID(id string) (*types.User, error) {
-	stmt, err := b.db.Prepare("SELECT id, display_name, username, image, bio, email FROM users INNER JOIN apple_authentication ON users.id = apple_authentication.user_id WHERE apple_authentication.apple_user = $1;")
+func (b *Backend) FindByAppleID(ctx context.Context, id string) (*types.User, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT id, display_name, username, image, bio, email FROM users INNER JOIN apple_authentication ON users.id = apple_authentication.user_id WHERE apple_authentication.apple_user = $1;")
 	if err != nil {
 		return nil, err
 	}
 
 	user := &types.User{}
-	err = stmt.QueryRow(id).Scan(&user.ID, &user.DisplayName, &user.Username, &user.Image, &user.Bio, &user.Email)
+	err = stmt.QueryRowContext(ctx, id).Scan(&user.ID, &user.DisplayName, &user.Username, &user.Image, &user.Bio, &user.Email)
 	if err != nil {
 		return nil, err
 	}
 
+	user.Images = images.Variants(user.Image)
+
 	return user, nil
 }
 
-func (b *Backend) FindByID(id int) (*types.User, error) {
-	stmt, err := b.db.Prepare("SELECT id, display_name, username, image, bio, email FROM users WHERE id = $1;")
+func (b *Backend) FindByID(ctx context.Context, id int) (*types.User, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT id, display_name, username, image, bio, email FROM users WHERE id = $1 AND deletion_scheduled_at IS NULL;")
 	if err != nil {
 		return nil, err
 	}
 
 	user := &types.User{}
-	err = stmt.QueryRow(id).Scan(&user.ID, &user.DisplayName, &user.Username, &user.Image, &user.Bio, &user.Email)
+	err = stmt.QueryRowContext(ctx, id).Scan(&user.ID, &user.DisplayName, &user.Username, &user.Image, &user.Bio, &user.Email)
 	if err != nil {
 		return nil, err
 	}
 
+	user.Images = images.Variants(user.Image)
+
 	return user, nil
 }
 
-func (b *Backend) IsRegistered(email string) (bool, error) {
-	stmt, err := b.db.Prepare("SELECT COUNT(*) FROM users WHERE email = $1;")
+func (b *Backend) IsRegistered(ctx context.Context, email string) (bool, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT COUNT(*) FROM users WHERE email = $1;")
 	if err != nil {
 		return false, err
 	}
 
 	var id int
-	err = stmt.QueryRow(email).Scan(&id)
+	err = stmt.QueryRowContext(ctx, email).Scan(&id)
 	if err != nil {
 		return false, err
 	}
@@ -292,29 +305,31 @@ func (b *Backend) IsRegistered(email string) (bool, error) {
 	return id == 1, nil
 }
 
-func (b *Backend) FindByEmail(email string) (*types.User, error) {
-	stmt, err := b.db.Prepare("SELECT id, display_name, username, image, bio, email FROM users WHERE email = $1;")
+func (b *Backend) FindByEmail(ctx context.Context, email string) (*types.User, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT id, display_name, username, image, bio, email FROM users WHERE email = $1;")
 	if err != nil {
 		return nil, err
 	}
 
 	user := &types.User{}
-	err = stmt.QueryRow(email).Scan(&user.ID, &user.DisplayName, &user.Username, &user.Image, &user.Bio, &user.Email)
+	err = stmt.QueryRowContext(ctx, email).Scan(&user.ID, &user.DisplayName, &user.Username, &user.Image, &user.Bio, &user.Email)
 	if err != nil {
 		return nil, err
 	}
 
+	user.Images = images.Variants(user.Image)
+
 	return user, nil
 }
 
-func (b *Backend) CreateUser(email, displayName, bio, image, username string) (int, error) {
-	stmt, err := b.db.Prepare("INSERT INTO users (display_name, username, email, bio, image) VALUES ($1, $2, $3, $4, $5) RETURNING id;")
+func (b *Backend) CreateUser(ctx context.Context, email, displayName, bio, image, username string) (int, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "INSERT INTO users (display_name, username, email, bio, image) VALUES ($1, $2, $3, $4, $5) RETURNING id;")
 	if err != nil {
 		return 0, err
 	}
 
 	var id int
-	err = stmt.QueryRow(displayName, strings.ToLower(username), email, bio, image).Scan(&id)
+	err = stmt.QueryRowContext(ctx, displayName, strings.ToLower(username), email, bio, image).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -322,64 +337,57 @@ func (b *Backend) CreateUser(email, displayName, bio, image, username string) (i
 	return id, nil
 }
 
-func (b *Backend) CreateUserWithAppleLogin(email, displayName, bio, image, username, appleID string) (int, error) {
-	ctx := context.Background()
-	tx, err := b.db.BeginTx(ctx, nil)
-	if err != nil {
-		return 0, err
-	}
+func (b *Backend) CreateUserWithAppleLogin(ctx context.Context, email, displayName, bio, image, username, appleID string) (int, error) {
+	var id int
 
-	res := tx.QueryRow(
-		"INSERT INTO users (display_name, username, email, bio, image) VALUES ($1, $2, $3, $4, $5) RETURNING id;",
-		displayName, strings.ToLower(username), email, bio, image,
-	)
+	err := sqlutil.Transaction(ctx, b.db, func(ctx context.Context) error {
+		tx := sqlutil.ExecutorFrom(ctx, b.db)
 
-	var id int
-	err = res.Scan(&id)
-	if err != nil {
-		_ = tx.Rollback()
-		return 0, err
-	}
+		err := tx.QueryRowContext(
+			ctx,
+			"INSERT INTO users (display_name, username, email, bio, image) VALUES ($1, $2, $3, $4, $5) RETURNING id;",
+			displayName, strings.ToLower(username), email, bio, image,
+		).Scan(&id)
 
-	_, err = tx.ExecContext(
-		ctx,
-		"INSERT INTO apple_authentication (user_id, apple_user) VALUES ($1, $2);",
-		id, appleID,
-	)
+		if err != nil {
+			return err
+		}
 
-	if err != nil {
-		_ = tx.Rollback()
-		return 0, err
-	}
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO apple_authentication (user_id, apple_user) VALUES ($1, $2);",
+			id, appleID,
+		)
+
+		return err
+	})
 
-	err = tx.Commit()
 	if err != nil {
-		_ = tx.Rollback()
 		return 0, err
 	}
 
 	return id, nil
 }
 
-func (b *Backend) UpdateUser(id int, displayName, bio, image string) error {
+func (b *Backend) UpdateUser(ctx context.Context, id int, displayName, bio, image string) error {
 	query := "UPDATE users SET display_name = $1, bio = $2, image = $3 WHERE id = $4;"
 
-	stmt, err := b.db.Prepare(query)
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.Exec(displayName, bio, image, id)
+	_, err = stmt.ExecContext(ctx, displayName, bio, image, id)
 	return err
 }
 
-func (b *Backend) GetProfileImage(id int) (string, error) {
-	stmt, err := b.db.Prepare("SELECT image FROM users WHERE id = $1;")
+func (b *Backend) GetProfileImage(ctx context.Context, id int) (string, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT image FROM users WHERE id = $1;")
 	if err != nil {
 		return "", err
 	}
 
-	r := stmt.QueryRow(id)
+	r := stmt.QueryRowContext(ctx, id)
 
 	var name string
 	err = r.Scan(&name)
@@ -390,13 +398,13 @@ func (b *Backend) GetProfileImage(id int) (string, error) {
 	return name, err
 }
 
-func (b *Backend) LinkedAccounts(id int) ([]LinkedAccount, error) {
-	stmt, err := b.db.Prepare("SELECT profile_id, username, provider FROM linked_accounts WHERE user_id = $1;")
+func (b *Backend) LinkedAccounts(ctx context.Context, id int) ([]LinkedAccount, error) {
+	stmt, err := sqlutil.ExecutorFrom(ctx, b.db).PrepareContext(ctx, "SELECT profile_id, username, provider FROM linked_accounts WHERE user_id = $1;")
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query(id)
+	rows, err := stmt.QueryContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
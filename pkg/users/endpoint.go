@@ -1,8 +1,9 @@
 package users
 
 import (
+	"context"
 	"database/sql"
-	"log"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"strconv"
@@ -14,9 +15,11 @@ import (
 	"github.com/soapboxsocial/soapbox/pkg/followers"
 	httputil "github.com/soapboxsocial/soapbox/pkg/http"
 	"github.com/soapboxsocial/soapbox/pkg/images"
+	"github.com/soapboxsocial/soapbox/pkg/log"
 	"github.com/soapboxsocial/soapbox/pkg/pubsub"
 	"github.com/soapboxsocial/soapbox/pkg/sessions"
 	"github.com/soapboxsocial/soapbox/pkg/stories"
+	"github.com/soapboxsocial/soapbox/pkg/users/types"
 )
 
 type Endpoint struct {
@@ -81,7 +84,7 @@ func (e *Endpoint) GetUserByUsername(w http.ResponseWriter, r *http.Request) {
 
 	username := params["username"]
 
-	id, err := e.ub.GetIDForUsername(username)
+	id, err := e.ub.GetIDForUsername(r.Context(), username)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			httputil.JsonError(w, http.StatusNotFound, httputil.ErrorCodeUserNotFound, "user not found")
@@ -106,9 +109,9 @@ func (e *Endpoint) handleUserRetrieval(id int, w http.ResponseWriter, r *http.Re
 	var err error
 
 	if caller == id {
-		user, err = e.ub.GetMyProfile(id)
+		user, err = e.ub.GetMyProfile(r.Context(), id)
 	} else {
-		user, err = e.ub.ProfileByID(id, caller)
+		user, err = e.ub.ProfileByID(r.Context(), id, caller)
 	}
 
 	if err != nil {
@@ -124,7 +127,7 @@ func (e *Endpoint) handleUserRetrieval(id int, w http.ResponseWriter, r *http.Re
 	// @TODO READD LATER
 	//cr, err := e.currentRoom.GetCurrentRoomForUser(id)
 	//if err != nil && err.Error() != "redis: nil" {
-	//	log.Println("current room retrieval error", err.Error())
+	//	log.Ctx(r.Context()).Println("current room retrieval error", err.Error())
 	//}
 	//
 	//if cr != "" {
@@ -133,36 +136,30 @@ func (e *Endpoint) handleUserRetrieval(id int, w http.ResponseWriter, r *http.Re
 
 	err = httputil.JsonEncode(w, user)
 	if err != nil {
-		log.Printf("failed to write user response: %s\n", err.Error())
+		log.Ctx(r.Context()).Printf("failed to write user response: %s", err.Error())
 	}
 }
 
 // @todo think about moving these 2 endpoints into a follower specific thing?
 func (e *Endpoint) GetFollowersForUser(w http.ResponseWriter, r *http.Request) {
-	params := mux.Vars(r)
-
-	id, err := strconv.Atoi(params["id"])
-	if err != nil {
-		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid id")
-		return
-	}
+	e.listUsers(w, r, e.fb.GetAllUsersFollowing)
+}
 
-	limit := httputil.GetInt(r.URL.Query(), "limit", 10)
-	offset := httputil.GetInt(r.URL.Query(), "offset", 0)
+func (e *Endpoint) GetFollowedByForUser(w http.ResponseWriter, r *http.Request) {
+	e.listUsers(w, r, e.fb.GetAllUsersFollowedBy)
+}
 
-	result, err := e.fb.GetAllUsersFollowing(id, limit, offset)
-	if err != nil {
-		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToGetFollowers, "")
-		return
-	}
+func (e *Endpoint) GetFriends(w http.ResponseWriter, r *http.Request) {
+	e.listUsers(w, r, e.fb.GetFriends)
+}
 
-	err = httputil.JsonEncode(w, result)
-	if err != nil {
-		log.Printf("failed to write user response: %s\n", err.Error())
-	}
+// userCursor is the position in a list of users ordered by ID.
+type userCursor struct {
+	After int `json:"after"`
 }
 
-func (e *Endpoint) GetFollowedByForUser(w http.ResponseWriter, r *http.Request) {
+// listUsers writes a page of the list of users returned by list for the user in the request path.
+func (e *Endpoint) listUsers(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, id, after, limit int) ([]*types.User, error)) {
 	params := mux.Vars(r)
 
 	id, err := strconv.Atoi(params["id"])
@@ -171,39 +168,35 @@ func (e *Endpoint) GetFollowedByForUser(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	limit := httputil.GetInt(r.URL.Query(), "limit", 10)
-	offset := httputil.GetInt(r.URL.Query(), "offset", 0)
-
-	result, err := e.fb.GetAllUsersFollowedBy(id, limit, offset)
+	cursor := userCursor{}
+	_, err = httputil.DecodeCursor(r.URL.Query(), &cursor)
 	if err != nil {
-		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToGetFollowers, "")
+		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid cursor")
 		return
 	}
 
-	err = httputil.JsonEncode(w, result)
-	if err != nil {
-		log.Printf("failed to write user response: %s\n", err.Error())
-	}
-}
-
-func (e *Endpoint) GetFriends(w http.ResponseWriter, r *http.Request) {
-	params := mux.Vars(r)
+	limit := httputil.GetLimit(r.URL.Query())
 
-	id, err := strconv.Atoi(params["id"])
+	result, err := list(r.Context(), id, cursor.After, limit)
 	if err != nil {
-		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid id")
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToGetFollowers, "")
 		return
 	}
 
-	result, err := e.fb.GetFriends(id)
+	var next interface{}
+	if len(result) == limit {
+		next = userCursor{After: result[len(result)-1].ID}
+	}
+
+	page, err := httputil.NewPage(result, next)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeFailedToGetFollowers, "")
 		return
 	}
 
-	err = httputil.JsonEncode(w, result)
+	err = httputil.JsonEncode(w, page)
 	if err != nil {
-		log.Printf("failed to write user response: %s\n", err.Error())
+		log.Ctx(r.Context()).Printf("failed to write user response: %s", err.Error())
 	}
 }
 
@@ -226,7 +219,7 @@ func (e *Endpoint) FollowUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = e.follow(userID, id)
+	err = e.follow(r.Context(), userID, id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "failed to follow")
 		return
@@ -257,7 +250,7 @@ func (e *Endpoint) MultiFollowUsers(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		err = e.follow(userID, id)
+		err = e.follow(r.Context(), userID, id)
 		if err != nil {
 			continue
 		}
@@ -285,12 +278,17 @@ func (e *Endpoint) UnfollowUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = e.fb.UnfollowUser(userID, id)
+	err = e.fb.UnfollowUser(r.Context(), userID, id)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "failed to unfollow")
 		return
 	}
 
+	err = e.queue.Publish(r.Context(), pubsub.UserTopic, pubsub.NewUnfollowEvent(userID, id))
+	if err != nil {
+		log.Ctx(r.Context()).Printf("queue.Publish err: %v", err)
+	}
+
 	httputil.JsonSuccess(w)
 }
 
@@ -319,7 +317,7 @@ func (e *Endpoint) EditUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldPath, err := e.ub.GetProfileImage(userID)
+	oldPath, err := e.ub.GetProfileImage(r.Context(), userID)
 	if err != nil {
 		httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "")
 		return
@@ -333,26 +331,32 @@ func (e *Endpoint) EditUser(w http.ResponseWriter, r *http.Request) {
 
 	image := oldPath
 	if file != nil {
-		image, err = e.processProfilePicture(file)
+		image, err = e.processProfilePicture(r.Context(), file)
 		if err != nil {
+			if images.IsInvalid(err) {
+				httputil.JsonError(w, http.StatusBadRequest, httputil.ErrorCodeInvalidRequestBody, "invalid image")
+				return
+			}
+
+			log.Ctx(r.Context()).Printf("failed to store profile picture err: %v", err)
 			httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 			return
 		}
 	}
 
-	err = e.ub.UpdateUser(userID, name, bio, image)
+	err = e.ub.UpdateUser(r.Context(), userID, name, bio, image)
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "")
 		return
 	}
 
 	if image != oldPath {
-		_ = e.ib.Remove(oldPath)
+		_ = e.ib.Remove(r.Context(), oldPath)
 	}
 
-	err = e.queue.Publish(pubsub.UserTopic, pubsub.NewUserUpdateEvent(userID))
+	err = e.queue.Publish(r.Context(), pubsub.UserTopic, pubsub.NewUserUpdateEvent(userID))
 	if err != nil {
-		log.Printf("queue.Publish err: %v\n", err)
+		log.Ctx(r.Context()).Printf("queue.Publish err: %v", err)
 	}
 
 	httputil.JsonSuccess(w)
@@ -367,7 +371,13 @@ func (e *Endpoint) GetStoriesForUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s, err := e.stories.GetStoriesForUser(id, time.Now().Unix())
+	requester, ok := httputil.GetUserIDFromContext(r.Context())
+	if !ok {
+		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
+		return
+	}
+
+	s, err := e.stories.GetStoriesForUser(r.Context(), id, requester, time.Now().Unix())
 	if err != nil {
 		httputil.JsonError(w, http.StatusInternalServerError, httputil.ErrorCodeInvalidRequestBody, "invalid id")
 		return
@@ -375,33 +385,35 @@ func (e *Endpoint) GetStoriesForUser(w http.ResponseWriter, r *http.Request) {
 
 	err = httputil.JsonEncode(w, s)
 	if err != nil {
-		log.Printf("failed to write story response: %s\n", err.Error())
+		log.Ctx(r.Context()).Printf("failed to write story response: %s", err.Error())
 	}
 }
 
-func (e *Endpoint) follow(userID, id int) error {
-	err := e.fb.FollowUser(userID, id)
+func (e *Endpoint) follow(ctx context.Context, userID, id int) error {
+	err := e.fb.FollowUser(ctx, userID, id)
 	if err != nil {
 		return err
 	}
 
+	ctx = httputil.DetachedContext(ctx)
+
 	go func() {
-		err = e.queue.Publish(pubsub.UserTopic, pubsub.NewFollowerEvent(userID, id))
+		err = e.queue.Publish(ctx, pubsub.UserTopic, pubsub.NewFollowerEvent(userID, id))
 		if err != nil {
-			log.Printf("queue.Publish err: %v\n", err)
+			log.Ctx(ctx).Printf("queue.Publish err: %v", err)
 		}
 	}()
 
 	return nil
 }
 
-func (e *Endpoint) processProfilePicture(file multipart.File) (string, error) {
-	pngBytes, err := images.MultipartFileToPng(file)
+func (e *Endpoint) processProfilePicture(ctx context.Context, file multipart.File) (string, error) {
+	data, err := ioutil.ReadAll(file)
 	if err != nil {
 		return "", err
 	}
 
-	name, err := e.ib.Store(pngBytes)
+	name, err := e.ib.Store(ctx, data)
 	if err != nil {
 		return "", err
 	}
@@ -1,10 +1,13 @@
 package types
 
+import "github.com/soapboxsocial/soapbox/pkg/images"
+
 type User struct {
-	ID          int     `json:"id"`
-	DisplayName string  `json:"display_name"`
-	Username    string  `json:"username"`
-	Image       string  `json:"image"`
-	Bio         string  `json:"bio"`
-	Email       *string `json:"email,omitempty"`
+	ID          int              `json:"id"`
+	DisplayName string           `json:"display_name"`
+	Username    string           `json:"username"`
+	Image       string           `json:"image"`
+	Images      []images.Variant `json:"images,omitempty"`
+	Bio         string           `json:"bio"`
+	Email       *string          `json:"email,omitempty"`
 }
@@ -22,6 +22,13 @@ sudo yum install -y supervisor
 
 sudo yum install -y redis
 
+# provides cwebp, used to encode the webp image variants.
+sudo yum install -y libwebp-tools
+
+# ffmpeg validates and transcodes story audio.
+sudo yum localinstall -y --nogpgcheck https://download1.rpmfusion.org/free/el/rpmfusion-free-release-7.noarch.rpm
+sudo yum install -y ffmpeg
+
 rm -rf /etc/supervisord.conf
 sudo ln -s /vagrant/conf/supervisord.conf /etc/supervisord.conf
 sudo mkdir -p /etc/supervisor/conf.d/
@@ -30,6 +37,7 @@ sudo ln -s /vagrant/conf/supervisord/notifications.conf /etc/supervisor/conf.d/n
 sudo ln -s /vagrant/conf/supervisord/indexer.conf /etc/supervisor/conf.d/indexer.conf
 sudo ln -s /vagrant/conf/supervisord/rooms.conf /etc/supervisor/conf.d/rooms.conf
 sudo ln -s /vagrant/conf/supervisord/metadata.conf /etc/supervisor/conf.d/metadata.conf
+sudo ln -s /vagrant/conf/supervisord/exports.conf /etc/supervisor/conf.d/exports.conf
 
 echo 'export GOPATH="/home/vagrant/go"' >> ~/.bashrc
 echo 'export PATH="$PATH:${GOPATH//://bin:}/bin"' >> ~/.bashrc
@@ -45,7 +53,6 @@ sudo systemctl start postgresql-9.6
 sudo systemctl enable postgresql-9.6
 
 sudo su - postgres -c "psql -a -w -f /var/www/db/database.sql"
-sudo su - postgres -c "psql -t voicely -a -w -f /var/www/db/tables.sql"
 
 rm /var/lib/pgsql/9.6/data/pg_hba.conf
 ln -s /vagrant/conf/pg_hba.conf /var/lib/pgsql/9.6/data/pg_hba.conf
@@ -53,6 +60,7 @@ ln -s /vagrant/conf/pg_hba.conf /var/lib/pgsql/9.6/data/pg_hba.conf
 wget https://artifacts.elastic.co/downloads/elasticsearch/elasticsearch-7.8.1-x86_64.rpm
 wget https://artifacts.elastic.co/downloads/elasticsearch/elasticsearch-7.8.1-x86_64.rpm.sha512
 sudo rpm --install elasticsearch-7.8.1-x86_64.rpm
+sudo /usr/share/elasticsearch/bin/elasticsearch-plugin install --batch analysis-phonetic
 
 sudo rm -rf /etc/nginx/nginx.conf
 sudo ln -s /vagrant/conf/nginx.conf /etc/nginx/nginx.conf
@@ -73,10 +81,29 @@ sudo mkdir -p /cdn/stories/
 sudo chown nginx:nginx -R /cdn/stories
 sudo chmod -R 0777 /cdn/stories
 
+# exports are not placed in /cdn, they may only be downloaded through the api.
+sudo mkdir -p /data/exports/
+sudo chown nginx:nginx -R /data/exports
+sudo chmod -R 0777 /data/exports
+
+sudo mkdir -p /data/events/
+sudo chmod -R 0777 /data/events
+
+sudo mkdir -p /data/replies/
+sudo chown nginx:nginx -R /data/replies
+sudo chmod -R 0777 /data/replies
+
 cd $GOPATH/src/github.com/soapboxsocial/soapbox && sudo go build -o /usr/local/bin/soapbox main.go
 cd $GOPATH/src/github.com/soapboxsocial/soapbox/cmd/indexer && sudo go build -o /usr/local/bin/indexer main.go
 cd $GOPATH/src/github.com/soapboxsocial/soapbox/cmd/rooms && sudo go build -o /usr/local/bin/rooms main.go
 cd $GOPATH/src/github.com/soapboxsocial/soapbox/cmd/stories && sudo go build -o /usr/local/bin/stories main.go
+cd $GOPATH/src/github.com/soapboxsocial/soapbox/cmd/migrate && sudo go build -o /usr/local/bin/migrate main.go
+cd $GOPATH/src/github.com/soapboxsocial/soapbox/cmd/accounts && sudo go build -o /usr/local/bin/accounts main.go
+cd $GOPATH/src/github.com/soapboxsocial/soapbox/cmd/images && sudo go build -o /usr/local/bin/images main.go
+cd $GOPATH/src/github.com/soapboxsocial/soapbox/cmd/storage && sudo go build -o /usr/local/bin/storage main.go
+
+/usr/local/bin/migrate up -c /conf/services/soapbox.toml
+/usr/local/bin/images -c /conf/services/images.toml
 
 crontab /vagrant/conf/crontab
 